@@ -6,6 +6,16 @@
 // -- Donor Count
 // -- Donation Analysis
 // -- Average Donations
+// -- Donor Lifecycle
+// -- Donor Cohorts
+// -- Dollar Retention
+// -- Gift Range Chart
+// -- Campaigns
+//
+// The -households flag combines the giving of the payees in each household
+// of households.xlsx, so a household is counted as one donor.  The
+// -mailings flag names a spreadsheet of the people each appeal was mailed
+// to, and the Campaigns tab then includes the response rates.
 //
 // Author: William Shaffer
 //
@@ -20,10 +30,19 @@ package main
 
 import (
 	a "acorn_go/pkg/accounting"
+	"acorn_go/pkg/campaigns"
 	dn "acorn_go/pkg/donations"
+	dns "acorn_go/pkg/donors"
+	"acorn_go/pkg/identity"
+	l "acorn_go/pkg/logging"
+	"acorn_go/pkg/rules"
 	s "acorn_go/pkg/spreadsheet"
 	sp "acorn_go/pkg/support"
+	"errors"
+	"flag"
 	"fmt"
+	"os"
+	"strconv"
 )
 
 // ----------------------------------------------------------------------------
@@ -36,6 +55,9 @@ import (
 
 const inputFile = "/home/bozo/golang/acorn_go/data/donations.xlsx"
 const tab = "Worksheet"
+const householdFile = "/home/bozo/golang/acorn_go/data/households.xlsx"
+const tabHouseholds = "Sheet1"
+const tabMailings = "Sheet1"
 const outputFile = "/home/bozo/Downloads/analysis.xlsx"
 
 // Output tabs
@@ -43,43 +65,99 @@ const (
 	donorCount       = "Donor Count"
 	donationAnalysis = "Donation Analysis"
 	averageDonations = "Average Donations"
+	donorLifecycle   = "Donor Lifecycle"
+	donorCohorts     = "Donor Cohorts"
+	dollarRetention  = "Dollar Retention"
+	giftRangeChart   = "Gift Range Chart"
+	campaignAnalysis = "Campaigns"
 )
 
 // ----------------------------------------------------------------------------
 // Functions
 // ----------------------------------------------------------------------------
 
-// main supervises the processing of the donation data.
+// main runs the program and exits with its status.
 func main() {
+	os.Exit(sp.Run("analyze", run))
+}
+
+// run supervises the processing of the donation data.
+func run() (err error) {
 	var sprdsht s.Spreadsheet
 	var donationList dn.DonationList
-	var err error
 	var output s.SpreadsheetFile
+	var ranges []dn.GiftRange
+	var config campaigns.Config
+	var password string
 
+	var byHousehold = flag.Bool("households", false, "combine the giving of the payees in each household")
+	var mailingFile = flag.String("mailings", "", "spreadsheet of the people each appeal was mailed to")
+	flag.Parse()
 	printHeader()
 	//
+	// Obtain password for the output spreadsheet
+	//
+	password, err = s.OutputPassword()
+	if err != nil {
+		return sp.Wrap(err, sp.Usage, "obtaining spreadsheet password")
+	}
+	//
 	// Obtain spreadsheet data
 	//
 	sprdsht, err = s.ProcessData(inputFile, tab)
-	sp.Check(err, "Error processing spreadsheet: ")
+	if err != nil {
+		return err
+	}
 	//
 	// Obtain donation list
 	//
 	donationList, err = dn.NewDonationList(&sprdsht)
-	sp.Check(err, "Error generating donor list: ")
+	if err != nil {
+		return err
+	}
+	if *byHousehold {
+		var households dns.HouseholdList
+		households, err = dns.ReadHouseholds(householdFile, tabHouseholds)
+		if err != nil {
+			return err
+		}
+		fmt.Println("Number of households: " + strconv.Itoa(households.Count()))
+		donationList = donationList.ByHousehold(&households)
+	}
+	//
+	// Obtain the gift ranges
+	//
+	ranges, err = dn.ConfiguredGiftRanges()
+	if err != nil {
+		return err
+	}
+	//
+	// Obtain the campaigns
+	//
+	config, err = campaigns.Active()
+	if err != nil {
+		return err
+	}
+	var campaignResults = dn.ComputeCampaigns(donationList, config.Names())
+	if *mailingFile != "" {
+		err = addMailings(&campaignResults, donationList, *mailingFile)
+		if err != nil {
+			return err
+		}
+	}
 	//
 	// Open the output spreadsheet
 	//
 	output, err = s.New(outputFile, donorCount)
-	sp.Check(err, "Error opening output file: ")
+	if err != nil {
+		return sp.WrapFile(err, sp.Output, "opening output file", outputFile)
+	}
+	output.SetPassword(password)
 	//
 	// Defer function to close spreadsheet
 	//
 	var finish = func() {
-		err = output.Save()
-		sp.Check(err, "Error saving output file: ")
-		err = output.Close()
-		sp.Check(err, "Error closing output file: ")
+		err = errors.Join(err, output.Save(), output.Close())
 	}
 	defer finish()
 	//
@@ -91,20 +169,105 @@ func main() {
 	// Output the donations
 	//
 	output, err = output.AddSheet(donationAnalysis)
-	sp.Check(err, "Error adding sheet: ")
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
 	var donationAnalysis = dn.ComputeDonations(donationList)
 	outputDonations(donationAnalysis, &output)
 	//
 	// Output average donations
 	//
 	output, err = output.AddSheet(averageDonations)
-	sp.Check(err, "Error adding sheet: ")
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
 	var dac = dn.NewDonationsAndCounts(donationAnalysis, donorCountAnalysis)
 	outputAvgDonations(&dac, &output)
 	//
+	// Output the donor lifecycle
+	//
+	output, err = output.AddSheet(donorLifecycle)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
+	var lifecycle = dn.ComputeLifecycle(donationList)
+	outputLifecycle(&lifecycle, &output)
+	//
+	// Output the donor cohorts
+	//
+	output, err = output.AddSheet(donorCohorts)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
+	var cohorts = dn.ComputeCohorts(donationList)
+	outputCohorts(&cohorts, &output)
+	//
+	// Output the dollar retention
+	//
+	output, err = output.AddSheet(dollarRetention)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
+	var retention = dn.ComputeDollarRetention(donationList)
+	outputDollarRetention(&retention, &output)
+	//
+	// Output the gift range chart
+	//
+	output, err = output.AddSheet(giftRangeChart)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
+	var chart = dn.ComputeGiftRangeChart(donationList, ranges)
+	outputGiftRangeChart(&chart, &output)
+	//
+	// Output the campaigns
+	//
+	output, err = output.AddSheet(campaignAnalysis)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
+	outputCampaigns(&campaignResults, &output)
+	//
+	// Output the donations excluded by the rules
+	//
+	output, err = output.AddSheet(rules.AuditTab)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
+	rules.OutputAudit(&output)
+	//
 	// Print completion notice
 	//
 	printFooter()
+	return err
+}
+
+// addMailings adds the appeal mailings in the mailing list spreadsheet to
+// the campaign analysis.  The names are matched to the donors.  A name that
+// matches no donor, or only resembles one, is counted as mailed but not as
+// a responder.
+func addMailings(analysis *dn.CampaignAnalysis, donationList dn.DonationList, fileName string) error {
+	var mailings, err = campaigns.ReadMailings(fileName, tabMailings)
+	if err != nil {
+		return err
+	}
+	var resolver = identity.NewResolver(donationList.DonorKeys(), make(identity.AliasTable))
+	for _, mailing := range mailings {
+		var keys = []string{}
+		var unmatched = []string{}
+		for _, name := range mailing.Names() {
+			var match = resolver.Resolve(name)
+			if !match.Resolved() {
+				l.Warn("mailing name is not a donor", "name", name, "campaign", mailing.Campaign(), l.File(fileName))
+				unmatched = append(unmatched, name)
+				continue
+			}
+			keys = append(keys, match.Key())
+		}
+		analysis.AddMailing(mailing.Campaign(), mailing.FiscalYear(), keys, unmatched)
+	}
+	fmt.Println("Number of appeal mailings: " + strconv.Itoa(len(mailings)))
+	return err
 }
 
 // ----------------------------------------------------------------------------
@@ -234,3 +397,279 @@ func outputAvgDonations(
 	s.WriteCell(output, "A", row, "Total Average Donations")
 	s.WriteCellFloat(output, "B", row, dac.TotalAvgDonation())
 }
+
+// outputLifecycle produces the donor lifecycle tab.  The first table counts
+// the donors in each segment.  The tables that follow show the flows from
+// the segments of the prior fiscal year to the segments of each fiscal year.
+func outputLifecycle(
+	lifecycle *dn.Lifecycle,
+	output *s.SpreadsheetFile) {
+	var row int = 1
+	//
+	// Place Title
+	//
+	s.WriteCell(output, "A", row, "Donor Lifecycle")
+	//
+	// Place Segment Counts
+	//
+	row += 2
+	s.WriteCell(output, "A", row, "Fiscal Year")
+	for index, segment := range dn.Segments[dn.New:] {
+		s.WriteCell(output, s.ColumnLetter(index+1), row, segment.String())
+	}
+	row++
+	for _, fy := range a.FYIndicators {
+		s.WriteCell(output, "A", row, fy.String())
+		for index, segment := range dn.Segments[dn.New:] {
+			s.WriteCellInt(output, s.ColumnLetter(index+1), row, lifecycle.Count(fy, segment))
+		}
+		row++
+	}
+	//
+	// Place Segment Flows
+	//
+	for _, fy := range a.FYIndicators {
+		row += 2
+		s.WriteCell(output, "A", row, "Flows into "+fy.String())
+		row++
+		s.WriteCell(output, "A", row, "Prior Year Segment")
+		for index, segment := range dn.Segments {
+			s.WriteCell(output, s.ColumnLetter(index+1), row, segment.String())
+		}
+		row++
+		for _, from := range dn.Segments {
+			s.WriteCell(output, "A", row, from.String())
+			for index, to := range dn.Segments {
+				s.WriteCellInt(output, s.ColumnLetter(index+1), row, lifecycle.Flow(fy, from, to))
+			}
+			row++
+		}
+	}
+}
+
+// outputCohorts produces the donor cohort tab.  Each row is the cohort of
+// donors whose first gift was made in a fiscal year.  The first table shows
+// the percent of the cohort giving in each later fiscal year and the second
+// shows the amount the cohort gave.
+func outputCohorts(
+	cohorts *dn.CohortAnalysis,
+	output *s.SpreadsheetFile) {
+	var row int = 1
+	//
+	// Place Title
+	//
+	s.WriteCell(output, "A", row, "Donor Cohort Analysis")
+	//
+	// Place Retention
+	//
+	row += 2
+	s.WriteCell(output, "A", row, "Retention Percent")
+	row = outputCohortHeadings(output, row+1)
+	for _, cohort := range dn.Cohorts {
+		s.WriteCell(output, "A", row, cohort.String())
+		s.WriteCellInt(output, "B", row, cohorts.Size(cohort))
+		for index, fy := range a.FYIndicators {
+			if cohort.Includes(fy) {
+				s.WriteCellFloat(output, s.ColumnLetter(index+2), row, cohorts.Retention(cohort, fy))
+			}
+		}
+		row++
+	}
+	//
+	// Place Revenue
+	//
+	row += 2
+	s.WriteCell(output, "A", row, "Donations")
+	row = outputCohortHeadings(output, row+1)
+	for _, cohort := range dn.Cohorts {
+		s.WriteCell(output, "A", row, cohort.String())
+		s.WriteCellInt(output, "B", row, cohorts.Size(cohort))
+		for index, fy := range a.FYIndicators {
+			if cohort.Includes(fy) {
+				s.WriteCellDecimal(output, s.ColumnLetter(index+2), row, cohorts.Donations(cohort, fy))
+			}
+		}
+		row++
+	}
+}
+
+// outputCohortHeadings places the headings of a cohort table and returns
+// the next row.
+func outputCohortHeadings(output *s.SpreadsheetFile, row int) int {
+	s.WriteCell(output, "A", row, "First Gift")
+	s.WriteCell(output, "B", row, "Donors")
+	for index, fy := range a.FYIndicators {
+		s.WriteCell(output, s.ColumnLetter(index+2), row, fy.String())
+	}
+	return row + 1
+}
+
+// outputDollarRetention produces the dollar retention tab.  The first table
+// breaks the donors who gave in both fiscal years into upgraded, same, and
+// downgraded.  The second table is the gain and loss of giving from the
+// prior fiscal year by category.
+func outputDollarRetention(
+	retention *dn.DollarRetention,
+	output *s.SpreadsheetFile) {
+	var row int = 1
+	//
+	// Place Title
+	//
+	s.WriteCell(output, "A", row, "Dollar Retention Analysis")
+	//
+	// Place Retained Donors
+	//
+	row += 2
+	s.WriteCell(output, "A", row, "Fiscal Year")
+	var column = 1
+	for _, change := range dn.RetainedChanges {
+		s.WriteCell(output, s.ColumnLetter(column), row, change.String()+" Donors")
+		s.WriteCell(output, s.ColumnLetter(column+1), row, change.String()+" Prior Year")
+		s.WriteCell(output, s.ColumnLetter(column+2), row, change.String()+" Current Year")
+		column += 3
+	}
+	s.WriteCell(output, s.ColumnLetter(column), row, "Revenue Retention Percent")
+	row++
+	for _, fy := range a.FYIndicators {
+		s.WriteCell(output, "A", row, fy.String())
+		column = 1
+		for _, change := range dn.RetainedChanges {
+			s.WriteCellInt(output, s.ColumnLetter(column), row, retention.Count(fy, change))
+			s.WriteCellDecimal(output, s.ColumnLetter(column+1), row, retention.Prior(fy, change))
+			s.WriteCellDecimal(output, s.ColumnLetter(column+2), row, retention.Current(fy, change))
+			column += 3
+		}
+		s.WriteCellFloat(output, s.ColumnLetter(column), row, retention.RevenueRetention(fy))
+		row++
+	}
+	//
+	// Place Gain and Loss
+	//
+	row += 2
+	s.WriteCell(output, "A", row, "Gain and Loss")
+	row++
+	s.WriteCell(output, "A", row, "Fiscal Year")
+	for index, change := range dn.GainLossChanges {
+		s.WriteCell(output, s.ColumnLetter(index+1), row, change.String())
+	}
+	column = len(dn.GainLossChanges) + 1
+	s.WriteCell(output, s.ColumnLetter(column), row, "Gains")
+	s.WriteCell(output, s.ColumnLetter(column+1), row, "Losses")
+	s.WriteCell(output, s.ColumnLetter(column+2), row, "Net")
+	row++
+	for _, fy := range a.FYIndicators {
+		s.WriteCell(output, "A", row, fy.String())
+		for index, change := range dn.GainLossChanges {
+			s.WriteCellDecimal(output, s.ColumnLetter(index+1), row, retention.GainLoss(fy, change))
+		}
+		s.WriteCellDecimal(output, s.ColumnLetter(column), row, retention.Gains(fy))
+		s.WriteCellDecimal(output, s.ColumnLetter(column+1), row, retention.Losses(fy))
+		s.WriteCellDecimal(output, s.ColumnLetter(column+2), row, retention.Net(fy))
+		row++
+	}
+	//
+	// Place Donor Counts
+	//
+	row += 2
+	s.WriteCell(output, "A", row, "Donors")
+	row++
+	s.WriteCell(output, "A", row, "Fiscal Year")
+	for index, change := range dn.GainLossChanges {
+		s.WriteCell(output, s.ColumnLetter(index+1), row, change.String())
+	}
+	row++
+	for _, fy := range a.FYIndicators {
+		s.WriteCell(output, "A", row, fy.String())
+		for index, change := range dn.GainLossChanges {
+			s.WriteCellInt(output, s.ColumnLetter(index+1), row, retention.Count(fy, change))
+		}
+		row++
+	}
+}
+
+// outputGiftRangeChart produces the gift range chart tab with a table for
+// each fiscal year.  Donors are placed in a range by their total giving in
+// the fiscal year.
+func outputGiftRangeChart(
+	chart *dn.GiftRangeChart,
+	output *s.SpreadsheetFile) {
+	var row int = 1
+	//
+	// Place Title
+	//
+	s.WriteCell(output, "A", row, "Gift Range Chart")
+	//
+	// Place a table for each fiscal year
+	//
+	for _, fy := range a.FYIndicators {
+		row += 2
+		s.WriteCell(output, "A", row, fy.String())
+		row++
+		s.WriteCell(output, "A", row, "Gift Range")
+		s.WriteCell(output, "B", row, "Donors")
+		s.WriteCell(output, "C", row, "Donations")
+		s.WriteCell(output, "D", row, "Percent of Donors")
+		s.WriteCell(output, "E", row, "Percent of Donations")
+		row++
+		//
+		// List the ranges from highest to lowest
+		//
+		var ranges = chart.Ranges()
+		for index := len(ranges) - 1; index >= 0; index-- {
+			s.WriteCell(output, "A", row, ranges[index].String())
+			s.WriteCellInt(output, "B", row, chart.Count(fy, index))
+			s.WriteCellDecimal(output, "C", row, chart.Amount(fy, index))
+			s.WriteCellFloat(output, "D", row, chart.PercentDonors(fy, index))
+			s.WriteCellFloat(output, "E", row, chart.PercentDollars(fy, index))
+			row++
+		}
+		s.WriteCell(output, "A", row, "Total")
+		s.WriteCellInt(output, "B", row, chart.TotalCount(fy))
+		s.WriteCellDecimal(output, "C", row, chart.TotalAmount(fy))
+		row++
+	}
+}
+
+// outputCampaigns produces the campaigns tab with a table for each fiscal
+// year.  The response rate is shown only for the campaigns with a mailing.
+func outputCampaigns(
+	analysis *dn.CampaignAnalysis,
+	output *s.SpreadsheetFile) {
+	var row int = 1
+	//
+	// Place Title
+	//
+	s.WriteCell(output, "A", row, "Campaign Analysis")
+	//
+	// Place a table for each fiscal year
+	//
+	for _, fy := range a.FYIndicators {
+		row += 2
+		s.WriteCell(output, "A", row, fy.String())
+		row++
+		s.WriteCell(output, "A", row, "Campaign")
+		s.WriteCell(output, "B", row, "Donations")
+		s.WriteCell(output, "C", row, "Gifts")
+		s.WriteCell(output, "D", row, "Donors")
+		s.WriteCell(output, "E", row, "New Donors")
+		s.WriteCell(output, "F", row, "Average Gift")
+		s.WriteCell(output, "G", row, "Mailed")
+		s.WriteCell(output, "H", row, "Responders")
+		s.WriteCell(output, "I", row, "Response Rate Percent")
+		row++
+		for _, name := range analysis.Names() {
+			s.WriteCell(output, "A", row, name)
+			s.WriteCellDecimal(output, "B", row, analysis.Amount(name, fy))
+			s.WriteCellInt(output, "C", row, analysis.Gifts(name, fy))
+			s.WriteCellInt(output, "D", row, analysis.Donors(name, fy))
+			s.WriteCellInt(output, "E", row, analysis.NewDonors(name, fy))
+			s.WriteCellFloat(output, "F", row, analysis.AverageGift(name, fy))
+			if analysis.HasMailing(name, fy) {
+				s.WriteCellInt(output, "G", row, analysis.Mailed(name, fy))
+				s.WriteCellInt(output, "H", row, analysis.Responders(name, fy))
+				s.WriteCellFloat(output, "I", row, analysis.ResponseRate(name, fy))
+			}
+			row++
+		}
+	}
+}
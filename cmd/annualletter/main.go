@@ -9,6 +9,21 @@
 // -- Donors - names and addresses of donors for the specified fiscal year
 // -- Month Donors - names and address of donor who have donated in the "current month"
 // -- Two-Year Donors - names and address of donors who have donated in FY2024 or FY2025
+// -- In-Kind Gifts - the in-kind gifts of the report year with their acknowledgment
+//
+// The -households flag combines the payees in each household of
+// households.xlsx, so a household receives one letter for its combined
+// giving.
+//
+// The -inkind flag adds the gifts in inkind.xlsx, so donors of in-kind gifts
+// in the report year receive a letter that acknowledges them.
+//
+// The -softcredit flag selects donors by the matching and donor-advised fund
+// gifts in softcredits.xlsx that give them soft credit, rather than the
+// employers and funds that paid them.
+//
+// The -online flag adds the gifts in the online giving platform's export,
+// online.csv, so donors who gave online receive a letter.
 //
 // Author: William Shaffer
 //
@@ -26,8 +41,15 @@ import (
 	a "acorn_go/pkg/accounting"
 	dna "acorn_go/pkg/donations"
 	dns "acorn_go/pkg/donors"
+	"acorn_go/pkg/identity"
+	l "acorn_go/pkg/logging"
+	"acorn_go/pkg/online"
+	r "acorn_go/pkg/redact"
+	"acorn_go/pkg/rules"
 	s "acorn_go/pkg/spreadsheet"
 	sp "acorn_go/pkg/support"
+	"errors"
+	"flag"
 	"fmt"
 	"os"
 	"strconv"
@@ -47,11 +69,21 @@ const donationsFile = "/home/bozo/golang/acorn_go/data/donations.xlsx"
 const tabDonations = "Worksheet"
 const donorFile = "/home/bozo/golang/acorn_go/data/donors.xlsx"
 const tabDonors = "Sheet1"
+const householdFile = "/home/bozo/golang/acorn_go/data/households.xlsx"
+const tabHouseholds = "Sheet1"
+const aliasFile = "/home/bozo/golang/acorn_go/data/aliases.xlsx"
+const tabAliases = "Sheet1"
+const inKindFile = "/home/bozo/golang/acorn_go/data/inkind.xlsx"
+const tabInKind = "Sheet1"
+const softCreditFile = "/home/bozo/golang/acorn_go/data/softcredits.xlsx"
+const tabSoftCredits = "Sheet1"
+const onlineFile = "/home/bozo/golang/acorn_go/data/online.csv"
 
 const outputFile = "/home/bozo/Downloads/annualletter.xlsx"
 const outputTab = "Donors"
 const outputTab2 = "Month Donors"
 const outputTab3 = "Two-Year Donors"
+const outputTab4 = "In-Kind Gifts"
 
 // reportYear specifies the calendar year for donors reported in the donor tab.
 // If the Month Donors is the main focus, be sure the report year is the
@@ -62,23 +94,64 @@ const reportYear = a.Y2026
 // Functions
 // ----------------------------------------------------------------------------
 
-// main supervises the processing of the donation data.
+// main runs the program and exits with its status.
 func main() {
+	os.Exit(sp.Run("annualletter", run))
+}
+
+// run supervises the processing of the donation data.
+func run() (err error) {
 	var donationList dna.DonationList
 	var donorList dns.DonorList
-	var err error
 	var output s.SpreadsheetFile
+	var password string
+
+	var byHousehold = flag.Bool("households", false, "send one letter to each household")
+	var withInKind = flag.Bool("inkind", false, "acknowledge the in-kind gifts")
+	var bySoftCredit = flag.Bool("softcredit", false, "select donors by the gifts that give them soft credit")
+	var withOnline = flag.Bool("online", false, "add the gifts made through the online giving platform")
+	flag.Parse()
 	//
 	// Read input data
 	//
 	printHeader()
-	donorList = generateAddressList()
-	donationList = generateDonationList()
+	password, err = s.OutputPassword()
+	if err != nil {
+		return sp.Wrap(err, sp.Usage, "obtaining spreadsheet password")
+	}
+	donorList, err = generateAddressList()
+	if err != nil {
+		return err
+	}
+	donationList, err = generateDonationList(&donorList, *withInKind, *bySoftCredit, *withOnline)
+	if err != nil {
+		return err
+	}
+	if *byHousehold {
+		var households dns.HouseholdList
+		households, err = dns.ReadHouseholds(householdFile, tabHouseholds)
+		if err != nil {
+			return err
+		}
+		fmt.Println("Number of households: " + strconv.Itoa(households.Count()))
+		donorList = households.Rollup(donorList)
+		donationList = donationList.ByHousehold(&households)
+	}
 	//
 	// Create output spreadsheet
 	//
 	output, err = s.New(outputFile, outputTab)
-	sp.Check(err, "Error opening output file: ")
+	if err != nil {
+		return sp.WrapFile(err, sp.Output, "opening output file", outputFile)
+	}
+	output.SetPassword(password)
+	//
+	// Defer function to save and close spreadsheet
+	//
+	var finish = func() {
+		err = errors.Join(err, output.Save(), output.Close())
+	}
+	defer finish()
 	//
 	// Output annual donors to spreadsheet
 	//
@@ -87,27 +160,40 @@ func main() {
 	// Output month donors
 	//
 	output, err = output.AddSheet(outputTab2)
-	sp.Check(err, "Error adding Month Donor sheet: ")
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding Month Donor sheet")
+	}
 	outputMonthlyDonors(&donorList, &donationList, output)
 	//
 	// Output two-year donors
 	//
 	output, err = output.AddSheet(outputTab3)
-	sp.Check(err, "Error adding Two-Year Donor sheet: ")
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding Two-Year Donor sheet")
+	}
 	outputTwoYearDonors(&donorList, &donationList, output)
 	//
-	// Save and close
+	// Output in-kind gifts
 	//
-	err = output.Save()
-	sp.Check(err, "Error saving output file")
-	err = output.Close()
-	sp.Check(err, "Error closing output file")
+	output, err = output.AddSheet(outputTab4)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding In-Kind Gifts sheet")
+	}
+	outputInKindGifts(&donorList, &donationList, output)
+	//
+	// Output the donations excluded by the rules
+	//
+	output, err = output.AddSheet(rules.AuditTab)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
+	rules.OutputAudit(&output)
 	printFooter()
-	os.Exit(0)
+	return err
 }
 
 // generateAddressList creates the donor list with addresses
-func generateAddressList() dns.DonorList {
+func generateAddressList() (dns.DonorList, error) {
 	var sprdsht s.Spreadsheet
 	var donorList dns.DonorList
 	var err error
@@ -115,31 +201,95 @@ func generateAddressList() dns.DonorList {
 	// Obtain spreadsheet data
 	//
 	sprdsht, err = s.ProcessData(donorFile, tabDonors)
-	sp.Check(err, "Error processing spreadsheet: ")
+	if err != nil {
+		return donorList, err
+	}
 	//
 	// Generate donor list
 	//
 	donorList, err = dns.NewDonorAddressList(&sprdsht)
-	sp.Check(err, "Error generating donor list: ")
-	return donorList
+	return donorList, err
 }
 
-// generateDonationList creates the donation list
-func generateDonationList() dna.DonationList {
+// generateDonationList creates the donation list keyed by the donors in
+// the donor list that the payees match.  If withInKind is true, the in-kind
+// gifts are added before the payees are matched.  If bySoftCredit is true,
+// the gifts that give soft credit are counted for the donors with the soft
+// credit.  If withOnline is true, the online gifts are added.
+func generateDonationList(
+	donorList *dns.DonorList,
+	withInKind bool,
+	bySoftCredit bool,
+	withOnline bool) (dna.DonationList, error) {
 	var sprdsht s.Spreadsheet
 	var donationList dna.DonationList
+	var aliases identity.AliasTable
 	var err error
 	//
 	// Obtain spreadsheet data
 	//
 	sprdsht, err = s.ProcessData(donationsFile, tabDonations)
-	sp.Check(err, "Error processing spreadsheet: ")
+	if err != nil {
+		return donationList, err
+	}
 	//
 	// Obtain donation list
 	//
 	donationList, err = dna.NewDonationList(&sprdsht)
-	sp.Check(err, "Error generating donation list: ")
-	return donationList
+	if err != nil {
+		return donationList, err
+	}
+	if withInKind {
+		var count int
+		count, err = donationList.ReadInKind(inKindFile, tabInKind)
+		if err != nil {
+			return donationList, err
+		}
+		fmt.Println("Number of in-kind gifts: " + strconv.Itoa(count))
+	}
+	if bySoftCredit {
+		var count int
+		count, err = donationList.ReadSoftCredits(softCreditFile, tabSoftCredits)
+		if err != nil {
+			return donationList, err
+		}
+		fmt.Println("Number of soft credits: " + strconv.Itoa(count))
+	}
+	//
+	// Match the payees to the donors
+	//
+	aliases, err = identity.ReadAliases(aliasFile, tabAliases)
+	if err != nil {
+		return donationList, err
+	}
+	var resolver = identity.NewResolver(donorList.Keys(), aliases)
+	donationList = donationList.Resolve(&resolver)
+	if withOnline {
+		var export online.Export
+		export, err = online.ReadExport(onlineFile)
+		if err != nil {
+			return donationList, err
+		}
+		for _, transaction := range export.Resolve(donorList, &resolver) {
+			l.Warn("online donor is not a donor", "name", transaction.Name(), "row", transaction.Row(), l.File(onlineFile))
+		}
+		fmt.Println("Number of online gifts: " + strconv.Itoa(export.AddTo(donationList)))
+	}
+	if bySoftCredit {
+		donationList = donationList.BySoftCredit()
+	}
+	return donationList, err
+}
+
+// contactOf returns the donor in the donor list for a donor in the donation
+// list.  A payee that matches no donor is returned with its name only, so
+// it is not left out of the letters.
+func contactOf(donorList *dns.DonorList, donor *dns.Donor) *dns.Donor {
+	if donorList.Contains(donor.Key()) {
+		return donorList.Get(donor.Key())
+	}
+	l.Warn("payee is not in the donor address list", "payee", donor.Key(), l.File(donorFile))
+	return donor
 }
 
 // ----------------------------------------------------------------------------
@@ -189,7 +339,7 @@ func outputAnnualDonors(
 	for _, key := range keys {
 		var donor = donationList.Get(key)
 		if selectDonor(donor) {
-			donor = donorList.Get(key)
+			donor = contactOf(donorList, donor)
 			s.WriteCell(&output, "A", row, donor.Name())
 			s.WriteCell(&output, "B", row, donor.Email())
 			s.WriteCell(&output, "C", row, donor.Street())
@@ -206,9 +356,10 @@ func outputAnnualDonors(
 	fmt.Println("Number of annual donors: " + strconv.Itoa(personCount))
 }
 
-// selectDonor returns true if the donor is to be output
+// selectDonor returns true if the donor is to be output.  A donor of an
+// in-kind gift in the report year receives a letter for it.
 func selectDonor(donor *dns.Donor) bool {
-	var result = donor.IsCalDonor(reportYear)
+	var result = donor.IsCalDonor(reportYear) || len(donor.CalInKindGifts(reportYear)) > 0
 	result = result && !donor.Deceased()
 	return result
 }
@@ -240,14 +391,14 @@ func outputMonthlyDonors(
 		var donor = donationList.Get(key)
 		if selectDonor(donor) {
 			if donor.IsCurrentDonor() {
-				donor = donorList.Get(key)
+				donor = contactOf(donorList, donor)
 				s.WriteCell(&output, "A", row, donor.Name())
 				s.WriteCell(&output, "B", row, donor.Email())
 				s.WriteCell(&output, "C", row, donor.Street())
 				s.WriteCell(&output, "D", row, donor.City())
 				s.WriteCell(&output, "E", row, donor.State())
 				s.WriteCell(&output, "F", row, donor.Zip())
-				s.WriteCell(&output, "G", row, key)
+				s.WriteCell(&output, "G", row, r.Name(r.Donor, key))
 				row++
 				personCount++
 			}
@@ -287,14 +438,14 @@ func outputTwoYearDonors(
 	for _, key := range keys {
 		var donation = donationList.Get(key)
 		if selectTwoYearDonor(donation) {
-			var donor = donorList.Get(key)
+			var donor = contactOf(donorList, donation)
 			s.WriteCell(&output, "A", row, donor.Name())
 			s.WriteCell(&output, "B", row, donor.Email())
 			s.WriteCell(&output, "C", row, donor.Street())
 			s.WriteCell(&output, "D", row, donor.City())
 			s.WriteCell(&output, "E", row, donor.State())
 			s.WriteCell(&output, "F", row, donor.Zip())
-			s.WriteCell(&output, "G", row, key)
+			s.WriteCell(&output, "G", row, r.Name(r.Donor, key))
 			//
 			// Compute total two-year donation
 			var fy2024Donations = donation.Donation((a.FY2024))
@@ -317,3 +468,56 @@ func selectTwoYearDonor(donor *dns.Donor) bool {
 	result = result && !donor.Deceased()
 	return result
 }
+
+// outputInKindGifts fills the tab In-Kind Gifts with the names and addresses
+// of the donors of in-kind gifts in the report year, the description of each
+// gift, and the wording that acknowledges it.  The value of the gift is not
+// stated in the acknowledgment.
+func outputInKindGifts(
+	donorList *dns.DonorList,
+	donationList *dna.DonationList,
+	output s.SpreadsheetFile) {
+	//
+	// Insert Heading
+	//
+	var row = 1
+	s.WriteCell(&output, "A", row, "Donor Name")
+	s.WriteCell(&output, "B", row, "Email")
+	s.WriteCell(&output, "C", row, "Street")
+	s.WriteCell(&output, "D", row, "City")
+	s.WriteCell(&output, "E", row, "State")
+	s.WriteCell(&output, "F", row, "Zip")
+	s.WriteCell(&output, "G", row, "Date")
+	s.WriteCell(&output, "H", row, "Description")
+	s.WriteCell(&output, "I", row, "Acknowledgment")
+	row++
+	//
+	// Process donors
+	//
+	var giftCount = 0
+	var keys = donationList.DonorKeys()
+	for _, key := range keys {
+		var donation = donationList.Get(key)
+		if donation.Deceased() {
+			continue
+		}
+		for _, gift := range donation.CalInKindGifts(reportYear) {
+			var donor = contactOf(donorList, donation)
+			s.WriteCell(&output, "A", row, donor.Name())
+			s.WriteCell(&output, "B", row, donor.Email())
+			s.WriteCell(&output, "C", row, donor.Street())
+			s.WriteCell(&output, "D", row, donor.City())
+			s.WriteCell(&output, "E", row, donor.State())
+			s.WriteCell(&output, "F", row, donor.Zip())
+			s.WriteCellDate(&output, "G", row, gift.Date())
+			s.WriteCell(&output, "H", row, gift.Description())
+			s.WriteCell(&output, "I", row, gift.Acknowledgment())
+			row++
+			giftCount++
+		}
+	}
+	//
+	// Output the gift count
+	//
+	fmt.Println("Number of in-kind gifts acknowledged: " + strconv.Itoa(giftCount))
+}
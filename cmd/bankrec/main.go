@@ -0,0 +1,301 @@
+// ----------------------------------------------------------------------------
+//
+// Bank deposit reconciliation
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+// This program confirms that the money recorded in Quickbooks as received
+// into the operating account reached the bank.  It matches the deposits in
+// an OFX or QFX bank statement download with the payments and deposits in
+// the donation spreadsheet and the deposits in the accounts payable
+// register.  The -file flag names the statement.  This program produces a
+// spreadsheet file bankrec.xlsx with tabs:
+// -- Matched: the bank deposits and the book entries they received
+// -- Unmatched Bank: the bank deposits with no book entries
+// -- Unmatched Book: the book entries not found in a bank deposit
+
+package main
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"acorn_go/pkg/bank"
+	q "acorn_go/pkg/quickbooks"
+	r "acorn_go/pkg/redact"
+	s "acorn_go/pkg/spreadsheet"
+	sp "acorn_go/pkg/support"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	dec "github.com/shopspring/decimal"
+)
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const (
+	inputFile        = "/home/bozo/golang/acorn_go/data/donations.xlsx"
+	tab              = "Worksheet"
+	statementFile    = "/home/bozo/golang/acorn_go/data/statement.qfx"
+	outputFileName   = "/home/bozo/Downloads/bankrec.xlsx"
+	matchedTab       = "Matched"
+	unmatchedBankTab = "Unmatched Bank"
+	unmatchedBookTab = "Unmatched Book"
+)
+
+// ----------------------------------------------------------------------------
+// Functions
+// ----------------------------------------------------------------------------
+
+// main runs the program and exits with its status.
+func main() {
+	os.Exit(sp.Run("bankrec", run))
+}
+
+// run supervises the execution of this program.  It produces a spreadsheet
+// with the reconciliation of the bank deposits.
+func run() error {
+	var sprdsht s.Spreadsheet
+	var statement bank.Statement
+	var entries []bank.Entry
+	var apTranlist q.TransList
+	var password string
+	var err error
+
+	var fileFlag = flag.String("file", statementFile, "OFX or QFX bank statement download")
+	flag.Parse()
+	printHeader()
+	//
+	// Obtain password for the output spreadsheet
+	//
+	password, err = s.OutputPassword()
+	if err != nil {
+		return sp.Wrap(err, sp.Usage, "obtaining spreadsheet password")
+	}
+	//
+	// Obtain the bank statement
+	//
+	statement, err = bank.ReadStatement(*fileFlag)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Statement period: " + statement.Start().String() + " to " + statement.End().String())
+	fmt.Println("Number of bank deposits: " + strconv.Itoa(len(statement.Deposits())))
+	//
+	// Obtain the book entries from the donations and accounts payable
+	//
+	sprdsht, err = s.ProcessData(inputFile, tab)
+	if err != nil {
+		return err
+	}
+	entries, err = bank.ReadDonationEntries(&sprdsht)
+	if err != nil {
+		return err
+	}
+	apTranlist, err = q.ReadAPTransactions()
+	if err != nil {
+		return err
+	}
+	entries = append(entries, bank.APEntries(&apTranlist)...)
+	//
+	// Output the reconciliation
+	//
+	var reconciliation = bank.Reconcile(&statement, entries)
+	err = outputReport(&reconciliation, password)
+	if err != nil {
+		return err
+	}
+	printFooter()
+	return err
+}
+
+// ----------------------------------------------------------------------------
+// Print Functions
+// ----------------------------------------------------------------------------
+
+// printHeader prints a message indicating the program has started
+func printHeader() {
+	fmt.Println("-----------------------------------------------------------")
+	fmt.Println("Acorn Scholarship Fund Bank Deposit Reconciliation")
+	fmt.Println("-----------------------------------------------------------")
+}
+
+// printFooter reports the end of the program
+func printFooter() {
+	fmt.Println("-----------------------------------------------------------")
+	fmt.Println("Program has ended")
+	fmt.Println("-----------------------------------------------------------")
+}
+
+// ----------------------------------------------------------------------------
+// Output Functions
+// ----------------------------------------------------------------------------
+
+// outputReport produces a spreadsheet with the matched deposits, the
+// unmatched bank deposits, and the unmatched book entries.  The spreadsheet
+// is encrypted with the password.
+func outputReport(reconciliation *bank.Reconciliation, password string) (err error) {
+	var output s.SpreadsheetFile
+	//
+	// Create output spreadsheet
+	//
+	output, err = s.New(outputFileName, matchedTab)
+	if err != nil {
+		return sp.WrapFile(err, sp.Output, "opening output file", outputFileName)
+	}
+	output.SetPassword(password)
+	var finish = func() {
+		err = errors.Join(err, output.Save(), output.Close())
+	}
+	defer finish()
+	//
+	// Output the matched and unmatched deposits and entries
+	//
+	outputMatched(&output, reconciliation.Matched())
+
+	output, err = output.AddSheet(unmatchedBankTab)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
+	outputUnmatchedBank(&output, reconciliation.UnmatchedBank())
+
+	output, err = output.AddSheet(unmatchedBookTab)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
+	outputUnmatchedBook(&output, reconciliation.UnmatchedBook())
+	return err
+}
+
+// outputMatched fills a tab with each bank deposit followed by the book
+// entries it received.
+func outputMatched(output *s.SpreadsheetFile, matches []bank.Match) {
+	//
+	// Insert Heading
+	//
+	var row = 1
+	s.WriteCell(output, "A", row, "Deposit Date")
+	s.WriteCell(output, "B", row, "Bank ID")
+	s.WriteCell(output, "C", row, "Deposit")
+	s.WriteCell(output, "D", row, "Entries")
+	s.WriteCell(output, "E", row, "Entry Date")
+	s.WriteCell(output, "F", row, "Payee")
+	s.WriteCell(output, "G", row, "Type")
+	s.WriteCell(output, "H", row, "Amount")
+	s.WriteCell(output, "I", row, "Source")
+	s.WriteCell(output, "J", row, "Row")
+	row++
+	//
+	// Insert the deposits and their entries
+	//
+	var total = dec.Zero
+	var batches = 0
+	for _, match := range matches {
+		var deposit = match.Deposit()
+		s.WriteCellDate(output, "A", row, deposit.Date())
+		s.WriteCell(output, "B", row, deposit.ID())
+		s.WriteCellDecimal(output, "C", row, deposit.Amount())
+		s.WriteCellInt(output, "D", row, len(match.Entries()))
+		for _, entry := range match.Entries() {
+			writeEntry(output, 4, row, entry)
+			row++
+		}
+		total = total.Add(deposit.Amount())
+		if match.IsBatch() {
+			batches++
+		}
+	}
+	s.WriteCell(output, "A", row, "Total")
+	s.WriteCellDecimal(output, "C", row, total)
+	fmt.Println("Number of bank deposits matched: " + strconv.Itoa(len(matches)))
+	fmt.Println("Number of batched deposits: " + strconv.Itoa(batches))
+}
+
+// outputUnmatchedBank fills a tab with the bank deposits that have no book
+// entries.
+func outputUnmatchedBank(output *s.SpreadsheetFile, deposits []bank.Transaction) {
+	//
+	// Insert Heading
+	//
+	var row = 1
+	s.WriteCell(output, "A", row, "Deposit Date")
+	s.WriteCell(output, "B", row, "Bank ID")
+	s.WriteCell(output, "C", row, "Type")
+	s.WriteCell(output, "D", row, "Name")
+	s.WriteCell(output, "E", row, "Memo")
+	s.WriteCell(output, "F", row, "Deposit")
+	row++
+	//
+	// Insert the deposits
+	//
+	var total = dec.Zero
+	for _, deposit := range deposits {
+		s.WriteCellDate(output, "A", row, deposit.Date())
+		s.WriteCell(output, "B", row, deposit.ID())
+		s.WriteCell(output, "C", row, deposit.Type())
+		s.WriteCell(output, "D", row, deposit.Name())
+		s.WriteCell(output, "E", row, deposit.Memo())
+		s.WriteCellDecimal(output, "F", row, deposit.Amount())
+		total = total.Add(deposit.Amount())
+		row++
+	}
+	s.WriteCell(output, "A", row, "Total")
+	s.WriteCellDecimal(output, "F", row, total)
+	fmt.Println("Number of bank deposits not matched: " + strconv.Itoa(len(deposits)))
+}
+
+// outputUnmatchedBook fills a tab with the book entries in the statement
+// period that were not found in a bank deposit.
+func outputUnmatchedBook(output *s.SpreadsheetFile, entries []bank.Entry) {
+	//
+	// Insert Heading
+	//
+	var row = 1
+	s.WriteCell(output, "A", row, "Entry Date")
+	s.WriteCell(output, "B", row, "Payee")
+	s.WriteCell(output, "C", row, "Type")
+	s.WriteCell(output, "D", row, "Amount")
+	s.WriteCell(output, "E", row, "Source")
+	s.WriteCell(output, "F", row, "Row")
+	row++
+	//
+	// Insert the entries
+	//
+	var total = dec.Zero
+	for _, entry := range entries {
+		writeEntry(output, 0, row, entry)
+		total = total.Add(entry.Amount())
+		row++
+	}
+	s.WriteCell(output, "A", row, "Total")
+	s.WriteCellDecimal(output, "D", row, total)
+	fmt.Println("Number of book entries not matched: " + strconv.Itoa(len(entries)))
+}
+
+// writeEntry writes the date, payee, type, amount, source, and row of a
+// book entry in six columns starting at the first column, counting from
+// zero.  The payees of donations are donors, so their names are redacted.
+func writeEntry(output *s.SpreadsheetFile, first int, row int, entry bank.Entry) {
+	var payee = entry.Payee()
+	if entry.Source() == bank.SourceDonations {
+		payee = r.Name(r.Donor, payee)
+	}
+	s.WriteCellDate(output, s.ColumnLetter(first), row, entry.Date())
+	s.WriteCell(output, s.ColumnLetter(first+1), row, payee)
+	s.WriteCell(output, s.ColumnLetter(first+2), row, entry.Type())
+	s.WriteCellDecimal(output, s.ColumnLetter(first+3), row, entry.Amount())
+	s.WriteCell(output, s.ColumnLetter(first+4), row, entry.Source())
+	if entry.Row() > 0 {
+		s.WriteCellInt(output, s.ColumnLetter(first+5), row, entry.Row())
+	}
+}
@@ -0,0 +1,274 @@
+// ----------------------------------------------------------------------------
+//
+// Workbook comparison
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package main
+
+// This file compares the rows of two sheets.  Rows are matched either by
+// position or by the value in a key column.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"strconv"
+	"strings"
+
+	s "acorn_go/pkg/spreadsheet"
+
+	dec "github.com/shopspring/decimal"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// ChangeKind identifies how a row or cell differs between the workbooks.
+type ChangeKind string
+
+// Difference is a single difference between the old and new workbooks.
+// For added and removed rows, the values are the row's cells joined with
+// a separator.
+type Difference struct {
+	Sheet  string
+	Kind   ChangeKind
+	Row    string
+	Column string
+	Old    string
+	New    string
+}
+
+// keyedRows holds the rows of a sheet indexed by key in their original
+// order.
+type keyedRows struct {
+	keys     []string
+	rows     map[string][]string
+	headings []string
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const (
+	Added   ChangeKind = "Added"
+	Removed ChangeKind = "Removed"
+	Changed ChangeKind = "Changed"
+)
+
+// Separator between cell values of an added or removed row
+const separator = " | "
+
+// ----------------------------------------------------------------------------
+// Functions
+// ----------------------------------------------------------------------------
+
+// CompareSheets returns the differences between the old and new rows of a
+// sheet.  If the key is not empty and both sheets have a heading row
+// containing the key, rows are matched by the value in the key column and
+// cells by heading.  Otherwise, rows and cells are matched by position.
+func CompareSheets(sheet string, oldRows [][]string, newRows [][]string, key string) []Difference {
+	var differences []Difference
+
+	var oldHeader = headerRow(oldRows, key)
+	var newHeader = headerRow(newRows, key)
+	if key != "" && oldHeader >= 0 && newHeader >= 0 {
+		differences = compareByKey(sheet,
+			indexRows(oldRows, oldHeader, key),
+			indexRows(newRows, newHeader, key))
+	} else {
+		differences = compareByPosition(sheet, oldRows, newRows)
+	}
+	return differences
+}
+
+// compareByPosition compares the sheets cell by cell.
+func compareByPosition(sheet string, oldRows [][]string, newRows [][]string) []Difference {
+	var differences []Difference
+	var numRows = max(len(oldRows), len(newRows))
+
+	for row := 0; row < numRows; row++ {
+		var rowName = strconv.Itoa(row + 1)
+		switch {
+		case row >= len(oldRows):
+			if !isEmpty(newRows[row]) {
+				differences = append(differences,
+					Difference{sheet, Added, rowName, "", "", join(newRows[row])})
+			}
+		case row >= len(newRows):
+			if !isEmpty(oldRows[row]) {
+				differences = append(differences,
+					Difference{sheet, Removed, rowName, "", join(oldRows[row]), ""})
+			}
+		default:
+			var numColumns = max(len(oldRows[row]), len(newRows[row]))
+			for column := 0; column < numColumns; column++ {
+				var oldValue = value(oldRows[row], column)
+				var newValue = value(newRows[row], column)
+				if !equal(oldValue, newValue) {
+					var cell = s.CellName(s.ColumnLetter(column), row+1)
+					differences = append(differences,
+						Difference{sheet, Changed, rowName, cell, oldValue, newValue})
+				}
+			}
+		}
+	}
+	return differences
+}
+
+// compareByKey compares rows with the same key heading by heading.
+func compareByKey(sheet string, oldRows keyedRows, newRows keyedRows) []Difference {
+	var differences []Difference
+	var headings = unionHeadings(oldRows.headings, newRows.headings)
+	//
+	// Removed and changed rows in the order of the old sheet
+	//
+	for _, key := range oldRows.keys {
+		var oldRow = oldRows.rows[key]
+		var newRow, found = newRows.rows[key]
+		if !found {
+			differences = append(differences,
+				Difference{sheet, Removed, key, "", join(oldRow), ""})
+			continue
+		}
+		for _, heading := range headings {
+			var oldValue = valueByHeading(oldRow, oldRows.headings, heading)
+			var newValue = valueByHeading(newRow, newRows.headings, heading)
+			if !equal(oldValue, newValue) {
+				differences = append(differences,
+					Difference{sheet, Changed, key, heading, oldValue, newValue})
+			}
+		}
+	}
+	//
+	// Added rows in the order of the new sheet
+	//
+	for _, key := range newRows.keys {
+		if _, found := oldRows.rows[key]; !found {
+			differences = append(differences,
+				Difference{sheet, Added, key, "", "", join(newRows.rows[key])})
+		}
+	}
+	return differences
+}
+
+// ----------------------------------------------------------------------------
+// Support Functions
+// ----------------------------------------------------------------------------
+
+// headerRow returns the index of the first row with a cell equal to the
+// key.  If there is no such row, -1 is returned.
+func headerRow(rows [][]string, key string) int {
+	var result = -1
+	if key != "" {
+		for index, row := range rows {
+			if position(row, key) >= 0 {
+				result = index
+				break
+			}
+		}
+	}
+	return result
+}
+
+// indexRows indexes the rows below the header row by the value in the key
+// column.  Rows with an empty key, such as totals, are skipped.  Duplicate
+// keys are numbered so every row can be compared.
+func indexRows(rows [][]string, header int, key string) keyedRows {
+	var result = keyedRows{
+		keys:     []string{},
+		rows:     make(map[string][]string),
+		headings: rows[header],
+	}
+	var keyColumn = position(rows[header], key)
+	var counts = make(map[string]int)
+
+	for _, row := range rows[header+1:] {
+		var name = value(row, keyColumn)
+		if name == "" {
+			continue
+		}
+		counts[name]++
+		if counts[name] > 1 {
+			name = name + " #" + strconv.Itoa(counts[name])
+		}
+		result.keys = append(result.keys, name)
+		result.rows[name] = row
+	}
+	return result
+}
+
+// unionHeadings returns the old headings followed by any new headings.
+func unionHeadings(oldHeadings []string, newHeadings []string) []string {
+	var headings = []string{}
+	for _, list := range [][]string{oldHeadings, newHeadings} {
+		for _, heading := range list {
+			heading = strings.TrimSpace(heading)
+			if heading != "" && position(headings, heading) < 0 {
+				headings = append(headings, heading)
+			}
+		}
+	}
+	return headings
+}
+
+// position returns the index of the value in the row or -1 if the row
+// does not contain the value.
+func position(row []string, target string) int {
+	var result = -1
+	for index, cell := range row {
+		if strings.TrimSpace(cell) == target {
+			result = index
+			break
+		}
+	}
+	return result
+}
+
+// value returns the trimmed value of a cell.  Cells beyond the end of a
+// truncated row are empty.
+func value(row []string, column int) string {
+	var result = ""
+	if column >= 0 && column < len(row) {
+		result = strings.TrimSpace(row[column])
+	}
+	return result
+}
+
+// valueByHeading returns the value in the column with the heading.
+func valueByHeading(row []string, headings []string, heading string) string {
+	return value(row, position(headings, heading))
+}
+
+// equal returns true if the values are the same.  Numbers that differ only
+// in formatting, such as 1500 and 1,500.00, are equal.
+func equal(oldValue string, newValue string) bool {
+	var result = oldValue == newValue
+	if !result {
+		var oldNumber, oldErr = dec.NewFromString(strings.ReplaceAll(oldValue, ",", ""))
+		var newNumber, newErr = dec.NewFromString(strings.ReplaceAll(newValue, ",", ""))
+		result = oldErr == nil && newErr == nil && oldNumber.Equal(newNumber)
+	}
+	return result
+}
+
+// isEmpty returns true if every cell in the row is empty.
+func isEmpty(row []string) bool {
+	return strings.TrimSpace(strings.Join(row, "")) == ""
+}
+
+// join returns the cells of a row as a single string.
+func join(row []string) string {
+	var cells = make([]string, len(row))
+	for index, cell := range row {
+		cells[index] = strings.TrimSpace(cell)
+	}
+	return strings.Join(cells, separator)
+}
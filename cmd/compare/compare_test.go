@@ -0,0 +1,135 @@
+// ----------------------------------------------------------------------------
+//
+// # compare_test
+//
+// Tests for CompareSheets.
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package main
+
+import "testing"
+
+// ----------------------------------------------------------------------------
+// Tests
+// ----------------------------------------------------------------------------
+
+func TestCompareByPosition(t *testing.T) {
+	var oldRows = [][]string{
+		{"Name", "Amount"},
+		{"King, Quanisha", "4000"},
+		{"Plazas, Matthew", "3,000.00"},
+	}
+	var newRows = [][]string{
+		{"Name", "Amount"},
+		{"King, Quanisha", "3500"},
+		{"Plazas, Matthew", "3000"},
+		{"Total", "6500"},
+	}
+
+	var differences = CompareSheets("Summary", oldRows, newRows, "")
+
+	if len(differences) != 2 {
+		t.Fatalf("expected 2 differences, got %d: %v", len(differences), differences)
+	}
+	var expected = Difference{"Summary", Changed, "2", "B2", "4000", "3500"}
+	if differences[0] != expected {
+		t.Errorf("differences[0] = %v, expected %v", differences[0], expected)
+	}
+	expected = Difference{"Summary", Added, "4", "", "", "Total | 6500"}
+	if differences[1] != expected {
+		t.Errorf("differences[1] = %v, expected %v", differences[1], expected)
+	}
+}
+
+func TestCompareByKey(t *testing.T) {
+	var oldRows = [][]string{
+		{"Recipient Summary"},
+		{},
+		{"Recipient", "School", "Amount"},
+		{"King, Quanisha", "UNCW", "4000"},
+		{"Plazas, Matthew", "NC State", "3000"},
+	}
+	var newRows = [][]string{
+		{"Recipient Summary"},
+		{},
+		{"Recipient", "School", "Amount"},
+		{"Alvarez, James", "ECU", "2000"},
+		{"King, Quanisha", "UNCW", "3500"},
+	}
+
+	var differences = CompareSheets("RecipSum", oldRows, newRows, "Recipient")
+
+	var expected = []Difference{
+		{"RecipSum", Changed, "King, Quanisha", "Amount", "4000", "3500"},
+		{"RecipSum", Removed, "Plazas, Matthew", "", "Plazas, Matthew | NC State | 3000", ""},
+		{"RecipSum", Added, "Alvarez, James", "", "", "Alvarez, James | ECU | 2000"},
+	}
+	if len(differences) != len(expected) {
+		t.Fatalf("expected %d differences, got %d: %v", len(expected), len(differences), differences)
+	}
+	for index := range expected {
+		if differences[index] != expected[index] {
+			t.Errorf("differences[%d] = %v, expected %v", index, differences[index], expected[index])
+		}
+	}
+}
+
+func TestCompareMissingKey(t *testing.T) {
+	var oldRows = [][]string{{"Name"}, {"King, Quanisha"}}
+	var newRows = [][]string{{"Name"}, {"Plazas, Matthew"}}
+
+	var differences = CompareSheets("Sheet", oldRows, newRows, "Recipient")
+
+	if len(differences) != 1 || differences[0].Column != "A2" {
+		t.Errorf("expected comparison by position, got %v", differences)
+	}
+}
+
+func TestIndexRowsDuplicateKeys(t *testing.T) {
+	var rows = [][]string{
+		{"Donor", "Amount"},
+		{"Apple, Julietta", "100"},
+		{"", "Subtotal"},
+		{"Apple, Julietta", "200"},
+	}
+
+	var indexed = indexRows(rows, 0, "Donor")
+
+	var expected = []string{"Apple, Julietta", "Apple, Julietta #2"}
+	if len(indexed.keys) != len(expected) {
+		t.Fatalf("expected keys %v, got %v", expected, indexed.keys)
+	}
+	for index := range expected {
+		if indexed.keys[index] != expected[index] {
+			t.Errorf("keys[%d] = %q, expected %q", index, indexed.keys[index], expected[index])
+		}
+	}
+}
+
+func TestEqual(t *testing.T) {
+	tests := []struct {
+		name     string
+		old      string
+		new      string
+		expected bool
+	}{
+		{"same text", "Cary", "Cary", true},
+		{"different text", "Cary", "Apex", false},
+		{"formatted number", "1,500.00", "1500", true},
+		{"different number", "1500", "1501", false},
+		{"empty and zero", "", "0", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := equal(test.old, test.new); actual != test.expected {
+				t.Errorf("equal(%q, %q) = %v, expected %v", test.old, test.new, actual, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+// ----------------------------------------------------------------------------
+//
+// Workbook Comparison Program
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package main
+
+// This program compares two Excel workbooks, such as two runs of a report
+// before and after a correction in Quickbooks.  Sheets with the same name are
+// compared cell by cell.  If a key heading is supplied, rows are matched by
+// the value in that column instead of by position.  The differences are
+// printed or written to a workbook.  The workbook holds the same values as
+// the workbooks compared, so it is encrypted with the spreadsheet password.
+//
+// Usage: compare [-key heading] [-sheet name] [-out file.xlsx] old.xlsx new.xlsx
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+
+	s "acorn_go/pkg/spreadsheet"
+	sp "acorn_go/pkg/support"
+)
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const outputTab = "Differences"
+
+// ----------------------------------------------------------------------------
+// Functions
+// ----------------------------------------------------------------------------
+
+// main runs the program and exits with its status.
+func main() {
+	os.Exit(sp.Run("compare", run))
+}
+
+// run supervises the comparison of the workbooks.
+func run() (err error) {
+	var key = flag.String("key", "", "heading of the column used to match rows")
+	var sheet = flag.String("sheet", "", "name of the only sheet to compare")
+	var outputFile = flag.String("out", "", "workbook for the differences instead of text")
+	var differences []Difference
+	var password string
+	flag.Parse()
+
+	printHeader()
+	if flag.NArg() != 2 {
+		err = errors.New("usage: compare [-key heading] [-sheet name] [-out file.xlsx] old.xlsx new.xlsx")
+		return sp.Wrap(err, sp.Usage, "parsing arguments")
+	}
+	var oldFile = flag.Arg(0)
+	var newFile = flag.Arg(1)
+	//
+	// Obtain password for the output spreadsheet
+	//
+	if *outputFile != "" {
+		password, err = s.OutputPassword()
+		if err != nil {
+			return sp.Wrap(err, sp.Usage, "obtaining spreadsheet password")
+		}
+	}
+	//
+	// Compare workbooks
+	//
+	differences, err = compareWorkbooks(oldFile, newFile, *sheet, *key)
+	if err != nil {
+		return err
+	}
+	//
+	// Output differences
+	//
+	if *outputFile == "" {
+		printDifferences(differences)
+	} else {
+		err = outputDifferences(*outputFile, differences, password)
+		if err != nil {
+			return err
+		}
+		fmt.Println("Differences written to " + *outputFile)
+	}
+	printSummary(differences)
+
+	printFooter()
+	return err
+}
+
+// compareWorkbooks compares the sheets of two workbooks.  If a sheet name is
+// supplied, only that sheet is compared.
+func compareWorkbooks(oldFile string, newFile string, sheet string, key string) ([]Difference, error) {
+	var differences []Difference
+	var oldSheets, newSheets []string
+	var err error
+
+	oldSheets, err = sheetNames(oldFile)
+	if err == nil {
+		newSheets, err = sheetNames(newFile)
+	}
+	if err != nil {
+		return differences, err
+	}
+	if sheet != "" {
+		if !slices.Contains(oldSheets, sheet) && !slices.Contains(newSheets, sheet) {
+			err = errors.New("sheet is not in either workbook: " + sheet)
+			return differences, err
+		}
+		oldSheets = slices.DeleteFunc(oldSheets, func(name string) bool { return name != sheet })
+		newSheets = slices.DeleteFunc(newSheets, func(name string) bool { return name != sheet })
+	}
+	//
+	// Sheets in the old workbook
+	//
+	for _, name := range oldSheets {
+		var oldRows, newRows [][]string
+		oldRows, err = readRows(oldFile, name)
+		if err == nil && slices.Contains(newSheets, name) {
+			newRows, err = readRows(newFile, name)
+		}
+		if err != nil {
+			return differences, err
+		}
+		differences = append(differences, CompareSheets(name, oldRows, newRows, key)...)
+	}
+	//
+	// Sheets only in the new workbook
+	//
+	for _, name := range newSheets {
+		if !slices.Contains(oldSheets, name) {
+			var newRows [][]string
+			newRows, err = readRows(newFile, name)
+			if err != nil {
+				return differences, err
+			}
+			differences = append(differences, CompareSheets(name, nil, newRows, key)...)
+		}
+	}
+	return differences, err
+}
+
+// sheetNames returns the sheet names of a workbook.  Encrypted workbooks
+// are opened with the password in ACORN_SPREADSHEET_PASSWORD.
+func sheetNames(fileName string) ([]string, error) {
+	var names, err = s.SheetNames(fileName, "")
+	var password = os.Getenv(s.PasswordVariable)
+	if err != nil && password != "" {
+		names, err = s.SheetNames(fileName, password)
+	}
+	return names, err
+}
+
+// readRows returns all the rows of a sheet, including the first.  An empty
+// sheet has no rows.
+func readRows(fileName string, sheet string) ([][]string, error) {
+	var rows [][]string
+	var sprdsht, err = s.ProcessData(fileName, sheet)
+	var password = os.Getenv(s.PasswordVariable)
+	if err != nil && password != "" {
+		sprdsht, err = s.ProcessProtectedData(fileName, sheet, password)
+	}
+	if errors.Is(err, s.ErrEmpty) {
+		return rows, nil
+	}
+	if err != nil {
+		return rows, err
+	}
+	for index := 0; index < sprdsht.Size(); index++ {
+		var row, _ = sprdsht.Row(index)
+		rows = append(rows, row)
+	}
+	return rows, err
+}
+
+// ----------------------------------------------------------------------------
+// Output Functions
+// ----------------------------------------------------------------------------
+
+// printDifferences prints the differences as text.
+func printDifferences(differences []Difference) {
+	for _, diff := range differences {
+		var location = diff.Sheet + " row " + diff.Row
+		if diff.Column != "" {
+			location += " " + diff.Column
+		}
+		switch diff.Kind {
+		case Added:
+			fmt.Println("+ " + location + ": " + diff.New)
+		case Removed:
+			fmt.Println("- " + location + ": " + diff.Old)
+		case Changed:
+			fmt.Println("~ " + location + ": " + diff.Old + " -> " + diff.New)
+		}
+	}
+}
+
+// outputDifferences writes the differences to a workbook encrypted with the
+// password.
+func outputDifferences(fileName string, differences []Difference, password string) error {
+	var output, err = s.New(fileName, outputTab)
+	if err != nil {
+		return sp.WrapFile(err, sp.Output, "opening output file", fileName)
+	}
+	output.SetPassword(password)
+	var row = 1
+	s.WriteCell(&output, "A", row, "Sheet")
+	s.WriteCell(&output, "B", row, "Change")
+	s.WriteCell(&output, "C", row, "Row")
+	s.WriteCell(&output, "D", row, "Column")
+	s.WriteCell(&output, "E", row, "Old Value")
+	s.WriteCell(&output, "F", row, "New Value")
+	for _, diff := range differences {
+		row++
+		s.WriteCell(&output, "A", row, diff.Sheet)
+		s.WriteCell(&output, "B", row, string(diff.Kind))
+		s.WriteCell(&output, "C", row, diff.Row)
+		s.WriteCell(&output, "D", row, diff.Column)
+		s.WriteCell(&output, "E", row, diff.Old)
+		s.WriteCell(&output, "F", row, diff.New)
+	}
+	return errors.Join(output.Save(), output.Close())
+}
+
+// printSummary prints the number of each kind of difference.
+func printSummary(differences []Difference) {
+	var counts = make(map[ChangeKind]int)
+	for _, diff := range differences {
+		counts[diff.Kind]++
+	}
+	fmt.Println("-----------------------------------------------------------")
+	fmt.Println("Added:   " + strconv.Itoa(counts[Added]))
+	fmt.Println("Removed: " + strconv.Itoa(counts[Removed]))
+	fmt.Println("Changed: " + strconv.Itoa(counts[Changed]))
+}
+
+// printHeader places the header information at the top of the page
+func printHeader() {
+	fmt.Println("-----------------------------------------------------------")
+	fmt.Println("Acorn Scholarship Fund Workbook Comparison")
+	fmt.Println("-----------------------------------------------------------")
+}
+
+// printFooter prints the notice that the program is finished.
+func printFooter() {
+	fmt.Println("-----------------------------------------------------------")
+	fmt.Println("Program is finished")
+	fmt.Println("-----------------------------------------------------------")
+}
@@ -10,18 +10,25 @@
 
 package main
 
-// This program produces a spreadsheet of donor names and addresses.
+// This program produces a spreadsheet of donor names and addresses.  The
+// -households flag combines the payees in each household of households.xlsx,
+// so each household appears once with its mailing name and address.
 
 // ----------------------------------------------------------------------------
 // Imports
 // ----------------------------------------------------------------------------
 
 import (
+	"errors"
+	"flag"
 	"fmt"
+	"os"
 	"strconv"
 
 	a "acorn_go/pkg/accounting"
 	dn "acorn_go/pkg/donors"
+	"acorn_go/pkg/identity"
+	"acorn_go/pkg/rules"
 	s "acorn_go/pkg/spreadsheet"
 	sp "acorn_go/pkg/support"
 )
@@ -36,6 +43,12 @@ const addressListTab = "Sheet1"
 const donationFile = "/home/bozo/golang/acorn_go/data/donations.xlsx"
 const donationTab = "Worksheet"
 
+const householdFile = "/home/bozo/golang/acorn_go/data/households.xlsx"
+const householdTab = "Sheet1"
+
+const aliasFile = "/home/bozo/golang/acorn_go/data/aliases.xlsx"
+const aliasTab = "Sheet1"
+
 const outputFile = "/home/bozo/Downloads/mailing_list.xlsx"
 const outputTab = "Donors"
 const inviteTab = "Invitees"
@@ -44,29 +57,55 @@ const inviteTab = "Invitees"
 // Functions
 // ----------------------------------------------------------------------------
 
-// main supervises the processing of the donation data.
+// main runs the program and exits with its status.
 func main() {
+	os.Exit(sp.Run("donors", run))
+}
+
+// run supervises the processing of the donation data.
+func run() (err error) {
 	var donorList dn.DonorList
 	var output s.SpreadsheetFile
-	var err error
+	var password string
 
+	var byHousehold = flag.Bool("households", false, "list each household once")
+	flag.Parse()
 	printHeader()
 	//
+	// Obtain password for the output spreadsheet
+	//
+	password, err = s.OutputPassword()
+	if err != nil {
+		return sp.Wrap(err, sp.Usage, "obtaining spreadsheet password")
+	}
+	//
+	// Fetch addresses
+	//
+	donorList, err = generateDonorList()
+	if err != nil {
+		return err
+	}
+	if *byHousehold {
+		var households dn.HouseholdList
+		households, err = dn.ReadHouseholds(householdFile, householdTab)
+		if err != nil {
+			return err
+		}
+		fmt.Println("Number of households: " + strconv.Itoa(households.Count()))
+		donorList = households.Rollup(donorList)
+	}
+	//
 	// Create output spreadsheet
 	//
 	output, err = s.New(outputFile, outputTab)
-	sp.Check(err, "Error opening output file: ")
+	if err != nil {
+		return sp.WrapFile(err, sp.Output, "opening output file", outputFile)
+	}
+	output.SetPassword(password)
 	defer func() {
-		err = output.Save()
-		sp.Check(err, "Error saving output file")
-		err = output.Close()
-		sp.Check(err, "Error closing output file")
+		err = errors.Join(err, output.Save(), output.Close())
 	}()
 	//
-	// Fetch addresses
-	//
-	donorList = generateDonorList()
-	//
 	// Output mailing list
 	//
 	outputAddresses(&donorList, &output)
@@ -74,37 +113,60 @@ func main() {
 	// Output the invite list
 	//
 	output, err = output.AddSheet(inviteTab)
-	sp.Check(err, "Error adding sheet: ")
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
 	outputInviteList(&donorList, &output)
+	//
+	// Output the donations excluded by the rules
+	//
+	output, err = output.AddSheet(rules.AuditTab)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
+	rules.OutputAudit(&output)
 
 	printFooter()
+	return err
 }
 
 // generateDonorList creates the donor list
-func generateDonorList() dn.DonorList {
+func generateDonorList() (dn.DonorList, error) {
 	var sprdsht s.Spreadsheet
 	var donorList dn.DonorList
+	var aliases identity.AliasTable
 	var err error
 	//
 	// Obtain donor spreadsheet data
 	//
 	sprdsht, err = s.ProcessData(addressListFile, addressListTab)
-	sp.Check(err, "Error processing spreadsheet: ")
+	if err != nil {
+		return donorList, err
+	}
 	//
 	// Generate donor list
 	//
 	donorList, err = dn.NewDonorAddressList(&sprdsht)
-	sp.Check(err, "Error generating donor list: ")
+	if err != nil {
+		return donorList, err
+	}
 	//
 	// Obtain donation spreadsheet data
 	//
 	sprdsht, err = s.ProcessData(donationFile, donationTab)
-	sp.Check(err, "Error processing spreadsheet: ")
+	if err != nil {
+		return donorList, err
+	}
 	//
-	// Add donation data to donor list
+	// Add donation data to donor list, matching payees to donors
 	//
-	dn.AddDonations(&sprdsht, &donorList)
-	return donorList
+	aliases, err = identity.ReadAliases(aliasFile, aliasTab)
+	if err != nil {
+		return donorList, err
+	}
+	var resolver = identity.NewResolver(donorList.Keys(), aliases)
+	err = dn.AddDonations(&sprdsht, &donorList, &resolver)
+	return donorList, err
 }
 
 // ----------------------------------------------------------------------------
@@ -0,0 +1,128 @@
+// ----------------------------------------------------------------------------
+//
+// Synthetic Data Program
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package main
+
+// This program writes fictitious donations.xlsx, donors.xlsx,
+// households.xlsx, accounts_payable.xlsx, and bills.xlsx spreadsheets so
+// that the other programs can be run without the organization's data.
+// The spreadsheets are written to their own directory, so that the
+// organization's spreadsheets are not overwritten.  The program refuses to
+// write into a directory that already holds any of them unless -force is
+// given.
+//
+// Usage: generate [-dir directory] [-seed number] [-donors count] [-force]
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	sp "acorn_go/pkg/support"
+	syn "acorn_go/pkg/synthetic"
+)
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const syntheticDirectory = "/home/bozo/golang/acorn_go/synthetic"
+
+const defaultSeed = 1
+
+// ----------------------------------------------------------------------------
+// Functions
+// ----------------------------------------------------------------------------
+
+// main runs the program and exits with its status.
+func main() {
+	os.Exit(sp.Run("generate", run))
+}
+
+// run supervises the generation of the spreadsheets.
+func run() error {
+	var dir = flag.String("dir", syntheticDirectory, "directory for the generated spreadsheets")
+	var seed = flag.Uint64("seed", defaultSeed, "seed for the random number generator")
+	var donorCount = flag.Int("donors", syn.DefaultDonorCount, "number of donors")
+	var force = flag.Bool("force", false, "overwrite spreadsheets already in the directory")
+	var generator syn.Generator
+	var err error
+	flag.Parse()
+
+	printHeader()
+	//
+	// Refuse to overwrite existing spreadsheets
+	//
+	if !*force {
+		err = checkDirectory(*dir)
+		if err != nil {
+			return err
+		}
+	}
+	//
+	// Create directory
+	//
+	err = os.MkdirAll(*dir, 0o755)
+	if err != nil {
+		return sp.WrapFile(err, sp.Output, "creating directory", *dir)
+	}
+	//
+	// Generate spreadsheets
+	//
+	generator, err = syn.New(*dir, *seed, *donorCount)
+	if err != nil {
+		return sp.Wrap(err, sp.Usage, "creating generator")
+	}
+	err = generator.Generate()
+	if err != nil {
+		return err
+	}
+	fmt.Println("Spreadsheets written to " + *dir)
+
+	printFooter()
+	return err
+}
+
+// checkDirectory returns an error if the directory already holds any of the
+// spreadsheets the program writes.
+func checkDirectory(dir string) error {
+	for _, name := range syn.Files {
+		var fileName = filepath.Join(dir, name)
+		var _, err = os.Stat(fileName)
+		if err == nil {
+			return sp.WrapFile(errors.New("spreadsheet already exists; use -force to overwrite it"),
+				sp.Usage, "checking output directory", fileName)
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return sp.WrapFile(err, sp.Output, "checking output directory", fileName)
+		}
+	}
+	return nil
+}
+
+// printHeader places the header information at the top of the page
+func printHeader() {
+	fmt.Println("-----------------------------------------------------------")
+	fmt.Println("Acorn Scholarship Fund Synthetic Data")
+	fmt.Println("-----------------------------------------------------------")
+}
+
+// printFooter prints the notice that the program is finished.
+func printFooter() {
+	fmt.Println("-----------------------------------------------------------")
+	fmt.Println("Program is finished")
+	fmt.Println("-----------------------------------------------------------")
+}
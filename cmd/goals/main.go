@@ -0,0 +1,376 @@
+// ----------------------------------------------------------------------------
+//
+// Fundraising goals report
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+// This program compares the fundraising goals set by the board with the
+// actual results of the fiscal year through the date given by the -asof
+// flag, or the end of the current fiscal year.  This program produces a
+// spreadsheet file goals.xlsx with tabs:
+// -- Goals: the actual, percent of goal, and projection of each goal
+// -- Pace: the results of the prior fiscal years through the same point
+// -- Monthly: the monthly donations goals with the cumulative results
+
+package main
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	a "acorn_go/pkg/accounting"
+	dna "acorn_go/pkg/donations"
+	ds "acorn_go/pkg/donationseries"
+	dns "acorn_go/pkg/donors"
+	"acorn_go/pkg/goals"
+	"acorn_go/pkg/identity"
+	l "acorn_go/pkg/logging"
+	md "acorn_go/pkg/majordonor"
+	"acorn_go/pkg/rules"
+	s "acorn_go/pkg/spreadsheet"
+	sp "acorn_go/pkg/support"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const (
+	inputFile      = "/home/bozo/golang/acorn_go/data/donations.xlsx"
+	tab            = "Worksheet"
+	donorFile      = "/home/bozo/golang/acorn_go/data/donors.xlsx"
+	tabDonors      = "Sheet1"
+	aliasFile      = "/home/bozo/golang/acorn_go/data/aliases.xlsx"
+	tabAliases     = "Sheet1"
+	goalFile       = "/home/bozo/golang/acorn_go/data/goals.xlsx"
+	tabGoals       = "Sheet1"
+	outputFileName = "/home/bozo/Downloads/goals.xlsx"
+	goalsTab       = "Goals"
+	paceTab        = "Pace"
+	monthlyTab     = "Monthly"
+
+	fy = a.CurrentFiscalYear
+)
+
+// ----------------------------------------------------------------------------
+// Functions
+// ----------------------------------------------------------------------------
+
+// main runs the program and exits with its status.
+func main() {
+	os.Exit(sp.Run("goals", run))
+}
+
+// run supervises the execution of this program.  It produces a spreadsheet
+// comparing the goals with the actual results.
+func run() error {
+	var sprdsht s.Spreadsheet
+	var donationList dna.DonationList
+	var donationSeries ds.DonationSeries
+	var donorList dns.DonorList
+	var aliases identity.AliasTable
+	var goalList goals.Goals
+	var analysis goals.Analysis
+	var tiers []md.Tier
+	var password string
+	var asOf d.Date
+	var err error
+
+	var asOfFlag = flag.String("asof", "", "date of the report, MM/DD/YYYY (default end of "+fy.String()+")")
+	flag.Parse()
+	printHeader()
+	//
+	// Obtain the date of the report
+	//
+	asOf = fy.End()
+	if *asOfFlag != "" {
+		asOf, err = d.NewFromString(*asOfFlag)
+		if err != nil {
+			return sp.Wrap(err, sp.Usage, "parsing the as-of date")
+		}
+	}
+	//
+	// Obtain password for the output spreadsheet
+	//
+	password, err = s.OutputPassword()
+	if err != nil {
+		return sp.Wrap(err, sp.Usage, "obtaining spreadsheet password")
+	}
+	//
+	// Obtain the major donor tiers
+	//
+	tiers, err = md.ConfiguredTiers()
+	if err != nil {
+		return err
+	}
+	//
+	// Obtain the goals
+	//
+	goalList, err = goals.ReadGoals(goalFile, tabGoals)
+	if err != nil {
+		return err
+	}
+	//
+	// Obtain spreadsheet data
+	//
+	sprdsht, err = s.ProcessData(inputFile, tab)
+	if err != nil {
+		return err
+	}
+	//
+	// Generate donation list and donation series
+	//
+	donationList, err = dna.NewDonationList(&sprdsht)
+	if err != nil {
+		return err
+	}
+	donationSeries, err = ds.NewDonationSeries(&sprdsht)
+	if err != nil {
+		return err
+	}
+	//
+	// Match the payees to the donors
+	//
+	sprdsht, err = s.ProcessData(donorFile, tabDonors)
+	if err != nil {
+		return err
+	}
+	donorList, err = dns.NewDonorAddressList(&sprdsht)
+	if err != nil {
+		return err
+	}
+	aliases, err = identity.ReadAliases(aliasFile, tabAliases)
+	if err != nil {
+		return err
+	}
+	var resolver = identity.NewResolver(donorList.Keys(), aliases)
+	donationList = donationList.Resolve(&resolver)
+	//
+	// Compare the goals with the results
+	//
+	analysis, err = goals.NewAnalysis(&goalList, donationList, donationSeries, tiers, asOf)
+	if err != nil {
+		return sp.Wrap(err, sp.Usage, "analyzing goals")
+	}
+	if len(analysis.Goals()) == 0 {
+		l.Warn("goals file has no goals for the fiscal year", "fiscal year", analysis.FiscalYear().String(),
+			l.File(goalFile))
+	}
+	err = outputReport(&analysis, password)
+	if err != nil {
+		return err
+	}
+	printFooter()
+	return err
+}
+
+// ----------------------------------------------------------------------------
+// Print Functions
+// ----------------------------------------------------------------------------
+
+// printHeader prints a message indicating the program has started
+func printHeader() {
+	fmt.Println("-----------------------------------------------------------")
+	fmt.Println("Acorn Scholarship Fund Fundraising Goals")
+	fmt.Println("-----------------------------------------------------------")
+}
+
+// printFooter reports the end of the program
+func printFooter() {
+	fmt.Println("-----------------------------------------------------------")
+	fmt.Println("Program has ended")
+	fmt.Println("-----------------------------------------------------------")
+}
+
+// ----------------------------------------------------------------------------
+// Output Functions
+// ----------------------------------------------------------------------------
+
+// outputReport produces a spreadsheet with the progress toward the goals,
+// the pace of the prior years, and the monthly goals.  The spreadsheet is
+// encrypted with the password.
+func outputReport(analysis *goals.Analysis, password string) (err error) {
+	var output s.SpreadsheetFile
+	//
+	// Create output spreadsheet
+	//
+	output, err = s.New(outputFileName, goalsTab)
+	if err != nil {
+		return sp.WrapFile(err, sp.Output, "opening output file", outputFileName)
+	}
+	output.SetPassword(password)
+	var finish = func() {
+		err = errors.Join(err, output.Save(), output.Close())
+	}
+	defer finish()
+	//
+	// Output the goals, the pace, and the monthly goals
+	//
+	outputGoals(&output, analysis)
+
+	output, err = output.AddSheet(paceTab)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
+	outputPace(&output, analysis)
+
+	output, err = output.AddSheet(monthlyTab)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
+	outputMonthly(&output, analysis)
+	//
+	// Output the donations excluded by the rules
+	//
+	output, err = output.AddSheet(rules.AuditTab)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
+	rules.OutputAudit(&output)
+	return err
+}
+
+// outputGoals fills a tab with the actual, percent of goal, amount
+// remaining, and projection of each goal.
+func outputGoals(output *s.SpreadsheetFile, analysis *goals.Analysis) {
+	//
+	// Insert Heading
+	//
+	var row = 1
+	s.WriteCell(output, "A", row, analysis.FiscalYear().String()+" goals as of "+analysis.AsOf().String())
+	row += 2
+	s.WriteCell(output, "A", row, "Measure")
+	s.WriteCell(output, "B", row, "Month")
+	s.WriteCell(output, "C", row, "Campaign")
+	s.WriteCell(output, "D", row, "Goal")
+	s.WriteCell(output, "E", row, "Actual")
+	s.WriteCell(output, "F", row, "Percent of Goal")
+	s.WriteCell(output, "G", row, "Remaining")
+	s.WriteCell(output, "H", row, "Projected")
+	s.WriteCell(output, "I", row, "Projected Percent")
+	row++
+	//
+	// Insert the goals
+	//
+	var reached = 0
+	for _, goal := range analysis.Goals() {
+		writeGoal(output, row, goal)
+		s.WriteCellFloat(output, "E", row, analysis.Actual(goal))
+		s.WriteCellFloat(output, "F", row, analysis.PercentOfGoal(goal))
+		s.WriteCellFloat(output, "G", row, analysis.Remaining(goal))
+		s.WriteCellFloat(output, "H", row, analysis.Projected(goal))
+		s.WriteCellFloat(output, "I", row, analysis.ProjectedPercent(goal))
+		if analysis.Remaining(goal) == 0.0 {
+			reached++
+		}
+		row++
+	}
+	fmt.Println("Number of goals: " + strconv.Itoa(len(analysis.Goals())))
+	fmt.Println("Number of goals reached: " + strconv.Itoa(reached))
+}
+
+// outputPace fills a tab with the actual of each goal and the results of
+// each prior fiscal year through the same point and for the whole year.
+func outputPace(output *s.SpreadsheetFile, analysis *goals.Analysis) {
+	//
+	// Insert Heading
+	//
+	var row = 1
+	s.WriteCell(output, "A", row, analysis.FiscalYear().String()+" pace as of "+analysis.AsOf().String())
+	row += 2
+	s.WriteCell(output, "A", row, "Measure")
+	s.WriteCell(output, "B", row, "Month")
+	s.WriteCell(output, "C", row, "Campaign")
+	s.WriteCell(output, "D", row, "Goal")
+	s.WriteCell(output, "E", row, "Actual")
+	var column = 5
+	for _, prior := range analysis.PriorYears() {
+		var label = prior.String() + " "
+		s.WriteCell(output, s.ColumnLetter(column), row, label+"to "+analysis.SamePoint(prior).String())
+		s.WriteCell(output, s.ColumnLetter(column+1), row, label+"Pace")
+		s.WriteCell(output, s.ColumnLetter(column+2), row, label+"Final")
+		column += 3
+	}
+	row++
+	//
+	// Insert the goals with the results of the prior years
+	//
+	for _, goal := range analysis.Goals() {
+		writeGoal(output, row, goal)
+		s.WriteCellFloat(output, "E", row, analysis.Actual(goal))
+		column = 5
+		for _, prior := range analysis.PriorYears() {
+			s.WriteCellFloat(output, s.ColumnLetter(column), row, analysis.PriorToDate(goal, prior))
+			s.WriteCellFloat(output, s.ColumnLetter(column+1), row, analysis.Pace(goal, prior))
+			s.WriteCellFloat(output, s.ColumnLetter(column+2), row, analysis.PriorFinal(goal, prior))
+			column += 3
+		}
+		row++
+	}
+}
+
+// outputMonthly fills a tab with the monthly donations goals in the order of
+// the goals spreadsheet, the donations of each month, and the cumulative
+// goals and donations.
+func outputMonthly(output *s.SpreadsheetFile, analysis *goals.Analysis) {
+	//
+	// Insert Heading
+	//
+	var row = 1
+	s.WriteCell(output, "A", row, analysis.FiscalYear().String()+" monthly goals as of "+analysis.AsOf().String())
+	row += 2
+	s.WriteCell(output, "A", row, "Month")
+	s.WriteCell(output, "B", row, "Goal")
+	s.WriteCell(output, "C", row, "Actual")
+	s.WriteCell(output, "D", row, "Percent of Goal")
+	s.WriteCell(output, "E", row, "Cumulative Goal")
+	s.WriteCell(output, "F", row, "Cumulative Actual")
+	s.WriteCell(output, "G", row, "Prior Year")
+	row++
+	//
+	// Insert the monthly goals
+	//
+	var cumulativeGoal = 0.0
+	var cumulativeActual = 0.0
+	var prior = analysis.FiscalYear().Prior()
+	for _, goal := range analysis.Goals() {
+		if !goal.IsMonthly() {
+			continue
+		}
+		cumulativeGoal += goal.Amount().InexactFloat64()
+		cumulativeActual += analysis.Actual(goal)
+		s.WriteCell(output, "A", row, goal.YearMonth().String())
+		s.WriteCellDecimal(output, "B", row, goal.Amount())
+		s.WriteCellFloat(output, "C", row, analysis.Actual(goal))
+		s.WriteCellFloat(output, "D", row, analysis.PercentOfGoal(goal))
+		s.WriteCellFloat(output, "E", row, cumulativeGoal)
+		s.WriteCellFloat(output, "F", row, cumulativeActual)
+		if prior != a.OutOfRange {
+			s.WriteCellFloat(output, "G", row, analysis.PriorToDate(goal, prior))
+		}
+		row++
+	}
+}
+
+// writeGoal writes the measure, month, campaign, and amount of a goal in
+// columns A through D.
+func writeGoal(output *s.SpreadsheetFile, row int, goal goals.Goal) {
+	s.WriteCell(output, "A", row, goal.Measure().String())
+	if goal.IsMonthly() {
+		s.WriteCell(output, "B", row, goal.YearMonth().String())
+	}
+	s.WriteCell(output, "C", row, goal.Campaign())
+	s.WriteCellDecimal(output, "D", row, goal.Amount())
+}
@@ -21,9 +21,12 @@ import (
 	a "acorn_go/pkg/accounting"
 	g "acorn_go/pkg/grants"
 	q "acorn_go/pkg/quickbooks"
+	r "acorn_go/pkg/redact"
 	sp "acorn_go/pkg/spreadsheet"
 	s "acorn_go/pkg/support"
+	"errors"
 	"fmt"
+	"os"
 
 	dec "github.com/shopspring/decimal"
 )
@@ -38,9 +41,13 @@ const outputFile = "/home/bozo/Downloads/individual.xlsx"
 // Main Function
 // ----------------------------------------------------------------------------
 
-// main supervises the processing of individual data.
+// main runs the program and exits with its status.
 func main() {
-	var err error = nil
+	os.Exit(s.Run("individual", run))
+}
+
+// run supervises the processing of individual data.
+func run() (err error) {
 	var apTranlist q.TransList
 	var grantList g.GrantList
 	var output sp.SpreadsheetFile
@@ -50,26 +57,33 @@ func main() {
 	// Read the accounts payable transaction
 	//
 	apTranlist, err = q.ReadAPTransactions()
-	s.Check(err, "Error: ")
+	if err != nil {
+		return err
+	}
 	//
 	// Generate grant transactions
 	//
 	grantList, err = g.AssembleIndividualGrantList(&apTranlist)
-	s.Check(err, "Error: ")
+	if err != nil {
+		return err
+	}
 	//
 	// Output results
 	//
 	output, err = sp.New(outputFile, "Individual Grants")
-	s.Check(err, "Error: ")
+	if err != nil {
+		return s.WrapFile(err, s.Output, "opening output file", outputFile)
+	}
 	var finish = func() {
-		err = output.Save()
-		s.Check(err, "Error saving output file: ")
-		err = output.Close()
-		s.Check(err, "Error closing output file: ")
+		err = errors.Join(err, output.Save(), output.Close())
 	}
 	defer finish()
-	outputRecipientSummary(&output, &grantList)
+	err = outputRecipientSummary(&output, &grantList)
+	if err != nil {
+		return err
+	}
 	printFooter()
+	return err
 }
 
 // ----------------------------------------------------------------------------
@@ -77,13 +91,15 @@ func main() {
 // ----------------------------------------------------------------------------
 
 // outputRecipientSummary creates the RecipSum tab with the recipient summary.
-func outputRecipientSummary(output *sp.SpreadsheetFile, grantList *g.GrantList) {
+func outputRecipientSummary(output *sp.SpreadsheetFile, grantList *g.GrantList) error {
 	//
 	// Create the recipient summary list
 	//
 	var row = 1
 	var list, err = g.AssembleRecipientSumList(grantList)
-	s.Check(err, "Error: ")
+	if err != nil {
+		return err
+	}
 	var totalPayments []dec.Decimal
 	var totalCount []int
 	var amount = dec.Zero
@@ -110,8 +126,10 @@ func outputRecipientSummary(output *sp.SpreadsheetFile, grantList *g.GrantList)
 	var ch = sp.NewColumnHandler(startColumn)
 	for _, name := range names {
 		var recipientSum, err = list.Get(name)
-		s.Check(err, "Error: ")
-		sp.WriteCell(output, "A", row, name)
+		if err != nil {
+			return err
+		}
+		sp.WriteCell(output, "A", row, r.Name(r.Recipient, name))
 		//
 		// Cycle through fiscal years
 		//
@@ -138,6 +156,7 @@ func outputRecipientSummary(output *sp.SpreadsheetFile, grantList *g.GrantList)
 	// grandCount is the total number of recipients (one row per name).
 	grandCount = len(names)
 	sp.OutputTotals(output, row, &ch, totalCount, totalPayments, grandCount)
+	return err
 }
 
 // ----------------------------------------------------------------------------
@@ -3,8 +3,23 @@
 // # Major Donor Analysis
 //
 // This program produces the major donor spreadsheet.  The tabs in the spreadsheet are:
-// -- Major Donor Analysis
-// -- Major Donor List by Year
+// -- Major Donor Count: major donors in all tiers and in each tier
+// -- Tier Movement: donors moving between tiers from the prior year
+// -- Major Donor List: donations and tier of each major donor by year
+//
+// The payees are matched to the donors in donors.xlsx, so the gifts of a
+// donor recorded under several payee names count together.
+//
+// The -households flag combines the giving of the payees in each household
+// of households.xlsx, so a household's combined giving sets its tier.
+//
+// The -inkind flag adds the gifts in inkind.xlsx to the Major Donor List.
+// The in-kind gifts are shown in their own columns and count toward the
+// tier in the list, while the count and movement tabs remain cash only.
+//
+// The -softcredit flag counts the matching and donor-advised fund gifts in
+// softcredits.xlsx for the donors with the soft credit, so a donor's
+// matched giving sets the donor's tier.
 //
 // Author: William Shaffer
 // Version: 27-Sep-2024
@@ -21,11 +36,17 @@ package main
 import (
 	a "acorn_go/pkg/accounting"
 	dn "acorn_go/pkg/donations"
+	dns "acorn_go/pkg/donors"
+	"acorn_go/pkg/identity"
 	md "acorn_go/pkg/majordonor"
+	"acorn_go/pkg/rules"
 	s "acorn_go/pkg/spreadsheet"
 	sp "acorn_go/pkg/support"
+	"errors"
+	"flag"
 	"fmt"
 	"os"
+	"strconv"
 )
 
 // ----------------------------------------------------------------------------
@@ -34,56 +55,164 @@ import (
 
 const inputFile = "/home/bozo/golang/acorn_go/data/donations.xlsx"
 const tab = "Worksheet"
+const donorFile = "/home/bozo/golang/acorn_go/data/donors.xlsx"
+const tabDonors = "Sheet1"
+const aliasFile = "/home/bozo/golang/acorn_go/data/aliases.xlsx"
+const tabAliases = "Sheet1"
+const householdFile = "/home/bozo/golang/acorn_go/data/households.xlsx"
+const tabHouseholds = "Sheet1"
+const inKindFile = "/home/bozo/golang/acorn_go/data/inkind.xlsx"
+const tabInKind = "Sheet1"
+const softCreditFile = "/home/bozo/golang/acorn_go/data/softcredits.xlsx"
+const tabSoftCredits = "Sheet1"
 const outputFile = "/home/bozo/Downloads/majordonor.xlsx"
 
-var columns = []string{"B", "C", "D"}
+// Output tabs
+const (
+	countTab    = "Major Donor Count"
+	movementTab = "Tier Movement"
+	listTab     = "Major Donor List"
+)
 
 // ----------------------------------------------------------------------------
 // Functions
 // ----------------------------------------------------------------------------
 
-// main supervises the processing of the donation data.
+// main runs the program and exits with its status.
 func main() {
+	os.Exit(sp.Run("majordonors", run))
+}
+
+// run supervises the processing of the donation data.
+func run() (err error) {
 	var sprdsht s.Spreadsheet
 	var donationList dn.DonationList
-	var err error
+	var donorList dns.DonorList
+	var aliases identity.AliasTable
 	var output s.SpreadsheetFile
+	var tiers []md.Tier
+	var password string
 
+	var byHousehold = flag.Bool("households", false, "combine the giving of the payees in each household")
+	var withInKind = flag.Bool("inkind", false, "include the in-kind gifts in the major donor list")
+	var bySoftCredit = flag.Bool("softcredit", false, "count gifts for the donors with soft credit")
+	flag.Parse()
 	printHeader()
 	//
+	// Obtain password for the output spreadsheet
+	//
+	password, err = s.OutputPassword()
+	if err != nil {
+		return sp.Wrap(err, sp.Usage, "obtaining spreadsheet password")
+	}
+	//
+	// Obtain the major donor tiers
+	//
+	tiers, err = md.ConfiguredTiers()
+	if err != nil {
+		return err
+	}
+	//
 	// Obtain spreadsheet data
 	//
 	sprdsht, err = s.ProcessData(inputFile, tab)
-	sp.Check(err, "Error processing spreadsheet: ")
+	if err != nil {
+		return err
+	}
 	//
 	// Obtain donation list
 	//
 	donationList, err = dn.NewDonationList(&sprdsht)
-	sp.Check(err, "Error generating donor list: ")
+	if err != nil {
+		return err
+	}
+	if *withInKind {
+		var count int
+		count, err = donationList.ReadInKind(inKindFile, tabInKind)
+		if err != nil {
+			return err
+		}
+		fmt.Println("Number of in-kind gifts: " + strconv.Itoa(count))
+	}
+	if *bySoftCredit {
+		var count int
+		count, err = donationList.ReadSoftCredits(softCreditFile, tabSoftCredits)
+		if err != nil {
+			return err
+		}
+		fmt.Println("Number of soft credits: " + strconv.Itoa(count))
+	}
+	//
+	// Match the payees to the donors
+	//
+	sprdsht, err = s.ProcessData(donorFile, tabDonors)
+	if err != nil {
+		return err
+	}
+	donorList, err = dns.NewDonorAddressList(&sprdsht)
+	if err != nil {
+		return err
+	}
+	aliases, err = identity.ReadAliases(aliasFile, tabAliases)
+	if err != nil {
+		return err
+	}
+	var resolver = identity.NewResolver(donorList.Keys(), aliases)
+	donationList = donationList.Resolve(&resolver)
+	if *bySoftCredit {
+		donationList = donationList.BySoftCredit()
+	}
+	if *byHousehold {
+		var households dns.HouseholdList
+		households, err = dns.ReadHouseholds(householdFile, tabHouseholds)
+		if err != nil {
+			return err
+		}
+		fmt.Println("Number of households: " + strconv.Itoa(households.Count()))
+		donationList = donationList.ByHousehold(&households)
+	}
 	//
 	// Open the output spreadsheet
 	//
-	output, err = s.New(outputFile, "Major Donor Count")
-	sp.Check(err, "Error opening output file: ")
+	output, err = s.New(outputFile, countTab)
+	if err != nil {
+		return sp.WrapFile(err, sp.Output, "opening output file", outputFile)
+	}
+	output.SetPassword(password)
 	var finish = func() {
-		err = output.Save()
-		sp.Check(err, "Error saving output file: ")
-		err = output.Close()
-		sp.Check(err, "Error closing output file: ")
-		os.Exit(0)
+		err = errors.Join(err, output.Save(), output.Close())
 	}
 	defer finish()
 	//
 	// Output the major donor count and donations
 	//
-	var majorDonor = md.ComputeMajorDonors(&donationList)
+	var majorDonor = md.ComputeMajorDonors(&donationList, tiers, a.FYIndicators[:])
 	outputMajorDonor(&majorDonor, &output)
 	//
+	// Output the movement between tiers
+	//
+	output, err = output.AddSheet(movementTab)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
+	outputMovement(&majorDonor, &output)
+	//
 	// Output the donations
 	//
-	output, err = output.AddSheet("Major Donor List")
-	sp.Check(err, "Error adding sheet: ")
-	outputMajorList(&donationList, &output)
+	output, err = output.AddSheet(listTab)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
+	outputMajorList(&donationList, &majorDonor, &output)
+	//
+	// Output the donations excluded by the rules
+	//
+	output, err = output.AddSheet(rules.AuditTab)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
+	rules.OutputAudit(&output)
+	return err
 }
 
 // ----------------------------------------------------------------------------
@@ -101,11 +230,14 @@ func printHeader() {
 // Output Functions
 // ----------------------------------------------------------------------------
 
-// outputMajorDonor inserts the major donor data into the spreadsheet.
+// outputMajorDonor inserts the major donor data into the spreadsheet.  The
+// first table covers the major donors in all tiers, followed by a table for
+// each tier from highest to lowest.
 func outputMajorDonor(
-	md *md.MajorDonor,
+	majorDonor *md.MajorDonor,
 	output *s.SpreadsheetFile) {
 	var row = 1
+	var fiscalYears = majorDonor.FiscalYears()
 	//
 	// Place Title
 	//
@@ -115,52 +247,132 @@ func outputMajorDonor(
 	//
 	row += 2
 	s.WriteCell(output, "A", row, "")
-	for _, fy := range a.FYIndicators {
-		s.WriteCell(output, columns[fy], row, fy.String())
+	for index, fy := range fiscalYears {
+		s.WriteCell(output, s.ColumnLetter(index+1), row, fy.String())
 	}
 	//
 	// Ouput Data
 	//
 	row += 2
 	s.WriteCell(output, "A", row, "Major donors")
-	for _, fy := range a.FYIndicators {
-		s.WriteCellInt(output, columns[fy], row, md.MajorDonorCount(fy))
+	for index, fy := range fiscalYears {
+		s.WriteCellInt(output, s.ColumnLetter(index+1), row, majorDonor.MajorDonorCount(fy))
 	}
 	row++
 	s.WriteCell(output, "A", row, "Major donor donations")
-	for _, fy := range a.FYIndicators {
-		s.WriteCellFloat(output, columns[fy], row, md.DonationsMajor(fy))
+	for index, fy := range fiscalYears {
+		s.WriteCellFloat(output, s.ColumnLetter(index+1), row, majorDonor.DonationsMajor(fy))
 	}
 	row++
 	s.WriteCell(output, "A", row, "Average major donor donation")
-	for _, fy := range a.FYIndicators {
-		s.WriteCellFloat(output, columns[fy], row, md.AvgDonation(fy))
+	for index, fy := range fiscalYears {
+		s.WriteCellFloat(output, s.ColumnLetter(index+1), row, majorDonor.AvgDonation(fy))
 	}
 	row++
 	s.WriteCell(output, "A", row, "Percent of total donations by major donors")
-	for _, fy := range a.FYIndicators {
-		s.WriteCellFloat(output, columns[fy], row, md.PercentDonation(fy))
+	for index, fy := range fiscalYears {
+		s.WriteCellFloat(output, s.ColumnLetter(index+1), row, majorDonor.PercentDonation(fy))
 	}
 	row += 2
 	s.WriteCell(output, "A", row, "Percent change in average donations")
-	for _, fy := range a.FYIndicators {
-		s.WriteCellFloat(output, columns[fy], row, md.PercentChange(fy))
+	for index, fy := range fiscalYears {
+		s.WriteCellFloat(output, s.ColumnLetter(index+1), row, majorDonor.PercentChange(fy))
+	}
+	//
+	// Output each tier
+	//
+	var tiers = majorDonor.Tiers()
+	for tier := len(tiers) - 1; tier >= 0; tier-- {
+		row += 3
+		s.WriteCell(output, "A", row, tiers[tier].Name+" ("+tiers[tier].Minimum.StringFixed(0)+" and over)")
+		row++
+		s.WriteCell(output, "A", row, "Donors")
+		for index, fy := range fiscalYears {
+			s.WriteCellInt(output, s.ColumnLetter(index+1), row, majorDonor.TierCount(tier, fy))
+		}
+		row++
+		s.WriteCell(output, "A", row, "Donations")
+		for index, fy := range fiscalYears {
+			s.WriteCellFloat(output, s.ColumnLetter(index+1), row, majorDonor.TierDonations(tier, fy))
+		}
+		row++
+		s.WriteCell(output, "A", row, "Average donation")
+		for index, fy := range fiscalYears {
+			s.WriteCellFloat(output, s.ColumnLetter(index+1), row, majorDonor.TierAvgDonation(tier, fy))
+		}
+		row++
+		s.WriteCell(output, "A", row, "Percent of total donations")
+		for index, fy := range fiscalYears {
+			s.WriteCellFloat(output, s.ColumnLetter(index+1), row, majorDonor.TierPercentDonation(tier, fy))
+		}
+		row++
+		s.WriteCell(output, "A", row, "Percent change in average donation")
+		for index, fy := range fiscalYears {
+			s.WriteCellFloat(output, s.ColumnLetter(index+1), row, majorDonor.TierPercentChange(tier, fy))
+		}
+	}
+}
+
+// outputMovement inserts a table for each fiscal year with the number of
+// donors moving from their tier in the prior fiscal year (rows) to their
+// tier in the fiscal year (columns).
+func outputMovement(
+	majorDonor *md.MajorDonor,
+	output *s.SpreadsheetFile) {
+	var row = 1
+	var tiers = majorDonor.Tiers()
+	//
+	// Place Title
+	//
+	s.WriteCell(output, "A", row, "Major Donor Tier Movement")
+	//
+	// Place a table for each fiscal year
+	//
+	for _, fy := range majorDonor.FiscalYears() {
+		row += 2
+		s.WriteCell(output, "A", row, "Movement into "+fy.String())
+		row++
+		s.WriteCell(output, "A", row, "Prior Year Tier")
+		for to := md.NotMajor; to < len(tiers); to++ {
+			s.WriteCell(output, s.ColumnLetter(to+2), row, md.TierName(tiers, to))
+		}
+		row++
+		for from := md.NotMajor; from < len(tiers); from++ {
+			s.WriteCell(output, "A", row, md.TierName(tiers, from))
+			for to := md.NotMajor; to < len(tiers); to++ {
+				if from != md.NotMajor || to != md.NotMajor {
+					s.WriteCellInt(output, s.ColumnLetter(to+2), row, majorDonor.Moves(fy, from, to))
+				}
+			}
+			row++
+		}
 	}
 }
 
-// outputMajorList output the list of donors whose combined FY2023 and FY2024
-// donations equal or exceed $2000.
-func outputMajorList(donorList *dn.DonationList, output *s.SpreadsheetFile) {
+// outputMajorList outputs the list of donors who have been in a tier in any
+// fiscal year of the analysis with their cash donation, in-kind gifts, and
+// tier in each year and their lifetime giving.  The tier is set by the cash
+// and in-kind giving together.
+func outputMajorList(
+	donorList *dn.DonationList,
+	majorDonor *md.MajorDonor,
+	output *s.SpreadsheetFile) {
 	var row = 1
+	var tiers = majorDonor.Tiers()
+	var fiscalYears = majorDonor.FiscalYears()
+	var lifetime = 3*len(fiscalYears) + 1
 	//
 	// Place Headings
 	//
 	s.WriteCell(output, "A", row, "Donor")
-	for _, fy := range a.FYIndicators {
-		s.WriteCell(output, columns[fy], row, "Donation "+fy.String())
+	for index, fy := range fiscalYears {
+		s.WriteCell(output, s.ColumnLetter(3*index+1), row, "Donation "+fy.String())
+		s.WriteCell(output, s.ColumnLetter(3*index+2), row, "In-Kind "+fy.String())
+		s.WriteCell(output, s.ColumnLetter(3*index+3), row, "Tier "+fy.String())
 	}
-
-	s.WriteCell(output, "C", row, "Donation FY24")
+	s.WriteCell(output, s.ColumnLetter(lifetime), row, "Lifetime Cash")
+	s.WriteCell(output, s.ColumnLetter(lifetime+1), row, "Lifetime In-Kind")
+	s.WriteCell(output, s.ColumnLetter(lifetime+2), row, "Lifetime Giving")
 	row++
 	//
 	// Output data
@@ -168,11 +380,23 @@ func outputMajorList(donorList *dn.DonationList, output *s.SpreadsheetFile) {
 	var names = donorList.DonorKeys()
 	for _, name := range names {
 		var donor = donorList.Get(name)
-		if donor.IsMajorDonorOverall() {
-			s.WriteCell(output, "A", row, name)
-			for _, fy := range a.FYIndicators {
-				s.WriteCellDecimal(output, columns[fy], row, donor.Donation(fy))
+		var major = false
+		for _, fy := range fiscalYears {
+			major = major || md.TierOf(tiers, donor.Recognition(fy)) != md.NotMajor
+		}
+		if major {
+			s.WriteCell(output, "A", row, donor.Name())
+			for index, fy := range fiscalYears {
+				var tier = md.TierOf(tiers, donor.Recognition(fy))
+				s.WriteCellDecimal(output, s.ColumnLetter(3*index+1), row, donor.Donation(fy))
+				s.WriteCellDecimal(output, s.ColumnLetter(3*index+2), row, donor.InKindDonation(fy))
+				if tier != md.NotMajor {
+					s.WriteCell(output, s.ColumnLetter(3*index+3), row, tiers[tier].Name)
+				}
 			}
+			s.WriteCellDecimal(output, s.ColumnLetter(lifetime), row, donor.LifetimeCash())
+			s.WriteCellDecimal(output, s.ColumnLetter(lifetime+1), row, donor.LifetimeInKind())
+			s.WriteCellDecimal(output, s.ColumnLetter(lifetime+2), row, donor.LifetimeGiving())
 			row++
 		}
 	}
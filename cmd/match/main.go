@@ -0,0 +1,295 @@
+// ----------------------------------------------------------------------------
+//
+// Payee matching program
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+// This program matches the Quickbooks payees in donations.xlsx to the donors
+// in donors.xlsx and produces a spreadsheet file payee_review.xlsx with tabs:
+// -- Review: payees matched by similar names, matched to more than one
+//    donor, or matched to none, with the likely donors
+// -- Summary: the number of payees matched each way
+//
+// The -approve flag adds the payees matched by normalized or similar names
+// to the alias table aliases.xlsx, so they are matched by alias from then
+// on.  Review the Review tab before approving.
+
+package main
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	dna "acorn_go/pkg/donations"
+	dns "acorn_go/pkg/donors"
+	"acorn_go/pkg/identity"
+	"acorn_go/pkg/rules"
+	s "acorn_go/pkg/spreadsheet"
+	sp "acorn_go/pkg/support"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	dec "github.com/shopspring/decimal"
+)
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const (
+	inputFile      = "/home/bozo/golang/acorn_go/data/donations.xlsx"
+	tab            = "Worksheet"
+	donorFile      = "/home/bozo/golang/acorn_go/data/donors.xlsx"
+	tabDonors      = "Sheet1"
+	aliasFile      = "/home/bozo/golang/acorn_go/data/aliases.xlsx"
+	tabAliases     = "Sheet1"
+	outputFileName = "/home/bozo/Downloads/payee_review.xlsx"
+	reviewTab      = "Review"
+	summaryTab     = "Summary"
+)
+
+// ----------------------------------------------------------------------------
+// Functions
+// ----------------------------------------------------------------------------
+
+// main runs the program and exits with its status.
+func main() {
+	os.Exit(sp.Run("match", run))
+}
+
+// run supervises the execution of this program.  It produces the review
+// spreadsheet and, if requested, updates the alias table.
+func run() error {
+	var sprdsht s.Spreadsheet
+	var donationList dna.DonationList
+	var donorList dns.DonorList
+	var aliases identity.AliasTable
+	var password string
+	var err error
+
+	var approve = flag.Bool("approve", false, "add the normalized and similar matches to the alias table")
+	flag.Parse()
+	printHeader()
+	//
+	// Obtain password for the output spreadsheet
+	//
+	password, err = s.OutputPassword()
+	if err != nil {
+		return sp.Wrap(err, sp.Usage, "obtaining spreadsheet password")
+	}
+	//
+	// Obtain the payees, donors, and aliases
+	//
+	sprdsht, err = s.ProcessData(inputFile, tab)
+	if err != nil {
+		return err
+	}
+	donationList, err = dna.NewDonationList(&sprdsht)
+	if err != nil {
+		return err
+	}
+	sprdsht, err = s.ProcessData(donorFile, tabDonors)
+	if err != nil {
+		return err
+	}
+	donorList, err = dns.NewDonorAddressList(&sprdsht)
+	if err != nil {
+		return err
+	}
+	aliases, err = identity.ReadAliases(aliasFile, tabAliases)
+	if err != nil {
+		return err
+	}
+	//
+	// Match the payees
+	//
+	var resolver = identity.NewResolver(donorList.Keys(), aliases)
+	var matches = []identity.Match{}
+	for _, payee := range donationList.DonorKeys() {
+		matches = append(matches, resolver.Resolve(payee))
+	}
+	err = outputReview(&donationList, matches, password)
+	if err != nil {
+		return err
+	}
+	//
+	// Approve the matches
+	//
+	if *approve {
+		err = approveMatches(aliases, matches)
+		if err != nil {
+			return err
+		}
+	}
+	printFooter()
+	return err
+}
+
+// approveMatches adds the payees matched by normalized or similar names to
+// the alias table and saves it.
+func approveMatches(aliases identity.AliasTable, matches []identity.Match) error {
+	var count = 0
+	for _, match := range matches {
+		if match.Status() == identity.Normalized || match.Status() == identity.Similar {
+			var err = aliases.Add(match.Payee(), match.Key())
+			if err != nil {
+				return sp.Wrap(err, sp.Other, "approving match")
+			}
+			count++
+		}
+	}
+	fmt.Println("Number of matches approved: " + strconv.Itoa(count))
+	return aliases.Write(aliasFile, tabAliases)
+}
+
+// totalGiving returns the total of the payee's gifts.
+func totalGiving(donor *dns.Donor) dec.Decimal {
+	var total = dec.Zero
+	for _, gift := range donor.Gifts() {
+		total = total.Add(gift.Amount())
+	}
+	return total
+}
+
+// ----------------------------------------------------------------------------
+// Print Functions
+// ----------------------------------------------------------------------------
+
+// printHeader prints a message indicating the program has started
+func printHeader() {
+	fmt.Println("-----------------------------------------------------------")
+	fmt.Println("Acorn Scholarship Fund Payee Matching")
+	fmt.Println("-----------------------------------------------------------")
+}
+
+// printFooter reports the end of the program
+func printFooter() {
+	fmt.Println("-----------------------------------------------------------")
+	fmt.Println("Program has ended")
+	fmt.Println("-----------------------------------------------------------")
+}
+
+// ----------------------------------------------------------------------------
+// Output Functions
+// ----------------------------------------------------------------------------
+
+// outputReview produces a spreadsheet with the payees to review and a
+// summary of the matches.  The spreadsheet is encrypted with the password.
+func outputReview(
+	donationList *dna.DonationList,
+	matches []identity.Match,
+	password string) (err error) {
+	var output s.SpreadsheetFile
+	//
+	// Create output spreadsheet
+	//
+	output, err = s.New(outputFileName, reviewTab)
+	if err != nil {
+		return sp.WrapFile(err, sp.Output, "opening output file", outputFileName)
+	}
+	output.SetPassword(password)
+	var finish = func() {
+		err = errors.Join(err, output.Save(), output.Close())
+	}
+	defer finish()
+	//
+	// Output the payees to review and the summary
+	//
+	outputPayees(&output, donationList, matches)
+
+	output, err = output.AddSheet(summaryTab)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
+	outputSummary(&output, matches)
+	//
+	// Output the donations excluded by the rules
+	//
+	output, err = output.AddSheet(rules.AuditTab)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
+	rules.OutputAudit(&output)
+	return err
+}
+
+// outputPayees fills the review tab with the payees that need review, their
+// giving, and the donors they may be.
+func outputPayees(output *s.SpreadsheetFile, donationList *dna.DonationList, matches []identity.Match) {
+	//
+	// Insert Heading
+	//
+	var row = 1
+	s.WriteCell(output, "A", row, "Payees to Review")
+	row += 2
+	s.WriteCell(output, "A", row, "Payee")
+	s.WriteCell(output, "B", row, "Status")
+	s.WriteCell(output, "C", row, "Donor Matched")
+	s.WriteCell(output, "D", row, "Gifts")
+	s.WriteCell(output, "E", row, "Total Giving")
+	for index := range identity.MaxCandidates {
+		var column = 5 + 2*index
+		s.WriteCell(output, s.ColumnLetter(column), row, "Candidate "+strconv.Itoa(index+1))
+		s.WriteCell(output, s.ColumnLetter(column+1), row, "Score")
+	}
+	row++
+	//
+	// Insert the payees
+	//
+	var count = 0
+	for _, match := range matches {
+		if !match.NeedsReview() {
+			continue
+		}
+		var donor = donationList.Get(match.Payee())
+		s.WriteCell(output, "A", row, match.Payee())
+		s.WriteCell(output, "B", row, match.Status().String())
+		s.WriteCell(output, "C", row, match.Key())
+		s.WriteCellInt(output, "D", row, donor.GiftCount())
+		s.WriteCellDecimal(output, "E", row, totalGiving(donor))
+		for index, candidate := range match.Candidates() {
+			var column = 5 + 2*index
+			s.WriteCell(output, s.ColumnLetter(column), row, candidate.Key)
+			s.WriteCellInt(output, s.ColumnLetter(column+1), row, candidate.Score)
+		}
+		row++
+		count++
+	}
+	fmt.Println("Number of payees to review: " + strconv.Itoa(count))
+}
+
+// outputSummary fills the summary tab with the number of payees matched
+// each way.
+func outputSummary(output *s.SpreadsheetFile, matches []identity.Match) {
+	var counts [identity.NumStatuses]int
+	for _, match := range matches {
+		counts[match.Status()]++
+	}
+	//
+	// Insert Heading
+	//
+	var row = 1
+	s.WriteCell(output, "A", row, "Payee Matching Summary")
+	row += 2
+	s.WriteCell(output, "A", row, "Status")
+	s.WriteCell(output, "B", row, "Payees")
+	row++
+	//
+	// Insert the counts
+	//
+	for _, status := range identity.Statuses {
+		s.WriteCell(output, "A", row, status.String())
+		s.WriteCellInt(output, "B", row, counts[status])
+		row++
+	}
+	s.WriteCell(output, "A", row, "Total")
+	s.WriteCellInt(output, "B", row, len(matches))
+}
@@ -0,0 +1,309 @@
+// ----------------------------------------------------------------------------
+//
+// Matching gift report
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+// This program lists the gifts that give soft credit to donors and the
+// employer matching gifts that are expected but not yet received.  The
+// report is as of the date given by the -asof flag, or the end of the
+// current fiscal year.  This program produces a spreadsheet file
+// matching.xlsx with tabs:
+// -- Soft Credits: the matching and donor-advised fund gifts with the donors
+//    who receive soft credit for them
+// -- Expected Matches: the gifts of donors whose matches are not received
+
+package main
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	a "acorn_go/pkg/accounting"
+	dna "acorn_go/pkg/donations"
+	dns "acorn_go/pkg/donors"
+	"acorn_go/pkg/identity"
+	l "acorn_go/pkg/logging"
+	"acorn_go/pkg/matching"
+	r "acorn_go/pkg/redact"
+	"acorn_go/pkg/rules"
+	s "acorn_go/pkg/spreadsheet"
+	sp "acorn_go/pkg/support"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	dec "github.com/shopspring/decimal"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const (
+	inputFile      = "/home/bozo/golang/acorn_go/data/donations.xlsx"
+	tab            = "Worksheet"
+	donorFile      = "/home/bozo/golang/acorn_go/data/donors.xlsx"
+	tabDonors      = "Sheet1"
+	aliasFile      = "/home/bozo/golang/acorn_go/data/aliases.xlsx"
+	tabAliases     = "Sheet1"
+	softCreditFile = "/home/bozo/golang/acorn_go/data/softcredits.xlsx"
+	tabSoftCredits = "Sheet1"
+	matchingFile   = "/home/bozo/golang/acorn_go/data/matching.xlsx"
+	tabMatching    = "Sheet1"
+	outputFileName = "/home/bozo/Downloads/matching.xlsx"
+	softCreditTab  = "Soft Credits"
+	expectedTab    = "Expected Matches"
+
+	fy = a.CurrentFiscalYear
+)
+
+// ----------------------------------------------------------------------------
+// Functions
+// ----------------------------------------------------------------------------
+
+// main runs the program and exits with its status.
+func main() {
+	os.Exit(sp.Run("matching", run))
+}
+
+// run supervises the execution of this program.  It produces a spreadsheet
+// with the soft credits and the matching gifts expected.
+func run() error {
+	var sprdsht s.Spreadsheet
+	var donationList dna.DonationList
+	var donorList dns.DonorList
+	var aliases identity.AliasTable
+	var table matching.Table
+	var password string
+	var asOf d.Date
+	var count int
+	var err error
+
+	var asOfFlag = flag.String("asof", "", "date of the report, MM/DD/YYYY (default end of "+fy.String()+")")
+	flag.Parse()
+	printHeader()
+	//
+	// Obtain the date of the report
+	//
+	asOf = fy.End()
+	if *asOfFlag != "" {
+		asOf, err = d.NewFromString(*asOfFlag)
+		if err != nil {
+			return sp.Wrap(err, sp.Usage, "parsing the as-of date")
+		}
+	}
+	//
+	// Obtain password for the output spreadsheet
+	//
+	password, err = s.OutputPassword()
+	if err != nil {
+		return sp.Wrap(err, sp.Usage, "obtaining spreadsheet password")
+	}
+	//
+	// Obtain spreadsheet data
+	//
+	sprdsht, err = s.ProcessData(inputFile, tab)
+	if err != nil {
+		return err
+	}
+	//
+	// Generate donation list with the soft credits
+	//
+	donationList, err = dna.NewDonationList(&sprdsht)
+	if err != nil {
+		return err
+	}
+	count, err = donationList.ReadSoftCredits(softCreditFile, tabSoftCredits)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Number of soft credits: " + strconv.Itoa(count))
+	//
+	// Match the payees and the program donors to the donors
+	//
+	sprdsht, err = s.ProcessData(donorFile, tabDonors)
+	if err != nil {
+		return err
+	}
+	donorList, err = dns.NewDonorAddressList(&sprdsht)
+	if err != nil {
+		return err
+	}
+	aliases, err = identity.ReadAliases(aliasFile, tabAliases)
+	if err != nil {
+		return err
+	}
+	var resolver = identity.NewResolver(donorList.Keys(), aliases)
+	donationList = donationList.Resolve(&resolver)
+	table, err = matching.ReadTable(matchingFile, tabMatching)
+	if err != nil {
+		return err
+	}
+	for _, name := range table.Resolve(&resolver) {
+		l.Warn("matching gift donor is not a donor", "name", name, l.File(matchingFile))
+	}
+	//
+	// Output the soft credits and the expected matches
+	//
+	err = outputReport(&donationList, table.Expected(donationList, asOf), asOf, password)
+	if err != nil {
+		return err
+	}
+	printFooter()
+	return err
+}
+
+// ----------------------------------------------------------------------------
+// Print Functions
+// ----------------------------------------------------------------------------
+
+// printHeader prints a message indicating the program has started
+func printHeader() {
+	fmt.Println("-----------------------------------------------------------")
+	fmt.Println("Acorn Scholarship Fund Matching Gifts")
+	fmt.Println("-----------------------------------------------------------")
+}
+
+// printFooter reports the end of the program
+func printFooter() {
+	fmt.Println("-----------------------------------------------------------")
+	fmt.Println("Program has ended")
+	fmt.Println("-----------------------------------------------------------")
+}
+
+// ----------------------------------------------------------------------------
+// Output Functions
+// ----------------------------------------------------------------------------
+
+// outputReport produces a spreadsheet with the soft credits and the
+// matching gifts expected.  The spreadsheet is encrypted with the password.
+func outputReport(
+	donationList *dna.DonationList,
+	matches []matching.Match,
+	asOf d.Date,
+	password string) (err error) {
+	var output s.SpreadsheetFile
+	//
+	// Create output spreadsheet
+	//
+	output, err = s.New(outputFileName, softCreditTab)
+	if err != nil {
+		return sp.WrapFile(err, sp.Output, "opening output file", outputFileName)
+	}
+	output.SetPassword(password)
+	var finish = func() {
+		err = errors.Join(err, output.Save(), output.Close())
+	}
+	defer finish()
+	//
+	// Output the soft credits and the expected matches
+	//
+	outputSoftCredits(&output, donationList)
+
+	output, err = output.AddSheet(expectedTab)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
+	outputExpected(&output, matches, asOf)
+	//
+	// Output the donations excluded by the rules
+	//
+	output, err = output.AddSheet(rules.AuditTab)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
+	rules.OutputAudit(&output)
+	return err
+}
+
+// outputSoftCredits fills a tab with the gifts that give soft credit, the
+// payee with the hard credit, and the donor with the soft credit.
+func outputSoftCredits(output *s.SpreadsheetFile, donationList *dna.DonationList) {
+	//
+	// Insert Heading
+	//
+	var row = 1
+	s.WriteCell(output, "A", row, "Paid By")
+	s.WriteCell(output, "B", row, "Date")
+	s.WriteCell(output, "C", row, "Amount")
+	s.WriteCell(output, "D", row, "Credit Type")
+	s.WriteCell(output, "E", row, "Soft Credit")
+	s.WriteCell(output, "F", row, "Fiscal Year")
+	row++
+	//
+	// Insert the gifts of each payee
+	//
+	var total = dec.Zero
+	var count = 0
+	for _, key := range donationList.DonorKeys() {
+		for _, gift := range donationList.Get(key).CreditedGifts() {
+			s.WriteCell(output, "A", row, key)
+			s.WriteCellDate(output, "B", row, gift.Date())
+			s.WriteCellDecimal(output, "C", row, gift.Amount())
+			s.WriteCell(output, "D", row, gift.CreditType().String())
+			s.WriteCell(output, "E", row, r.Name(r.Donor, gift.CreditedTo()))
+			s.WriteCell(output, "F", row, "FY"+strconv.Itoa(a.FiscalYearNumber(gift.Date())))
+			total = total.Add(gift.Amount())
+			count++
+			row++
+		}
+	}
+	s.WriteCell(output, "A", row, "Total")
+	s.WriteCellDecimal(output, "C", row, total)
+	fmt.Println("Number of gifts with soft credit: " + strconv.Itoa(count))
+}
+
+// outputExpected fills a tab with the gifts of donors whose matching gifts
+// have not been received in full.
+func outputExpected(output *s.SpreadsheetFile, matches []matching.Match, asOf d.Date) {
+	//
+	// Insert Heading
+	//
+	var row = 1
+	s.WriteCell(output, "A", row, "Matching gifts expected as of "+asOf.String())
+	row += 2
+	s.WriteCell(output, "A", row, "Donor Name")
+	s.WriteCell(output, "B", row, "Employer")
+	s.WriteCell(output, "C", row, "Gift Date")
+	s.WriteCell(output, "D", row, "Gift")
+	s.WriteCell(output, "E", row, "Match Ratio")
+	s.WriteCell(output, "F", row, "Expected")
+	s.WriteCell(output, "G", row, "Received")
+	s.WriteCell(output, "H", row, "Outstanding")
+	s.WriteCell(output, "I", row, "Days Since Gift")
+	row++
+	//
+	// Insert the matches not received
+	//
+	var outstanding = dec.Zero
+	var count = 0
+	for _, match := range matches {
+		if match.IsReceived() {
+			continue
+		}
+		s.WriteCell(output, "A", row, r.Name(r.Donor, match.Donor()))
+		s.WriteCell(output, "B", row, match.Employer())
+		s.WriteCellDate(output, "C", row, match.Date())
+		s.WriteCellDecimal(output, "D", row, match.Gift())
+		s.WriteCell(output, "E", row, match.Ratio().String())
+		s.WriteCellDecimal(output, "F", row, match.Expected())
+		s.WriteCellDecimal(output, "G", row, match.Received())
+		s.WriteCellDecimal(output, "H", row, match.Outstanding())
+		s.WriteCellInt(output, "I", row, match.DaysOutstanding(asOf))
+		outstanding = outstanding.Add(match.Outstanding())
+		count++
+		row++
+	}
+	s.WriteCell(output, "A", row, "Total")
+	s.WriteCellDecimal(output, "H", row, outstanding)
+	fmt.Println("Number of matching gifts expected: " + strconv.Itoa(count))
+}
@@ -0,0 +1,327 @@
+// ----------------------------------------------------------------------------
+//
+// Online giving import
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+// This program imports the CSV export of the online giving platform,
+// matches the gifts to donors, and reconciles the platform's payouts with
+// the deposits from the processor in Quickbooks.  The -file flag names the
+// export, and the -payee flag names the Quickbooks payee of the deposits.
+// This program produces a spreadsheet file online.xlsx with tabs:
+// -- Online Gifts: the gifts with their gross, fee, net, and donor
+// -- Deposits: the payouts paired with the Quickbooks deposits
+// -- Fiscal Years: the gross, fees, and net of the gifts in each fiscal year
+
+package main
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	dns "acorn_go/pkg/donors"
+	"acorn_go/pkg/identity"
+	l "acorn_go/pkg/logging"
+	"acorn_go/pkg/online"
+	r "acorn_go/pkg/redact"
+	s "acorn_go/pkg/spreadsheet"
+	sp "acorn_go/pkg/support"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	dec "github.com/shopspring/decimal"
+)
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const (
+	inputFile      = "/home/bozo/golang/acorn_go/data/donations.xlsx"
+	tab            = "Worksheet"
+	donorFile      = "/home/bozo/golang/acorn_go/data/donors.xlsx"
+	tabDonors      = "Sheet1"
+	aliasFile      = "/home/bozo/golang/acorn_go/data/aliases.xlsx"
+	tabAliases     = "Sheet1"
+	onlineFile     = "/home/bozo/golang/acorn_go/data/online.csv"
+	processorPayee = "Online Giving Platform"
+	outputFileName = "/home/bozo/Downloads/online.xlsx"
+	giftsTab       = "Online Gifts"
+	depositsTab    = "Deposits"
+	fiscalYearsTab = "Fiscal Years"
+)
+
+// ----------------------------------------------------------------------------
+// Functions
+// ----------------------------------------------------------------------------
+
+// main runs the program and exits with its status.
+func main() {
+	os.Exit(sp.Run("online", run))
+}
+
+// run supervises the execution of this program.  It produces a spreadsheet
+// with the online gifts and the reconciliation of the deposits.
+func run() error {
+	var sprdsht s.Spreadsheet
+	var donorList dns.DonorList
+	var aliases identity.AliasTable
+	var export online.Export
+	var deposits []online.Deposit
+	var password string
+	var err error
+
+	var fileFlag = flag.String("file", onlineFile, "CSV export of the online giving platform")
+	var payeeFlag = flag.String("payee", processorPayee, "Quickbooks payee of the processor's deposits")
+	flag.Parse()
+	printHeader()
+	//
+	// Obtain password for the output spreadsheet
+	//
+	password, err = s.OutputPassword()
+	if err != nil {
+		return sp.Wrap(err, sp.Usage, "obtaining spreadsheet password")
+	}
+	//
+	// Obtain the online gifts
+	//
+	export, err = online.ReadExport(*fileFlag)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Number of online gifts: " + strconv.Itoa(export.Count()))
+	//
+	// Match the online gifts to the donors
+	//
+	sprdsht, err = s.ProcessData(donorFile, tabDonors)
+	if err != nil {
+		return err
+	}
+	donorList, err = dns.NewDonorAddressList(&sprdsht)
+	if err != nil {
+		return err
+	}
+	aliases, err = identity.ReadAliases(aliasFile, tabAliases)
+	if err != nil {
+		return err
+	}
+	var resolver = identity.NewResolver(donorList.Keys(), aliases)
+	for _, transaction := range export.Resolve(&donorList, &resolver) {
+		l.Warn("online donor is not a donor", "name", transaction.Name(), "row", transaction.Row(), l.File(*fileFlag))
+	}
+	//
+	// Obtain the deposits from the processor
+	//
+	sprdsht, err = s.ProcessData(inputFile, tab)
+	if err != nil {
+		return err
+	}
+	deposits, err = online.ReadDeposits(&sprdsht, *payeeFlag)
+	if err != nil {
+		return err
+	}
+	//
+	// Output the gifts and the reconciliation
+	//
+	err = outputReport(&export, online.Reconcile(export.Payouts(), deposits), password)
+	if err != nil {
+		return err
+	}
+	printFooter()
+	return err
+}
+
+// ----------------------------------------------------------------------------
+// Print Functions
+// ----------------------------------------------------------------------------
+
+// printHeader prints a message indicating the program has started
+func printHeader() {
+	fmt.Println("-----------------------------------------------------------")
+	fmt.Println("Acorn Scholarship Fund Online Giving")
+	fmt.Println("-----------------------------------------------------------")
+}
+
+// printFooter reports the end of the program
+func printFooter() {
+	fmt.Println("-----------------------------------------------------------")
+	fmt.Println("Program has ended")
+	fmt.Println("-----------------------------------------------------------")
+}
+
+// ----------------------------------------------------------------------------
+// Output Functions
+// ----------------------------------------------------------------------------
+
+// outputReport produces a spreadsheet with the online gifts, the
+// reconciliation of the deposits, and the totals of each fiscal year.  The
+// spreadsheet is encrypted with the password.
+func outputReport(
+	export *online.Export,
+	reconciliations []online.Reconciliation,
+	password string) (err error) {
+	var output s.SpreadsheetFile
+	//
+	// Create output spreadsheet
+	//
+	output, err = s.New(outputFileName, giftsTab)
+	if err != nil {
+		return sp.WrapFile(err, sp.Output, "opening output file", outputFileName)
+	}
+	output.SetPassword(password)
+	var finish = func() {
+		err = errors.Join(err, output.Save(), output.Close())
+	}
+	defer finish()
+	//
+	// Output the gifts, deposits, and fiscal years
+	//
+	outputGifts(&output, export)
+
+	output, err = output.AddSheet(depositsTab)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
+	outputDeposits(&output, reconciliations)
+
+	output, err = output.AddSheet(fiscalYearsTab)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
+	outputFiscalYears(&output, export.Totals())
+	return err
+}
+
+// outputGifts fills a tab with the online gifts and the donors they were
+// matched to.
+func outputGifts(output *s.SpreadsheetFile, export *online.Export) {
+	//
+	// Insert Heading
+	//
+	var row = 1
+	s.WriteCell(output, "A", row, "Date")
+	s.WriteCell(output, "B", row, "Transaction ID")
+	s.WriteCell(output, "C", row, "Donor Name")
+	s.WriteCell(output, "D", row, "Matched By")
+	s.WriteCell(output, "E", row, "Gross")
+	s.WriteCell(output, "F", row, "Fee")
+	s.WriteCell(output, "G", row, "Net")
+	s.WriteCell(output, "H", row, "Fee Covered")
+	s.WriteCell(output, "I", row, "Payout Date")
+	row++
+	//
+	// Insert the gifts
+	//
+	for _, transaction := range export.Transactions() {
+		s.WriteCellDate(output, "A", row, transaction.Date())
+		s.WriteCell(output, "B", row, transaction.ID())
+		s.WriteCell(output, "C", row, r.Name(r.Donor, transaction.Key()))
+		s.WriteCell(output, "D", row, transaction.MatchedBy())
+		s.WriteCellDecimal(output, "E", row, transaction.Gross())
+		s.WriteCellDecimal(output, "F", row, transaction.Fee())
+		s.WriteCellDecimal(output, "G", row, transaction.Net())
+		if transaction.FeeCovered() {
+			s.WriteCell(output, "H", row, "Yes")
+		}
+		if payout, paidOut := transaction.Payout(); paidOut {
+			s.WriteCellDate(output, "I", row, payout)
+		}
+		row++
+	}
+}
+
+// outputDeposits fills a tab with the payouts and the deposits that
+// received them.
+func outputDeposits(output *s.SpreadsheetFile, reconciliations []online.Reconciliation) {
+	//
+	// Insert Heading
+	//
+	var row = 1
+	s.WriteCell(output, "A", row, "Payout Date")
+	s.WriteCell(output, "B", row, "Gifts")
+	s.WriteCell(output, "C", row, "Gross")
+	s.WriteCell(output, "D", row, "Fee")
+	s.WriteCell(output, "E", row, "Net")
+	s.WriteCell(output, "F", row, "Deposit Date")
+	s.WriteCell(output, "G", row, "Deposit")
+	s.WriteCell(output, "H", row, "Difference")
+	s.WriteCell(output, "I", row, "Status")
+	row++
+	//
+	// Insert the reconciliations
+	//
+	var unreconciled = 0
+	for _, reconciliation := range reconciliations {
+		var payout, hasPayout = reconciliation.Payout()
+		var deposit, hasDeposit = reconciliation.Deposit()
+		if hasPayout {
+			s.WriteCellDate(output, "A", row, payout.Date())
+			s.WriteCellInt(output, "B", row, payout.Count())
+			s.WriteCellDecimal(output, "C", row, payout.Gross())
+			s.WriteCellDecimal(output, "D", row, payout.Fee())
+			s.WriteCellDecimal(output, "E", row, payout.Net())
+		}
+		if hasDeposit {
+			s.WriteCellDate(output, "F", row, deposit.Date())
+			s.WriteCellDecimal(output, "G", row, deposit.Amount())
+		}
+		if hasPayout && hasDeposit {
+			s.WriteCellDecimal(output, "H", row, reconciliation.Difference())
+		}
+		s.WriteCell(output, "I", row, reconciliation.Status())
+		if reconciliation.Status() != online.StatusMatched {
+			unreconciled++
+		}
+		row++
+	}
+	fmt.Println("Number of payouts and deposits not reconciled: " + strconv.Itoa(unreconciled))
+}
+
+// outputFiscalYears fills a tab with the gross, fees, and net of the online
+// gifts in each fiscal year.  Donor totals use the gross, while finance
+// totals use the net.
+func outputFiscalYears(output *s.SpreadsheetFile, totals []online.Totals) {
+	//
+	// Insert Heading
+	//
+	var row = 1
+	s.WriteCell(output, "A", row, "Fiscal Year")
+	s.WriteCell(output, "B", row, "Gifts")
+	s.WriteCell(output, "C", row, "Gross")
+	s.WriteCell(output, "D", row, "Fees")
+	s.WriteCell(output, "E", row, "Net")
+	s.WriteCell(output, "F", row, "Fees Covered")
+	s.WriteCell(output, "G", row, "Covered Amount")
+	row++
+	//
+	// Insert the totals of each fiscal year
+	//
+	var gross = dec.Zero
+	var fees = dec.Zero
+	var net = dec.Zero
+	for _, total := range totals {
+		s.WriteCell(output, "A", row, total.Label())
+		s.WriteCellInt(output, "B", row, total.Count())
+		s.WriteCellDecimal(output, "C", row, total.Gross())
+		s.WriteCellDecimal(output, "D", row, total.Fee())
+		s.WriteCellDecimal(output, "E", row, total.Net())
+		s.WriteCellInt(output, "F", row, total.Covered())
+		s.WriteCellDecimal(output, "G", row, total.CoveredFees())
+		gross = gross.Add(total.Gross())
+		fees = fees.Add(total.Fee())
+		net = net.Add(total.Net())
+		row++
+	}
+	s.WriteCell(output, "A", row, "Total")
+	s.WriteCellDecimal(output, "C", row, gross)
+	s.WriteCellDecimal(output, "D", row, fees)
+	s.WriteCellDecimal(output, "E", row, net)
+}
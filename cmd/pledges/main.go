@@ -0,0 +1,355 @@
+// ----------------------------------------------------------------------------
+//
+// Pledge fulfillment report
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+// This program matches the gifts of donors with pledges to the installments
+// of their pledges.  The report is as of the date given by the -asof flag,
+// or the end of the current fiscal year.  This program produces a
+// spreadsheet file pledges.xlsx with tabs:
+// -- Pledge Balances: the amount paid and the balance of each pledge
+// -- Overdue Installments: the installments not paid by their due dates
+// -- Expected Receipts: the unpaid installments by fiscal year
+
+package main
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	a "acorn_go/pkg/accounting"
+	dna "acorn_go/pkg/donations"
+	dns "acorn_go/pkg/donors"
+	"acorn_go/pkg/identity"
+	l "acorn_go/pkg/logging"
+	"acorn_go/pkg/pledges"
+	"acorn_go/pkg/rules"
+	s "acorn_go/pkg/spreadsheet"
+	sp "acorn_go/pkg/support"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	dec "github.com/shopspring/decimal"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const (
+	inputFile      = "/home/bozo/golang/acorn_go/data/donations.xlsx"
+	tab            = "Worksheet"
+	donorFile      = "/home/bozo/golang/acorn_go/data/donors.xlsx"
+	tabDonors      = "Sheet1"
+	aliasFile      = "/home/bozo/golang/acorn_go/data/aliases.xlsx"
+	tabAliases     = "Sheet1"
+	pledgeFile     = "/home/bozo/golang/acorn_go/data/pledges.xlsx"
+	tabPledges     = "Sheet1"
+	outputFileName = "/home/bozo/Downloads/pledges.xlsx"
+	balancesTab    = "Pledge Balances"
+	overdueTab     = "Overdue Installments"
+	expectedTab    = "Expected Receipts"
+
+	fy = a.CurrentFiscalYear
+)
+
+// ----------------------------------------------------------------------------
+// Functions
+// ----------------------------------------------------------------------------
+
+// main runs the program and exits with its status.
+func main() {
+	os.Exit(sp.Run("pledges", run))
+}
+
+// run supervises the execution of this program.  It produces a spreadsheet
+// with the balances of the pledges.
+func run() error {
+	var sprdsht s.Spreadsheet
+	var donationList dna.DonationList
+	var donorList dns.DonorList
+	var aliases identity.AliasTable
+	var ledger pledges.Ledger
+	var password string
+	var asOf d.Date
+	var err error
+
+	var asOfFlag = flag.String("asof", "", "date of the report, MM/DD/YYYY (default end of "+fy.String()+")")
+	flag.Parse()
+	printHeader()
+	//
+	// Obtain the date of the report
+	//
+	asOf = fy.End()
+	if *asOfFlag != "" {
+		asOf, err = d.NewFromString(*asOfFlag)
+		if err != nil {
+			return sp.Wrap(err, sp.Usage, "parsing the as-of date")
+		}
+	}
+	//
+	// Obtain password for the output spreadsheet
+	//
+	password, err = s.OutputPassword()
+	if err != nil {
+		return sp.Wrap(err, sp.Usage, "obtaining spreadsheet password")
+	}
+	//
+	// Obtain spreadsheet data
+	//
+	sprdsht, err = s.ProcessData(inputFile, tab)
+	if err != nil {
+		return err
+	}
+	//
+	// Generate donation list
+	//
+	donationList, err = dna.NewDonationList(&sprdsht)
+	if err != nil {
+		return err
+	}
+	//
+	// Match the payees and the pledge donors to the donors
+	//
+	sprdsht, err = s.ProcessData(donorFile, tabDonors)
+	if err != nil {
+		return err
+	}
+	donorList, err = dns.NewDonorAddressList(&sprdsht)
+	if err != nil {
+		return err
+	}
+	aliases, err = identity.ReadAliases(aliasFile, tabAliases)
+	if err != nil {
+		return err
+	}
+	var resolver = identity.NewResolver(donorList.Keys(), aliases)
+	donationList = donationList.Resolve(&resolver)
+	ledger, err = pledges.ReadLedger(pledgeFile, tabPledges)
+	if err != nil {
+		return err
+	}
+	for _, name := range ledger.Resolve(&resolver) {
+		l.Warn("pledge donor is not a donor", "name", name, l.File(pledgeFile))
+	}
+	//
+	// Apply the gifts to the pledges
+	//
+	ledger.Apply(donationList, asOf)
+	err = outputReport(&ledger, asOf, password)
+	if err != nil {
+		return err
+	}
+	printFooter()
+	return err
+}
+
+// ----------------------------------------------------------------------------
+// Print Functions
+// ----------------------------------------------------------------------------
+
+// printHeader prints a message indicating the program has started
+func printHeader() {
+	fmt.Println("-----------------------------------------------------------")
+	fmt.Println("Acorn Scholarship Fund Pledge Fulfillment")
+	fmt.Println("-----------------------------------------------------------")
+}
+
+// printFooter reports the end of the program
+func printFooter() {
+	fmt.Println("-----------------------------------------------------------")
+	fmt.Println("Program has ended")
+	fmt.Println("-----------------------------------------------------------")
+}
+
+// ----------------------------------------------------------------------------
+// Output Functions
+// ----------------------------------------------------------------------------
+
+// outputReport produces a spreadsheet with the pledge balances, the overdue
+// installments, and the expected receipts.  The spreadsheet is encrypted
+// with the password.
+func outputReport(ledger *pledges.Ledger, asOf d.Date, password string) (err error) {
+	var output s.SpreadsheetFile
+	//
+	// Create output spreadsheet
+	//
+	output, err = s.New(outputFileName, balancesTab)
+	if err != nil {
+		return sp.WrapFile(err, sp.Output, "opening output file", outputFileName)
+	}
+	output.SetPassword(password)
+	var finish = func() {
+		err = errors.Join(err, output.Save(), output.Close())
+	}
+	defer finish()
+	//
+	// Output the balances, overdue installments, and expected receipts
+	//
+	outputBalances(&output, ledger, asOf)
+
+	output, err = output.AddSheet(overdueTab)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
+	outputOverdue(&output, ledger, asOf)
+
+	output, err = output.AddSheet(expectedTab)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
+	outputExpected(&output, ledger.Expected(asOf), asOf)
+	//
+	// Output the donations excluded by the rules
+	//
+	output, err = output.AddSheet(rules.AuditTab)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
+	rules.OutputAudit(&output)
+	return err
+}
+
+// outputBalances fills a tab with the amount paid, the balance, and the
+// amount overdue on each pledge.
+func outputBalances(output *s.SpreadsheetFile, ledger *pledges.Ledger, asOf d.Date) {
+	//
+	// Insert Heading
+	//
+	var row = 1
+	s.WriteCell(output, "A", row, "Pledge balances as of "+asOf.String())
+	row += 2
+	s.WriteCell(output, "A", row, "Pledge")
+	s.WriteCell(output, "B", row, "Donor Name")
+	s.WriteCell(output, "C", row, "Campaign")
+	s.WriteCell(output, "D", row, "Schedule")
+	s.WriteCell(output, "E", row, "Installments")
+	s.WriteCell(output, "F", row, "Start Date")
+	s.WriteCell(output, "G", row, "Pledged")
+	s.WriteCell(output, "H", row, "Paid")
+	s.WriteCell(output, "I", row, "Balance")
+	s.WriteCell(output, "J", row, "Overdue")
+	s.WriteCell(output, "K", row, "Status")
+	row++
+	//
+	// Insert the pledges
+	//
+	var pledged = dec.Zero
+	var paid = dec.Zero
+	var overdue = dec.Zero
+	var fulfilled = 0
+	for _, pledge := range ledger.Pledges() {
+		s.WriteCell(output, "A", row, pledge.ID())
+		s.WriteCell(output, "B", row, pledge.Key())
+		s.WriteCell(output, "C", row, pledge.Campaign())
+		s.WriteCell(output, "D", row, pledge.Schedule().String())
+		s.WriteCellInt(output, "E", row, len(pledge.Installments()))
+		s.WriteCellDate(output, "F", row, pledge.Start())
+		s.WriteCellDecimal(output, "G", row, pledge.Amount())
+		s.WriteCellDecimal(output, "H", row, pledge.Paid())
+		s.WriteCellDecimal(output, "I", row, pledge.Balance())
+		s.WriteCellDecimal(output, "J", row, pledge.Overdue(asOf))
+		s.WriteCell(output, "K", row, pledge.Status(asOf))
+		pledged = pledged.Add(pledge.Amount())
+		paid = paid.Add(pledge.Paid())
+		overdue = overdue.Add(pledge.Overdue(asOf))
+		if pledge.IsFulfilled() {
+			fulfilled++
+		}
+		row++
+	}
+	s.WriteCell(output, "A", row, "Total")
+	s.WriteCellDecimal(output, "G", row, pledged)
+	s.WriteCellDecimal(output, "H", row, paid)
+	s.WriteCellDecimal(output, "I", row, pledged.Sub(paid))
+	s.WriteCellDecimal(output, "J", row, overdue)
+	fmt.Println("Number of pledges: " + strconv.Itoa(ledger.Count()))
+	fmt.Println("Number of pledges fulfilled: " + strconv.Itoa(fulfilled))
+}
+
+// outputOverdue fills a tab with the installments not paid in full by their
+// due dates.
+func outputOverdue(output *s.SpreadsheetFile, ledger *pledges.Ledger, asOf d.Date) {
+	//
+	// Insert Heading
+	//
+	var row = 1
+	s.WriteCell(output, "A", row, "Overdue installments as of "+asOf.String())
+	row += 2
+	s.WriteCell(output, "A", row, "Pledge")
+	s.WriteCell(output, "B", row, "Donor Name")
+	s.WriteCell(output, "C", row, "Due Date")
+	s.WriteCell(output, "D", row, "Days Overdue")
+	s.WriteCell(output, "E", row, "Installment")
+	s.WriteCell(output, "F", row, "Paid")
+	s.WriteCell(output, "G", row, "Unpaid")
+	row++
+	//
+	// Insert the overdue installments
+	//
+	var count = 0
+	for _, pledge := range ledger.Pledges() {
+		for _, installment := range pledge.Installments() {
+			if !installment.IsOverdue(asOf) {
+				continue
+			}
+			s.WriteCell(output, "A", row, pledge.ID())
+			s.WriteCell(output, "B", row, pledge.Key())
+			s.WriteCellDate(output, "C", row, installment.Due())
+			s.WriteCellInt(output, "D", row, installment.DaysOverdue(asOf))
+			s.WriteCellDecimal(output, "E", row, installment.Amount())
+			s.WriteCellDecimal(output, "F", row, installment.Paid())
+			s.WriteCellDecimal(output, "G", row, installment.Unpaid())
+			count++
+			row++
+		}
+	}
+	fmt.Println("Number of overdue installments: " + strconv.Itoa(count))
+}
+
+// outputExpected fills a tab with the unpaid installments expected in each
+// fiscal year.  Overdue installments are expected in the fiscal year of the
+// report.
+func outputExpected(output *s.SpreadsheetFile, expected []pledges.Receipts, asOf d.Date) {
+	//
+	// Insert Heading
+	//
+	var row = 1
+	s.WriteCell(output, "A", row, "Expected pledge receipts as of "+asOf.String())
+	row += 2
+	s.WriteCell(output, "A", row, "Fiscal Year")
+	s.WriteCell(output, "B", row, "Installments")
+	s.WriteCell(output, "C", row, "Expected")
+	s.WriteCell(output, "D", row, "Overdue")
+	row++
+	//
+	// Insert the receipts of each fiscal year
+	//
+	var amount = dec.Zero
+	var overdue = dec.Zero
+	var count = 0
+	for _, receipts := range expected {
+		s.WriteCell(output, "A", row, receipts.Label())
+		s.WriteCellInt(output, "B", row, receipts.Installments())
+		s.WriteCellDecimal(output, "C", row, receipts.Amount())
+		s.WriteCellDecimal(output, "D", row, receipts.Overdue())
+		amount = amount.Add(receipts.Amount())
+		overdue = overdue.Add(receipts.Overdue())
+		count += receipts.Installments()
+		row++
+	}
+	s.WriteCell(output, "A", row, "Total")
+	s.WriteCellInt(output, "B", row, count)
+	s.WriteCellDecimal(output, "C", row, amount)
+	s.WriteCellDecimal(output, "D", row, overdue)
+}
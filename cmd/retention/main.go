@@ -8,10 +8,24 @@
 //
 // ----------------------------------------------------------------------------
 
-// This program generates a list of prior year donors who did not donate in
-// in the current year with the dates and amounts they donated.
-// This program produces a spreadsheet file nonrepeat.xlsx with tab:
-// -- Non-Repeat Donors
+// This program generates the lists of donors who have not donated in the
+// current fiscal year with their giving history and contact details.
+// This program produces a spreadsheet file nonrepeat.xlsx with tabs:
+// -- LYBUNT: donors who gave last year but not this year
+// -- SYBUNT: donors who gave in an earlier year but not last year or this year
+// -- Summary: the counts as of the report date and the same date last year
+// -- Sustainers: donors who give monthly, quarterly, or annually, with a flag
+//    for recurring gifts that have missed an expected installment
+//
+// The -asof flag runs the report as of a date in the fiscal year, such as
+// mid-year.  Only the gifts made by that date are used.
+//
+// The -softcredit flag counts the matching and donor-advised fund gifts in
+// softcredits.xlsx for the donors with the soft credit rather than the
+// employers and funds that paid them.
+//
+// The -online flag adds the gifts in the online giving platform's export,
+// online.csv, at their gross amounts.
 
 package main
 
@@ -21,13 +35,22 @@ package main
 
 import (
 	a "acorn_go/pkg/accounting"
-	dn "acorn_go/pkg/donations"
+	dna "acorn_go/pkg/donations"
+	dns "acorn_go/pkg/donors"
+	"acorn_go/pkg/identity"
+	l "acorn_go/pkg/logging"
+	"acorn_go/pkg/online"
+	"acorn_go/pkg/rules"
 	s "acorn_go/pkg/spreadsheet"
 	sp "acorn_go/pkg/support"
+	"errors"
+	"flag"
 	"fmt"
+	"os"
+	"strconv"
 
 	dec "github.com/shopspring/decimal"
-	"github.com/waysys/assert/assert"
+	d "github.com/waysys/waydate/pkg/date"
 )
 
 // ----------------------------------------------------------------------------
@@ -41,8 +64,18 @@ import (
 const (
 	inputFile      = "/home/bozo/golang/acorn_go/data/donations.xlsx"
 	tab            = "Worksheet"
+	donorFile      = "/home/bozo/golang/acorn_go/data/donors.xlsx"
+	tabDonors      = "Sheet1"
+	aliasFile      = "/home/bozo/golang/acorn_go/data/aliases.xlsx"
+	tabAliases     = "Sheet1"
+	softCreditFile = "/home/bozo/golang/acorn_go/data/softcredits.xlsx"
+	tabSoftCredits = "Sheet1"
+	onlineFile     = "/home/bozo/golang/acorn_go/data/online.csv"
 	outputFileName = "/home/bozo/Downloads/nonrepeat.xlsx"
-	sheetName      = "Non-Repeat Donors"
+	lybuntTab      = "LYBUNT"
+	sybuntTab      = "SYBUNT"
+	summaryTab     = "Summary"
+	sustainerTab   = "Sustainers"
 
 	fy = a.CurrentFiscalYear
 )
@@ -51,29 +84,151 @@ const (
 // Functions
 // ----------------------------------------------------------------------------
 
-// main supervises the execution of this program.  It produces a spreadsheet
-// with the list of non-repeat donors
+// main runs the program and exits with its status.
 func main() {
+	os.Exit(sp.Run("retention", run))
+}
+
+// run supervises the execution of this program.  It produces a spreadsheet
+// with the lists of lapsed donors.
+func run() error {
 	var sprdsht s.Spreadsheet
-	var donorList dn.DonationList
+	var donationList dna.DonationList
+	var donorList dns.DonorList
+	var aliases identity.AliasTable
+	var password string
+	var asOf d.Date
 	var err error
 
+	var asOfFlag = flag.String("asof", "", "date of the report, MM/DD/YYYY (default end of "+fy.String()+")")
+	var bySoftCredit = flag.Bool("softcredit", false, "count gifts for the donors with soft credit")
+	var withOnline = flag.Bool("online", false, "add the gifts made through the online giving platform")
+	flag.Parse()
 	printHeader()
 	//
+	// Obtain the date of the report
+	//
+	asOf = fy.End()
+	if *asOfFlag != "" {
+		asOf, err = d.NewFromString(*asOfFlag)
+		if err != nil {
+			return sp.Wrap(err, sp.Usage, "parsing the as-of date")
+		}
+	}
+	//
+	// Obtain password for the output spreadsheet
+	//
+	password, err = s.OutputPassword()
+	if err != nil {
+		return sp.Wrap(err, sp.Usage, "obtaining spreadsheet password")
+	}
+	//
 	// Obtain spreadsheet data
 	//
 	sprdsht, err = s.ProcessData(inputFile, tab)
-	sp.Check(err, "Error processing spreadsheet")
+	if err != nil {
+		return err
+	}
+	//
+	// Generate donation list
+	//
+	donationList, err = dna.NewDonationList(&sprdsht)
+	if err != nil {
+		return err
+	}
+	if *bySoftCredit {
+		var count int
+		count, err = donationList.ReadSoftCredits(softCreditFile, tabSoftCredits)
+		if err != nil {
+			return err
+		}
+		fmt.Println("Number of soft credits: " + strconv.Itoa(count))
+	}
+	//
+	// Generate the donor list with contact details
+	//
+	sprdsht, err = s.ProcessData(donorFile, tabDonors)
+	if err != nil {
+		return err
+	}
+	donorList, err = dns.NewDonorAddressList(&sprdsht)
+	if err != nil {
+		return err
+	}
 	//
-	// Generate donor list
+	// Match the payees to the donors
 	//
-	donorList, err = dn.NewDonationList(&sprdsht)
-	sp.Check(err, "Error generating donor list: ")
+	aliases, err = identity.ReadAliases(aliasFile, tabAliases)
+	if err != nil {
+		return err
+	}
+	var resolver = identity.NewResolver(donorList.Keys(), aliases)
+	donationList = donationList.Resolve(&resolver)
+	if *withOnline {
+		err = addOnline(donationList, &donorList, &resolver)
+		if err != nil {
+			return err
+		}
+	}
+	if *bySoftCredit {
+		donationList = donationList.BySoftCredit()
+	}
 	//
-	// Output non-repeat donor
+	// Output the lapsed donors
 	//
-	outputRetention(&donorList)
+	err = outputRetention(&donationList, &donorList, asOf, password)
+	if err != nil {
+		return err
+	}
 	printFooter()
+	return err
+}
+
+// addOnline adds the gifts in the export of the online giving platform to
+// the donation list.
+func addOnline(donationList dna.DonationList, donorList *dns.DonorList, resolver *identity.Resolver) error {
+	var export, err = online.ReadExport(onlineFile)
+	if err != nil {
+		return err
+	}
+	for _, transaction := range export.Resolve(donorList, resolver) {
+		l.Warn("online donor is not a donor", "name", transaction.Name(), "row", transaction.Row(), l.File(onlineFile))
+	}
+	fmt.Println("Number of online gifts: " + strconv.Itoa(export.AddTo(donationList)))
+	return err
+}
+
+// lapsedDonors returns the donors with the lapse as of the date.  Deceased
+// donors are left out.
+func lapsedDonors(
+	donationList *dna.DonationList,
+	donorList *dns.DonorList,
+	asOf d.Date,
+	lapse dna.Lapse) []dna.LapsedDonor {
+	var result = []dna.LapsedDonor{}
+	for _, lapsed := range dna.LapsedDonors(*donationList, asOf, lapse) {
+		var key = lapsed.Donor().Key()
+		if !donorList.Contains(key) || !donorList.Get(key).Deceased() {
+			result = append(result, lapsed)
+		}
+	}
+	return result
+}
+
+// livingSustainers returns the donors with a recurring gift as of the date.
+// Deceased donors are left out.
+func livingSustainers(
+	donationList *dna.DonationList,
+	donorList *dns.DonorList,
+	asOf d.Date) []dna.Sustainer {
+	var result = []dna.Sustainer{}
+	for _, sustainer := range dna.Sustainers(*donationList, asOf) {
+		var key = sustainer.Donor().Key()
+		if !donorList.Contains(key) || !donorList.Get(key).Deceased() {
+			result = append(result, sustainer)
+		}
+	}
+	return result
 }
 
 // ----------------------------------------------------------------------------
@@ -98,45 +253,228 @@ func printFooter() {
 // Output Functions
 // ----------------------------------------------------------------------------
 
-// outputRetention produces a spreadsheet with the non-repeat donors names
-// and donation dates and amounts listed.
-func outputRetention(donorList *dn.DonationList) {
-	var err error
+// outputRetention produces a spreadsheet with the LYBUNT and SYBUNT donors,
+// a summary comparing them with the same date last year, and the
+// sustainers.  The spreadsheet is encrypted with the password.
+func outputRetention(
+	donationList *dna.DonationList,
+	donorList *dns.DonorList,
+	asOf d.Date,
+	password string) (err error) {
 	var output s.SpreadsheetFile
+	var lastYear = a.SameDayPriorYear(asOf)
 	//
 	// Create output spreadsheet
 	//
-	output, err = s.New(outputFileName, sheetName)
-	sp.Check(err, "Error opening output file: ")
+	output, err = s.New(outputFileName, lybuntTab)
+	if err != nil {
+		return sp.WrapFile(err, sp.Output, "opening output file", outputFileName)
+	}
+	output.SetPassword(password)
 	var finish = func() {
-		err = output.Save()
-		sp.Check(err, "Error saving output file")
-		err = output.Close()
-		sp.Check(err, "Error closing output file")
+		err = errors.Join(err, output.Save(), output.Close())
 	}
 	defer finish()
 	//
+	// Output the LYBUNT and SYBUNT donors
+	//
+	var lybunt = lapsedDonors(donationList, donorList, asOf, dna.Lybunt)
+	outputLapsed(&output, "LYBUNT donors as of "+asOf.String(), lybunt, donorList)
+
+	output, err = output.AddSheet(sybuntTab)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
+	var sybunt = lapsedDonors(donationList, donorList, asOf, dna.Sybunt)
+	outputLapsed(&output, "SYBUNT donors as of "+asOf.String(), sybunt, donorList)
+	//
+	// Output the summary
+	//
+	output, err = output.AddSheet(summaryTab)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
+	var lists = [2][2][]dna.LapsedDonor{
+		{lybunt, lapsedDonors(donationList, donorList, lastYear, dna.Lybunt)},
+		{sybunt, lapsedDonors(donationList, donorList, lastYear, dna.Sybunt)},
+	}
+	outputSummary(&output, [2]d.Date{asOf, lastYear}, lists)
+	fmt.Println("Number of LYBUNT donors: " + strconv.Itoa(len(lybunt)))
+	fmt.Println("Number of SYBUNT donors: " + strconv.Itoa(len(sybunt)))
+	//
+	// Output the sustainers
+	//
+	output, err = output.AddSheet(sustainerTab)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
+	var sustainers = livingSustainers(donationList, donorList, asOf)
+	outputSustainers(&output, "Sustainers as of "+asOf.String(), sustainers, donorList)
+	//
+	// Output the donations excluded by the rules
+	//
+	output, err = output.AddSheet(rules.AuditTab)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
+	rules.OutputAudit(&output)
+	return err
+}
+
+// outputLapsed fills a tab with the contact details and giving history of
+// the lapsed donors.  The contact details come from the donor list.
+func outputLapsed(
+	output *s.SpreadsheetFile,
+	title string,
+	lapsedList []dna.LapsedDonor,
+	donorList *dns.DonorList) {
+	//
 	// Insert Heading
 	//
 	var row = 1
-	s.WriteCell(&output, "A", row, "List of non-repeat donors: "+fy.String())
+	s.WriteCell(output, "A", row, title)
 	row += 2
-	s.WriteCell(&output, "A", row, "Donor Name")
-	s.WriteCell(&output, "B", row, "Amount of Donation")
+	s.WriteCell(output, "A", row, "Donor Name")
+	s.WriteCell(output, "B", row, "Email")
+	s.WriteCell(output, "C", row, "Street")
+	s.WriteCell(output, "D", row, "City")
+	s.WriteCell(output, "E", row, "State")
+	s.WriteCell(output, "F", row, "Zip")
+	s.WriteCell(output, "G", row, "Last Gift Date")
+	s.WriteCell(output, "H", row, "Last Gift Amount")
+	s.WriteCell(output, "I", row, "Last Gift Year")
+	s.WriteCell(output, "J", row, "Last Gift Year Total")
+	s.WriteCell(output, "K", row, "Lifetime Total")
 	row++
 	//
 	// Insert donor information
 	//
-	var names = donorList.DonorKeys()
-	for _, name := range names {
-		var donor = donorList.Get(name)
-		if donor.IsNonRepeatDonor(fy) {
-			var priorAmount = donor.Donation(fy.Prior())
-			assert.Assert(priorAmount.GreaterThan(dec.Zero), "Donor was not a prior donor: "+name)
-			var currentAmount = donor.Donation(fy)
-			assert.Assert(currentAmount.Equal(dec.Zero), "Donor is a current donor: "+name)
-			s.WriteCell(&output, "A", row, name)
-			s.WriteCellDecimal(&output, "B", row, priorAmount)
+	for _, lapsed := range lapsedList {
+		var donor = lapsed.Donor()
+		if donorList.Contains(donor.Key()) {
+			var contact = donorList.Get(donor.Key())
+			s.WriteCell(output, "A", row, contact.Name())
+			if contact.HasEmail() {
+				s.WriteCell(output, "B", row, contact.Email())
+			}
+			s.WriteCell(output, "C", row, contact.Street())
+			s.WriteCell(output, "D", row, contact.City())
+			s.WriteCell(output, "E", row, contact.State())
+			s.WriteCell(output, "F", row, contact.Zip())
+		} else {
+			l.Warn("donor is not in the donor address list", "donor", donor.Key(), l.File(donorFile))
+			s.WriteCell(output, "A", row, donor.Name())
+		}
+		s.WriteCellDate(output, "G", row, lapsed.LastGift().Date())
+		s.WriteCellDecimal(output, "H", row, lapsed.LastGift().Amount())
+		s.WriteCell(output, "I", row, "FY"+strconv.Itoa(lapsed.LastYear()))
+		s.WriteCellDecimal(output, "J", row, lapsed.LastYearTotal())
+		s.WriteCellDecimal(output, "K", row, lapsed.LifetimeTotal())
+		row++
+	}
+}
+
+// outputSustainers fills a tab with the contact details and recurring gift
+// of the sustainers.  The contact details come from the donor list.
+func outputSustainers(
+	output *s.SpreadsheetFile,
+	title string,
+	sustainers []dna.Sustainer,
+	donorList *dns.DonorList) {
+	//
+	// Insert Heading
+	//
+	var row = 1
+	s.WriteCell(output, "A", row, title)
+	row += 2
+	s.WriteCell(output, "A", row, "Donor Name")
+	s.WriteCell(output, "B", row, "Email")
+	s.WriteCell(output, "C", row, "Street")
+	s.WriteCell(output, "D", row, "City")
+	s.WriteCell(output, "E", row, "State")
+	s.WriteCell(output, "F", row, "Zip")
+	s.WriteCell(output, "G", row, "Cadence")
+	s.WriteCell(output, "H", row, "Installments")
+	s.WriteCell(output, "I", row, "Typical Amount")
+	s.WriteCell(output, "J", row, "Last Installment Date")
+	s.WriteCell(output, "K", row, "Last Installment Amount")
+	s.WriteCell(output, "L", row, "Next Expected")
+	s.WriteCell(output, "M", row, "Missed Installments")
+	s.WriteCell(output, "N", row, "Missed")
+	row++
+	//
+	// Insert donor information
+	//
+	var stopped = 0
+	for _, sustainer := range sustainers {
+		var donor = sustainer.Donor()
+		if donorList.Contains(donor.Key()) {
+			var contact = donorList.Get(donor.Key())
+			s.WriteCell(output, "A", row, contact.Name())
+			if contact.HasEmail() {
+				s.WriteCell(output, "B", row, contact.Email())
+			}
+			s.WriteCell(output, "C", row, contact.Street())
+			s.WriteCell(output, "D", row, contact.City())
+			s.WriteCell(output, "E", row, contact.State())
+			s.WriteCell(output, "F", row, contact.Zip())
+		} else {
+			l.Warn("donor is not in the donor address list", "donor", donor.Key(), l.File(donorFile))
+			s.WriteCell(output, "A", row, donor.Name())
+		}
+		s.WriteCell(output, "G", row, sustainer.Cadence().String())
+		s.WriteCellInt(output, "H", row, sustainer.Installments())
+		s.WriteCellDecimal(output, "I", row, sustainer.TypicalAmount())
+		s.WriteCellDate(output, "J", row, sustainer.LastInstallment().Date())
+		s.WriteCellDecimal(output, "K", row, sustainer.LastInstallment().Amount())
+		s.WriteCellDate(output, "L", row, sustainer.NextExpected())
+		s.WriteCellInt(output, "M", row, sustainer.MissedInstallments())
+		var missed = "No"
+		if sustainer.Missed() {
+			missed = "Yes"
+			stopped++
+		}
+		s.WriteCell(output, "N", row, missed)
+		row++
+	}
+	fmt.Println("Number of sustainers: " + strconv.Itoa(len(sustainers)))
+	fmt.Println("Number of sustainers who missed an installment: " + strconv.Itoa(stopped))
+}
+
+// outputSummary fills the summary tab with the number of LYBUNT and SYBUNT
+// donors and their giving as of the report date and the same date last
+// year.
+func outputSummary(
+	output *s.SpreadsheetFile,
+	dates [2]d.Date,
+	lists [2][2][]dna.LapsedDonor) {
+	var names = [2]string{"LYBUNT", "SYBUNT"}
+	//
+	// Insert Heading
+	//
+	var row = 1
+	s.WriteCell(output, "A", row, "Lapsed Donor Summary")
+	row += 2
+	s.WriteCell(output, "A", row, "As of")
+	s.WriteCell(output, "B", row, "Donors")
+	s.WriteCell(output, "C", row, "Last Gift Year Total")
+	s.WriteCell(output, "D", row, "Lifetime Total")
+	row++
+	//
+	// Insert the counts and totals
+	//
+	for index, name := range names {
+		for period, date := range dates {
+			var lastYearTotal = dec.Zero
+			var lifetimeTotal = dec.Zero
+			for _, lapsed := range lists[index][period] {
+				lastYearTotal = lastYearTotal.Add(lapsed.LastYearTotal())
+				lifetimeTotal = lifetimeTotal.Add(lapsed.LifetimeTotal())
+			}
+			s.WriteCell(output, "A", row, name+" "+date.String())
+			s.WriteCellInt(output, "B", row, len(lists[index][period]))
+			s.WriteCellDecimal(output, "C", row, lastYearTotal)
+			s.WriteCellDecimal(output, "D", row, lifetimeTotal)
 			row++
 		}
 	}
@@ -0,0 +1,268 @@
+// ----------------------------------------------------------------------------
+//
+// Reversed gift report
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+// This program lists the refunds, chargebacks, and other reversals of gifts
+// and the gifts they were netted against.  This program produces a
+// spreadsheet file reversals.xlsx with tabs:
+// -- Reversed Gifts: each reversal with the gift it reverses
+// -- Summary: the giving received, reversed, and kept in each fiscal year
+
+package main
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	a "acorn_go/pkg/accounting"
+	dna "acorn_go/pkg/donations"
+	dns "acorn_go/pkg/donors"
+	"acorn_go/pkg/identity"
+	l "acorn_go/pkg/logging"
+	"acorn_go/pkg/rules"
+	s "acorn_go/pkg/spreadsheet"
+	sp "acorn_go/pkg/support"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	dec "github.com/shopspring/decimal"
+)
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const (
+	inputFile      = "/home/bozo/golang/acorn_go/data/donations.xlsx"
+	tab            = "Worksheet"
+	donorFile      = "/home/bozo/golang/acorn_go/data/donors.xlsx"
+	tabDonors      = "Sheet1"
+	aliasFile      = "/home/bozo/golang/acorn_go/data/aliases.xlsx"
+	tabAliases     = "Sheet1"
+	outputFileName = "/home/bozo/Downloads/reversals.xlsx"
+	reversalsTab   = "Reversed Gifts"
+	summaryTab     = "Summary"
+)
+
+// ----------------------------------------------------------------------------
+// Functions
+// ----------------------------------------------------------------------------
+
+// main runs the program and exits with its status.
+func main() {
+	os.Exit(sp.Run("reversals", run))
+}
+
+// run supervises the execution of this program.  It produces a spreadsheet
+// with the reversed gifts.
+func run() error {
+	var sprdsht s.Spreadsheet
+	var donationList dna.DonationList
+	var donorList dns.DonorList
+	var aliases identity.AliasTable
+	var password string
+	var err error
+
+	printHeader()
+	//
+	// Obtain password for the output spreadsheet
+	//
+	password, err = s.OutputPassword()
+	if err != nil {
+		return sp.Wrap(err, sp.Usage, "obtaining spreadsheet password")
+	}
+	//
+	// Obtain spreadsheet data
+	//
+	sprdsht, err = s.ProcessData(inputFile, tab)
+	if err != nil {
+		return err
+	}
+	//
+	// Generate donation list
+	//
+	donationList, err = dna.NewDonationList(&sprdsht)
+	if err != nil {
+		return err
+	}
+	//
+	// Match the payees to the donors
+	//
+	sprdsht, err = s.ProcessData(donorFile, tabDonors)
+	if err != nil {
+		return err
+	}
+	donorList, err = dns.NewDonorAddressList(&sprdsht)
+	if err != nil {
+		return err
+	}
+	aliases, err = identity.ReadAliases(aliasFile, tabAliases)
+	if err != nil {
+		return err
+	}
+	var resolver = identity.NewResolver(donorList.Keys(), aliases)
+	donationList = donationList.Resolve(&resolver)
+	//
+	// Output the reversed gifts
+	//
+	err = outputReport(&donationList, password)
+	if err != nil {
+		return err
+	}
+	printFooter()
+	return err
+}
+
+// ----------------------------------------------------------------------------
+// Print Functions
+// ----------------------------------------------------------------------------
+
+// printHeader prints a message indicating the program has started
+func printHeader() {
+	fmt.Println("-----------------------------------------------------------")
+	fmt.Println("Acorn Scholarship Fund Reversed Gifts")
+	fmt.Println("-----------------------------------------------------------")
+}
+
+// printFooter reports the end of the program
+func printFooter() {
+	fmt.Println("-----------------------------------------------------------")
+	fmt.Println("Program has ended")
+	fmt.Println("-----------------------------------------------------------")
+}
+
+// ----------------------------------------------------------------------------
+// Output Functions
+// ----------------------------------------------------------------------------
+
+// outputReport produces a spreadsheet with the reversed gifts and the
+// summary by fiscal year.  The spreadsheet is encrypted with the password.
+func outputReport(donationList *dna.DonationList, password string) (err error) {
+	var output s.SpreadsheetFile
+	//
+	// Create output spreadsheet
+	//
+	output, err = s.New(outputFileName, reversalsTab)
+	if err != nil {
+		return sp.WrapFile(err, sp.Output, "opening output file", outputFileName)
+	}
+	output.SetPassword(password)
+	var finish = func() {
+		err = errors.Join(err, output.Save(), output.Close())
+	}
+	defer finish()
+	//
+	// Output the reversed gifts and the summary
+	//
+	outputReversals(&output, dna.ReversedGifts(*donationList))
+
+	output, err = output.AddSheet(summaryTab)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
+	outputSummary(&output, dna.SummarizeReversals(*donationList))
+	//
+	// Output the donations excluded by the rules
+	//
+	output, err = output.AddSheet(rules.AuditTab)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
+	rules.OutputAudit(&output)
+	return err
+}
+
+// outputReversals fills a tab with each reversal and the gift it was netted
+// against.  A reversal that matched no gift is reported in the log.
+func outputReversals(output *s.SpreadsheetFile, reversals []dna.ReversedGift) {
+	//
+	// Insert Heading
+	//
+	var row = 1
+	s.WriteCell(output, "A", row, "Reversed Gifts")
+	row += 2
+	s.WriteCell(output, "A", row, "Donor Name")
+	s.WriteCell(output, "B", row, "Type")
+	s.WriteCell(output, "C", row, "Reversal Date")
+	s.WriteCell(output, "D", row, "Amount Reversed")
+	s.WriteCell(output, "E", row, "Gift Date")
+	s.WriteCell(output, "F", row, "Gift Received")
+	s.WriteCell(output, "G", row, "Gift Kept")
+	s.WriteCell(output, "H", row, "Fiscal Year")
+	s.WriteCell(output, "I", row, "Row")
+	row++
+	//
+	// Insert the reversals
+	//
+	var unmatched = 0
+	for _, reversed := range reversals {
+		var reversal = reversed.Reversal()
+		s.WriteCell(output, "A", row, reversed.Donor().Name())
+		s.WriteCell(output, "B", row, reversal.Type())
+		s.WriteCellDate(output, "C", row, reversal.Date())
+		s.WriteCellDecimal(output, "D", row, reversed.Amount())
+		if original, found := reversed.Original(); found {
+			s.WriteCellDate(output, "E", row, original.Date())
+			s.WriteCellDecimal(output, "F", row, original.Received())
+			s.WriteCellDecimal(output, "G", row, original.Amount())
+		} else {
+			l.Warn("reversal matched no gift", "donor", reversed.Donor().Key(),
+				l.File(reversal.File()), l.Row(reversal.Row()))
+			s.WriteCell(output, "E", row, "No matching gift")
+			unmatched++
+		}
+		s.WriteCell(output, "H", row, reversed.FiscalYear().String())
+		s.WriteCellInt(output, "I", row, reversal.Row())
+		row++
+	}
+	fmt.Println("Number of reversals: " + strconv.Itoa(len(reversals)))
+	fmt.Println("Number of reversals that matched no gift: " + strconv.Itoa(unmatched))
+}
+
+// outputSummary fills the summary tab with the giving received, reversed,
+// and kept in each fiscal year.
+func outputSummary(output *s.SpreadsheetFile, summaries [a.NumFiscalYears]dna.ReversalSummary) {
+	//
+	// Insert Heading
+	//
+	var row = 1
+	s.WriteCell(output, "A", row, "Reversals by Fiscal Year")
+	row += 2
+	s.WriteCell(output, "A", row, "Fiscal Year")
+	s.WriteCell(output, "B", row, "Received")
+	s.WriteCell(output, "C", row, "Reversals")
+	s.WriteCell(output, "D", row, "Reversed")
+	s.WriteCell(output, "E", row, "Kept")
+	row++
+	//
+	// Insert the totals of each fiscal year
+	//
+	var received = dec.Zero
+	var reversed = dec.Zero
+	var count = 0
+	for _, summary := range summaries {
+		s.WriteCell(output, "A", row, summary.FiscalYear().String())
+		s.WriteCellDecimal(output, "B", row, summary.Received())
+		s.WriteCellInt(output, "C", row, summary.Reversals())
+		s.WriteCellDecimal(output, "D", row, summary.Reversed())
+		s.WriteCellDecimal(output, "E", row, summary.Kept())
+		received = received.Add(summary.Received())
+		reversed = reversed.Add(summary.Reversed())
+		count += summary.Reversals()
+		row++
+	}
+	s.WriteCell(output, "A", row, "Total")
+	s.WriteCellDecimal(output, "B", row, received)
+	s.WriteCellInt(output, "C", row, count)
+	s.WriteCellDecimal(output, "D", row, reversed)
+	s.WriteCellDecimal(output, "E", row, received.Sub(reversed))
+}
@@ -0,0 +1,320 @@
+// ----------------------------------------------------------------------------
+//
+// RFM donor scoring
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+// This program scores each donor on recency, frequency, and monetary value
+// and places the donor in a segment such as Champions, At Risk, or
+// Hibernating.  This program produces a spreadsheet file rfm.xlsx with tabs:
+// -- RFM Scores: the scores and segment of each donor with contact details
+// -- Segments: the number of donors and their giving in each segment
+//
+// The -asof flag scores the donors as of a date.  Only the gifts made by that
+// date are used.  ACORN_RFM_SCORING sets the breakpoints of the scores.
+
+package main
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	a "acorn_go/pkg/accounting"
+	dna "acorn_go/pkg/donations"
+	dns "acorn_go/pkg/donors"
+	"acorn_go/pkg/identity"
+	l "acorn_go/pkg/logging"
+	"acorn_go/pkg/rules"
+	s "acorn_go/pkg/spreadsheet"
+	sp "acorn_go/pkg/support"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	dec "github.com/shopspring/decimal"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const (
+	inputFile      = "/home/bozo/golang/acorn_go/data/donations.xlsx"
+	tab            = "Worksheet"
+	donorFile      = "/home/bozo/golang/acorn_go/data/donors.xlsx"
+	tabDonors      = "Sheet1"
+	aliasFile      = "/home/bozo/golang/acorn_go/data/aliases.xlsx"
+	tabAliases     = "Sheet1"
+	outputFileName = "/home/bozo/Downloads/rfm.xlsx"
+	scoresTab      = "RFM Scores"
+	segmentsTab    = "Segments"
+
+	fy = a.CurrentFiscalYear
+)
+
+// ----------------------------------------------------------------------------
+// Functions
+// ----------------------------------------------------------------------------
+
+// main runs the program and exits with its status.
+func main() {
+	os.Exit(sp.Run("rfm", run))
+}
+
+// run supervises the execution of this program.  It produces a spreadsheet
+// with the scored donors.
+func run() error {
+	var sprdsht s.Spreadsheet
+	var donationList dna.DonationList
+	var donorList dns.DonorList
+	var aliases identity.AliasTable
+	var scoring dna.RFMScoring
+	var password string
+	var asOf d.Date
+	var err error
+
+	var asOfFlag = flag.String("asof", "", "date of the scores, MM/DD/YYYY (default end of "+fy.String()+")")
+	flag.Parse()
+	printHeader()
+	//
+	// Obtain the date of the scores and the scoring
+	//
+	asOf = fy.End()
+	if *asOfFlag != "" {
+		asOf, err = d.NewFromString(*asOfFlag)
+		if err != nil {
+			return sp.Wrap(err, sp.Usage, "parsing the as-of date")
+		}
+	}
+	scoring, err = dna.ConfiguredRFMScoring()
+	if err != nil {
+		return err
+	}
+	//
+	// Obtain password for the output spreadsheet
+	//
+	password, err = s.OutputPassword()
+	if err != nil {
+		return sp.Wrap(err, sp.Usage, "obtaining spreadsheet password")
+	}
+	//
+	// Obtain spreadsheet data
+	//
+	sprdsht, err = s.ProcessData(inputFile, tab)
+	if err != nil {
+		return err
+	}
+	//
+	// Generate donation list
+	//
+	donationList, err = dna.NewDonationList(&sprdsht)
+	if err != nil {
+		return err
+	}
+	//
+	// Generate the donor list with contact details
+	//
+	sprdsht, err = s.ProcessData(donorFile, tabDonors)
+	if err != nil {
+		return err
+	}
+	donorList, err = dns.NewDonorAddressList(&sprdsht)
+	if err != nil {
+		return err
+	}
+	//
+	// Match the payees to the donors
+	//
+	aliases, err = identity.ReadAliases(aliasFile, tabAliases)
+	if err != nil {
+		return err
+	}
+	var resolver = identity.NewResolver(donorList.Keys(), aliases)
+	donationList = donationList.Resolve(&resolver)
+	//
+	// Score the living donors
+	//
+	var scores = dna.ComputeRFM(livingDonors(&donationList, &donorList), asOf, scoring)
+	err = outputRFM(scores, &donorList, asOf, password)
+	if err != nil {
+		return err
+	}
+	fmt.Println("Number of donors scored: " + strconv.Itoa(len(scores)))
+	printFooter()
+	return err
+}
+
+// livingDonors returns the donation list without the deceased donors.
+func livingDonors(donationList *dna.DonationList, donorList *dns.DonorList) dna.DonationList {
+	var result = make(dna.DonationList)
+	for key, donor := range *donationList {
+		if !donorList.Contains(key) || !donorList.Get(key).Deceased() {
+			result[key] = donor
+		}
+	}
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Print Functions
+// ----------------------------------------------------------------------------
+
+// printHeader prints a message indicating the program has started
+func printHeader() {
+	fmt.Println("-----------------------------------------------------------")
+	fmt.Println("Acorn Scholarship Fund RFM Scoring")
+	fmt.Println("-----------------------------------------------------------")
+}
+
+// printFooter reports the end of the program
+func printFooter() {
+	fmt.Println("-----------------------------------------------------------")
+	fmt.Println("Program has ended")
+	fmt.Println("-----------------------------------------------------------")
+}
+
+// ----------------------------------------------------------------------------
+// Output Functions
+// ----------------------------------------------------------------------------
+
+// outputRFM produces a spreadsheet with the scored donors and a summary of
+// the segments.  The spreadsheet is encrypted with the password.
+func outputRFM(
+	scores []dna.RFMScore,
+	donorList *dns.DonorList,
+	asOf d.Date,
+	password string) (err error) {
+	var output s.SpreadsheetFile
+	//
+	// Create output spreadsheet
+	//
+	output, err = s.New(outputFileName, scoresTab)
+	if err != nil {
+		return sp.WrapFile(err, sp.Output, "opening output file", outputFileName)
+	}
+	output.SetPassword(password)
+	var finish = func() {
+		err = errors.Join(err, output.Save(), output.Close())
+	}
+	defer finish()
+	//
+	// Output the scores and the segments
+	//
+	outputScores(&output, "RFM scores as of "+asOf.String(), scores, donorList)
+
+	output, err = output.AddSheet(segmentsTab)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
+	outputSegments(&output, "RFM segments as of "+asOf.String(), scores)
+	//
+	// Output the donations excluded by the rules
+	//
+	output, err = output.AddSheet(rules.AuditTab)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
+	rules.OutputAudit(&output)
+	return err
+}
+
+// outputScores fills a tab with the contact details, giving, scores, and
+// segment of each donor.  The contact details come from the donor list.
+func outputScores(
+	output *s.SpreadsheetFile,
+	title string,
+	scores []dna.RFMScore,
+	donorList *dns.DonorList) {
+	//
+	// Insert Heading
+	//
+	var row = 1
+	s.WriteCell(output, "A", row, title)
+	row += 2
+	s.WriteCell(output, "A", row, "Donor Name")
+	s.WriteCell(output, "B", row, "Email")
+	s.WriteCell(output, "C", row, "Street")
+	s.WriteCell(output, "D", row, "City")
+	s.WriteCell(output, "E", row, "State")
+	s.WriteCell(output, "F", row, "Zip")
+	s.WriteCell(output, "G", row, "Last Gift Date")
+	s.WriteCell(output, "H", row, "Months Since Last Gift")
+	s.WriteCell(output, "I", row, "Gifts")
+	s.WriteCell(output, "J", row, "Total Giving")
+	s.WriteCell(output, "K", row, "Recency")
+	s.WriteCell(output, "L", row, "Frequency")
+	s.WriteCell(output, "M", row, "Monetary")
+	s.WriteCell(output, "N", row, "RFM Code")
+	s.WriteCell(output, "O", row, "Segment")
+	row++
+	//
+	// Insert donor information
+	//
+	for _, score := range scores {
+		var donor = score.Donor()
+		if donorList.Contains(donor.Key()) {
+			var contact = donorList.Get(donor.Key())
+			s.WriteCell(output, "A", row, contact.Name())
+			if contact.HasEmail() {
+				s.WriteCell(output, "B", row, contact.Email())
+			}
+			s.WriteCell(output, "C", row, contact.Street())
+			s.WriteCell(output, "D", row, contact.City())
+			s.WriteCell(output, "E", row, contact.State())
+			s.WriteCell(output, "F", row, contact.Zip())
+		} else {
+			l.Warn("donor is not in the donor address list", "donor", donor.Key(), l.File(donorFile))
+			s.WriteCell(output, "A", row, donor.Name())
+		}
+		s.WriteCellDate(output, "G", row, score.LastGift())
+		s.WriteCellInt(output, "H", row, score.Months())
+		s.WriteCellInt(output, "I", row, score.Gifts())
+		s.WriteCellDecimal(output, "J", row, score.Total())
+		s.WriteCellInt(output, "K", row, score.Recency())
+		s.WriteCellInt(output, "L", row, score.Frequency())
+		s.WriteCellInt(output, "M", row, score.Monetary())
+		s.WriteCell(output, "N", row, score.Code())
+		s.WriteCell(output, "O", row, score.Segment().String())
+		row++
+	}
+}
+
+// outputSegments fills the segments tab with the number of donors and their
+// total giving in each segment.
+func outputSegments(output *s.SpreadsheetFile, title string, scores []dna.RFMScore) {
+	var counts [dna.NumRFMSegments]int
+	var totals [dna.NumRFMSegments]dec.Decimal
+	for _, score := range scores {
+		counts[score.Segment()]++
+		totals[score.Segment()] = totals[score.Segment()].Add(score.Total())
+	}
+	//
+	// Insert Heading
+	//
+	var row = 1
+	s.WriteCell(output, "A", row, title)
+	row += 2
+	s.WriteCell(output, "A", row, "Segment")
+	s.WriteCell(output, "B", row, "Donors")
+	s.WriteCell(output, "C", row, "Total Giving")
+	row++
+	//
+	// Insert the counts and totals
+	//
+	for _, segment := range dna.RFMSegments {
+		s.WriteCell(output, "A", row, segment.String())
+		s.WriteCellInt(output, "B", row, counts[segment])
+		s.WriteCellDecimal(output, "C", row, totals[segment])
+		row++
+	}
+	s.WriteCell(output, "A", row, "Total")
+	s.WriteCellInt(output, "B", row, len(scores))
+}
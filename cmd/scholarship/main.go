@@ -25,9 +25,12 @@ import (
 	a "acorn_go/pkg/accounting"
 	g "acorn_go/pkg/grants"
 	q "acorn_go/pkg/quickbooks"
+	r "acorn_go/pkg/redact"
 	sp "acorn_go/pkg/spreadsheet"
 	s "acorn_go/pkg/support"
+	"errors"
 	"fmt"
+	"os"
 
 	dec "github.com/shopspring/decimal"
 	"github.com/waysys/assert/assert"
@@ -54,9 +57,13 @@ const startColumn = "B"
 // Main Function
 // ----------------------------------------------------------------------------
 
-// main supervises the processing of scholarship data.
+// main runs the program and exits with its status.
 func main() {
-	var err error = nil
+	os.Exit(s.Run("scholarship", run))
+}
+
+// run supervises the processing of scholarship data.
+func run() (err error) {
 	var apTranlist q.TransList
 	var billList q.BillList
 	var grantList g.GrantList
@@ -79,21 +86,21 @@ func main() {
 	if err == nil {
 		grantList, err = g.AssembleGrantList(&billList, &apTranlist)
 	}
+	if err != nil {
+		return err
+	}
 	//
 	// Output results
 	//
-	if err == nil {
-		output, err = sp.New(outputFile, summary)
+	output, err = sp.New(outputFile, summary)
+	if err != nil {
+		return s.WrapFile(err, s.Output, "opening output file", outputFile)
 	}
-	s.Check(err, "Error: ")
 	//
-	// Defer function to close spreadsheet
+	// Defer function to save and close spreadsheet
 	//
 	var finish = func() {
-		err = output.Save()
-		s.Check(err, "Error saving output file: ")
-		err = output.Close()
-		s.Check(err, "Error closing output file: ")
+		err = errors.Join(err, output.Save(), output.Close())
 	}
 	defer finish()
 	//
@@ -104,26 +111,30 @@ func main() {
 	// Produce recipient tab
 	//
 	output, err = output.AddSheet(recipients)
-	s.Check(err, "Error: ")
+	if err != nil {
+		return s.Wrap(err, s.Output, "adding sheet")
+	}
 	outputRecipientList(&output, &grantList)
 	//
 	// Produce recipient summary tab
 	//
 	output, err = output.AddSheet(recipientSummary)
-	s.Check(err, "Error: ")
-	outputRecipientSummary(&output, &grantList)
+	if err == nil {
+		err = outputRecipientSummary(&output, &grantList)
+	}
+	if err != nil {
+		return s.Wrap(err, s.Output, "producing recipient summary")
+	}
 	//
 	// Produce the name tag list
 	//
 	output, err = output.AddSheet(nameTags)
-	s.Check(err, "Error: ")
+	if err != nil {
+		return s.Wrap(err, s.Output, "adding sheet")
+	}
 	outputNameTagList(&output, &grantList)
-	//
-	// Save results
-	//
-	output.Save()
-	s.Check(err, "Error: ")
 	printFooter()
+	return err
 }
 
 // ----------------------------------------------------------------------------
@@ -251,7 +262,7 @@ func outputRecipientList(output *sp.SpreadsheetFile, grantList *g.GrantList) {
 		//
 		sp.WriteCellDate(output, "A", row, transactionDate)
 		sp.WriteCell(output, "B", row, dependent)
-		sp.WriteCell(output, "C", row, recipient)
+		sp.WriteCell(output, "C", row, r.Name(r.Recipient, recipient))
 		sp.WriteCell(output, "D", row, edInst)
 		sp.WriteCellDecimal(output, columns[transType], row, amount)
 		totals[transType] = totals[transType].Add(amount)
@@ -291,7 +302,7 @@ func computeBalance(balance dec.Decimal, amount dec.Decimal, transType g.TransTy
 }
 
 // outputRecipientSummary creates the RecipSum tab with the recipient summary.
-func outputRecipientSummary(output *sp.SpreadsheetFile, grantList *g.GrantList) {
+func outputRecipientSummary(output *sp.SpreadsheetFile, grantList *g.GrantList) error {
 	//
 	// Create the recipient summary list
 	//
@@ -316,7 +327,9 @@ func outputRecipientSummary(output *sp.SpreadsheetFile, grantList *g.GrantList)
 	// Loop through the recipient summaries
 	//
 	var list, err = g.AssembleRecipientSumList(grantList)
-	s.Check(err, "Error: ")
+	if err != nil {
+		return err
+	}
 	var names = list.Names()
 	var grandCount = 0
 	var ch = sp.NewColumnHandler(startColumn)
@@ -325,8 +338,10 @@ func outputRecipientSummary(output *sp.SpreadsheetFile, grantList *g.GrantList)
 	//
 	for _, name := range names {
 		var recipientSum, err = list.Get(name)
-		s.Check(err, "Error: ")
-		sp.WriteCell(output, "A", row, name)
+		if err != nil {
+			return err
+		}
+		sp.WriteCell(output, "A", row, r.Name(r.Recipient, name))
 		grandCount++
 		//
 		// Cycle through fiscal years
@@ -352,6 +367,7 @@ func outputRecipientSummary(output *sp.SpreadsheetFile, grantList *g.GrantList)
 	//
 	row++
 	sp.OutputTotals(output, row, &ch, totalCount, totalPayments, grandCount)
+	return err
 }
 
 // outputNameTagList produces a list of recipients that have been given awards,
@@ -365,7 +381,7 @@ func outputNameTagList(output *sp.SpreadsheetFile, grantList *g.GrantList) {
 	// Loop through grant list names
 	//
 	for _, name := range names {
-		name = q.RevertName(name)
+		name = q.RevertName(r.Name(r.Recipient, name))
 		sp.WriteCell(output, "A", row, name)
 		row++
 	}
@@ -19,10 +19,13 @@ package main
 // ----------------------------------------------------------------------------
 
 import (
+	l "acorn_go/pkg/logging"
 	sp "acorn_go/pkg/spreadsheet"
 	s "acorn_go/pkg/support"
+	"errors"
 	"fmt"
 	"maps"
+	"os"
 	"slices"
 	"strconv"
 
@@ -55,9 +58,13 @@ const (
 // Functions
 // ----------------------------------------------------------------------------
 
-// main supervises the processing of scholarship analysis.
+// main runs the program and exits with its status.
 func main() {
-	var err error
+	os.Exit(s.Run("scholarship_analysis", run))
+}
+
+// run supervises the processing of scholarship analysis.
+func run() (err error) {
 	var sprdsht sp.Spreadsheet
 	var billList []Entry
 	var output sp.SpreadsheetFile
@@ -67,7 +74,9 @@ func main() {
 	// Read the bills
 	//
 	sprdsht, err = readBills()
-	s.Check(err, "Error: ")
+	if err != nil {
+		return err
+	}
 	//
 	// Build bill list
 	//
@@ -85,12 +94,11 @@ func main() {
 	// Create output spreadsheet
 	//
 	output, err = sp.New(outputFile, outputTab1)
-	s.Check(err, "Error opening output file: ")
+	if err != nil {
+		return s.WrapFile(err, s.Output, "opening output file", outputFile)
+	}
 	var finish = func() {
-		var err1 = output.Save()
-		s.Check(err1, "Error saving output file: ")
-		err1 = output.Close()
-		s.Check(err1, "Error closing output file: ")
+		err = errors.Join(err, output.Save(), output.Close())
 	}
 	defer finish()
 	//
@@ -101,15 +109,20 @@ func main() {
 	// Produce the individual grant analysis
 	//
 	output, err = output.AddSheet(outputTab2)
-	s.Check(err, "Error adding individual grant tab: ")
+	if err != nil {
+		return s.Wrap(err, s.Output, "adding individual grant tab")
+	}
 	outputIndividualGrants(billCount, billAmount, &output)
 	//
 	// Produce the associate/dependent scholarship count
 	//
 	output, err = output.AddSheet(outputTab3)
-	s.Check(err, "Error adding dependent count tab: ")
+	if err != nil {
+		return s.Wrap(err, s.Output, "adding dependent count tab")
+	}
 	outputDependentCount(billCount, &output)
 	printFooter()
+	return err
 }
 
 // ----------------------------------------------------------------------------
@@ -137,7 +150,8 @@ func processBills(sprdsht *sp.Spreadsheet) []Entry {
 	for row := 1; row < numRows; row++ {
 		var entry, err = processBill(sprdsht, row)
 		if err != nil {
-			fmt.Printf("spreadsheet row %d: %s \n", row+1, err)
+			l.Warn("bill skipped because it cannot be read", "error", err,
+				l.File(sprdsht.FileName()), l.Row(row+1))
 		} else if entry.Scholarship().TermPeriod() != Outside {
 			billList = append(billList, entry)
 		}
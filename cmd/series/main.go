@@ -22,9 +22,11 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"os"
 
 	a "acorn_go/pkg/accounting"
 	ds "acorn_go/pkg/donationseries"
+	"acorn_go/pkg/rules"
 	s "acorn_go/pkg/spreadsheet"
 	sp "acorn_go/pkg/support"
 
@@ -59,30 +61,50 @@ const (
 // Main Function
 // ----------------------------------------------------------------------------
 
-// main supervises the execution of this program.  It produces a spreadsheet
-// with the donation series by year and month
+// main runs the program and exits with its status.
 func main() {
+	os.Exit(sp.Run("series", run))
+}
+
+// run supervises the execution of this program.  It produces a spreadsheet
+// with the donation series by year and month
+func run() error {
 	var sprdsht s.Spreadsheet
 	var donationSeries ds.DonationSeries
+	var password string
 	var err error
 
 	printHeader()
 	//
+	// Obtain password for the output spreadsheet
+	//
+	password, err = s.OutputPassword()
+	if err != nil {
+		return sp.Wrap(err, sp.Usage, "obtaining spreadsheet password")
+	}
+	//
 	// Obtain spreadsheet data
 	//
 	sprdsht, err = s.ProcessData(inputFile, tab)
-	sp.Check(err, "Error processing spreadsheet")
+	if err != nil {
+		return err
+	}
 	//
 	// Generate donation series
 	//
 	donationSeries, err = ds.NewDonationSeries(&sprdsht)
-	sp.Check(err, "Error generating donation series")
+	if err != nil {
+		return err
+	}
 	//
 	// Output donation series to spreadsheet
 	//
-	err = outputDonationSeries(&donationSeries)
-	sp.Check(err, "Error writing output")
+	err = outputDonationSeries(&donationSeries, password)
+	if err != nil {
+		return err
+	}
 	printFooter()
+	return err
 }
 
 // ----------------------------------------------------------------------------
@@ -108,8 +130,8 @@ func printFooter() {
 // ----------------------------------------------------------------------------
 
 // outputDonationSeries produces a spreadsheet with the donation time series
-func outputDonationSeries(dsPtr *(ds.DonationSeries)) error {
-	var err error = nil
+// encrypted with the password.
+func outputDonationSeries(dsPtr *(ds.DonationSeries), password string) (err error) {
 	var output s.SpreadsheetFile
 	var keys []d.YearMonth
 	var totalDonations = make([]float64, a.NumFiscalYears)
@@ -127,16 +149,14 @@ func outputDonationSeries(dsPtr *(ds.DonationSeries)) error {
 
 	output, err = s.New(outputFileName, sheetName)
 	if err != nil {
-		return err
+		return sp.WrapFile(err, sp.Output, "opening output file", outputFileName)
 	}
+	output.SetPassword(password)
 	//
 	// Defer function to close spreadsheet
 	//
 	var finish = func() {
-		err = output.Save()
-		sp.Check(err, "Error saving output file: ")
-		err = output.Close()
-		sp.Check(err, "Error closing output file: ")
+		err = errors.Join(err, output.Save(), output.Close())
 	}
 	defer finish()
 	//
@@ -200,6 +220,14 @@ func outputDonationSeries(dsPtr *(ds.DonationSeries)) error {
 		outputTotals(output, label, totalDonations[fy], row)
 		row++
 	}
+	//
+	// Output the donations excluded by the rules
+	//
+	output, err = output.AddSheet(rules.AuditTab)
+	if err != nil {
+		return sp.Wrap(err, sp.Output, "adding sheet")
+	}
+	rules.OutputAudit(&output)
 	return err
 }
 
@@ -23,7 +23,9 @@ import (
 
 	"acorn_go/pkg/donors"
 	v "acorn_go/pkg/email"
+	r "acorn_go/pkg/redact"
 	"acorn_go/pkg/spreadsheet"
+	sp "acorn_go/pkg/support"
 )
 
 // ----------------------------------------------------------------------------
@@ -37,14 +39,28 @@ const addressListTab = "Sheet1"
 // Functions
 // ----------------------------------------------------------------------------
 
+// main runs the program and exits with its status.
 func main() {
+	os.Exit(sp.Run("validate", run))
+}
+
+// run validates the email address of each donor.
+func run() error {
 	var addressList donors.DonorList
+	var err error
 
 	printHeader()
 	//
+	// Validation requires the actual email addresses
+	//
+	r.Disable()
+	//
 	// Fetch email addresses
 	//
-	addressList = generateAddresses()
+	addressList, err = generateAddresses()
+	if err != nil {
+		return err
+	}
 	//
 	// Validate each emil
 	//
@@ -54,7 +70,9 @@ func main() {
 		if address.HasEmail() {
 			var email = address.Email()
 			var result, err = v.Validate(email)
-			check(err, "Error validating email belonging to: "+address.Name())
+			if err != nil {
+				return sp.Wrap(err, sp.Other, "validating email belonging to "+address.Name())
+			}
 			if !result {
 				fmt.Println("invalid email for " + key + ": " + email)
 			}
@@ -63,10 +81,11 @@ func main() {
 		}
 
 	}
+	return err
 }
 
 // generateAddresses creates the collection of addresses
-func generateAddresses() donors.DonorList {
+func generateAddresses() (donors.DonorList, error) {
 	var sprdsht spreadsheet.Spreadsheet
 	var addressList donors.DonorList
 	var err error
@@ -74,13 +93,14 @@ func generateAddresses() donors.DonorList {
 	// Obtain spreadsheet data
 	//
 	sprdsht, err = spreadsheet.ProcessData(addressListFile, addressListTab)
-	check(err, "Error generating address list: ")
+	if err != nil {
+		return addressList, err
+	}
 	//
 	// Generate address list
 	//
 	addressList, err = donors.NewDonorAddressList(&sprdsht)
-	check(err, "Error generating address list: ")
-	return addressList
+	return addressList, err
 }
 
 // ----------------------------------------------------------------------------
@@ -93,16 +113,3 @@ func printHeader() {
 	fmt.Println("Acorn Scholarship Fund Email Validation")
 	fmt.Println("-----------------------------------------------------------")
 }
-
-// ----------------------------------------------------------------------------
-// Support Functions
-// ----------------------------------------------------------------------------
-
-// check tests an error to see if it is null.  If not, it prints an
-// error message and exits the program.
-func check(err error, message string) {
-	if err != nil {
-		fmt.Println(message + err.Error())
-		os.Exit(1)
-	}
-}
@@ -9,6 +9,7 @@ require (
 	github.com/waysys/waydate v1.1.1
 	github.com/xuri/excelize/v2 v2.9.1
 	github.com/zerobounce/zerobouncego v1.1.0
+	golang.org/x/term v0.32.0
 )
 
 require (
@@ -24,6 +25,7 @@ require (
 	github.com/xuri/nfp v0.0.1 // indirect
 	golang.org/x/crypto v0.38.0 // indirect
 	golang.org/x/net v0.40.0 // indirect
+	golang.org/x/sys v0.33.0 // indirect
 	golang.org/x/text v0.25.0 // indirect
 	gopkg.in/guregu/null.v4 v4.0.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
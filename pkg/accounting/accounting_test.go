@@ -148,3 +148,123 @@ func Test_FYIndicator_Prior(t *testing.T) {
 		}
 	}
 }
+
+// Test_FiscalYearNumber checks FiscalYearNumber inside and outside the
+// fiscal years of the analysis.
+func Test_FiscalYearNumber(t *testing.T) {
+	tests := []struct {
+		month d.Month
+		day   d.Day
+		year  d.Year
+		want  int
+	}{
+		{8, 31, 2019, 2019},
+		{9, 1, 2019, 2020},
+		{9, 1, 2022, 2023},
+		{8, 31, 2026, 2026},
+		{12, 31, 2026, 2027},
+	}
+
+	for _, tt := range tests {
+		var date, _ = d.New(tt.month, tt.day, tt.year)
+		got := FiscalYearNumber(date)
+		if got != tt.want {
+			t.Errorf("FiscalYearNumber(%s) = %d; want %d", date.String(), got, tt.want)
+		}
+	}
+	if FiscalYearBegins(2023).String() != FY2023.Begin().String() {
+		t.Error("FiscalYearBegins(2023) should be " + FY2023.Begin().String())
+	}
+	if FY2023.Number() != 2023 || FY2026.Number() != 2026 {
+		t.Error("fiscal year numbers do not match the fiscal year names")
+	}
+}
+
+// Test_SameDayPriorYear checks SameDayPriorYear including a leap day.
+func Test_SameDayPriorYear(t *testing.T) {
+	var date, _ = d.New(2, 29, 2024)
+	var got = SameDayPriorYear(date).String()
+	var want, _ = d.New(2, 28, 2023)
+	if got != want.String() {
+		t.Errorf("SameDayPriorYear(%s) = %s; want %s", date.String(), got, want.String())
+	}
+	date, _ = d.New(3, 15, 2026)
+	want, _ = d.New(3, 15, 2025)
+	got = SameDayPriorYear(date).String()
+	if got != want.String() {
+		t.Errorf("SameDayPriorYear(%s) = %s; want %s", date.String(), got, want.String())
+	}
+}
+
+// Test_DaysBetween checks DaysBetween and AddDays across a leap day and a
+// year end.
+func Test_DaysBetween(t *testing.T) {
+	var from, _ = d.New(2, 1, 2024)
+	var thru, _ = d.New(3, 1, 2024)
+	if days := DaysBetween(from, thru); days != 29 {
+		t.Errorf("DaysBetween(%s, %s) = %d; want 29", from.String(), thru.String(), days)
+	}
+	if days := DaysBetween(thru, from); days != -29 {
+		t.Errorf("DaysBetween(%s, %s) = %d; want -29", thru.String(), from.String(), days)
+	}
+	var date, _ = d.New(12, 15, 2025)
+	var want, _ = d.New(1, 14, 2026)
+	if got := AddDays(date, 30).String(); got != want.String() {
+		t.Errorf("AddDays(%s, 30) = %s; want %s", date.String(), got, want.String())
+	}
+}
+
+// Test_CompareDates checks CompareDates for earlier, later, and equal dates.
+func Test_CompareDates(t *testing.T) {
+	var early, _ = d.New(12, 31, 2025)
+	var late, _ = d.New(1, 1, 2026)
+	if CompareDates(early, late) >= 0 || CompareDates(late, early) <= 0 || CompareDates(late, late) != 0 {
+		t.Errorf("CompareDates(%s, %s) = %d; want negative", early.String(), late.String(), CompareDates(early, late))
+	}
+}
+
+// Test_Percent checks Percent and PercentChange, including the zero and
+// negative totals that give zero.
+func Test_Percent(t *testing.T) {
+	if got := Percent(1, 3); got != 33 {
+		t.Errorf("Percent(1, 3) = %v; want 33", got)
+	}
+	if got := Percent(1, 0); got != 0 {
+		t.Errorf("Percent(1, 0) = %v; want 0", got)
+	}
+	if got := PercentChange(200, 150); got != -25 {
+		t.Errorf("PercentChange(200, 150) = %v; want -25", got)
+	}
+	if got := PercentChange(-100, 50); got != 0 {
+		t.Errorf("PercentChange(-100, 50) = %v; want 0", got)
+	}
+}
+
+// Test_AddMonths checks AddMonths at the end of a month and across a year
+// end.
+func Test_AddMonths(t *testing.T) {
+	var tests = []struct {
+		date   string
+		months int
+		want   string
+	}{
+		{"01/31/2024", 1, "02/29/2024"},
+		{"01/31/2025", 1, "02/28/2025"},
+		{"11/15/2025", 3, "02/15/2026"},
+		{"07/01/2025", 12, "07/01/2026"},
+		{"03/31/2026", -1, "02/28/2026"},
+	}
+	for _, tt := range tests {
+		var date, err = d.NewFromString(tt.date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var want d.Date
+		if want, err = d.NewFromString(tt.want); err != nil {
+			t.Fatal(err)
+		}
+		if got := AddMonths(date, tt.months).String(); got != want.String() {
+			t.Errorf("AddMonths(%s, %d) = %s; want %s", date.String(), tt.months, got, want.String())
+		}
+	}
+}
@@ -15,6 +15,8 @@ package accounting
 // ----------------------------------------------------------------------------
 
 import (
+	"time"
+
 	d "github.com/waysys/waydate/pkg/date"
 	"github.com/waysys/waydate/pkg/daterange"
 )
@@ -108,6 +110,68 @@ func IsCurrentMonth(date d.Date) bool {
 	return result
 }
 
+// SameDayPriorYear returns the date one year before the date.  February 29
+// becomes February 28.
+func SameDayPriorYear(date d.Date) d.Date {
+	var day = date.Day()
+	if date.Month() == 2 && day == 29 {
+		day = 28
+	}
+	var prior, err = d.New(date.Month(), day, date.Year()-1)
+	if err != nil {
+		panic(err)
+	}
+	return prior
+}
+
+// DaysBetween returns the number of days from the first date to the
+// second.  It is negative if the second date is before the first.
+func DaysBetween(from d.Date, thru d.Date) int {
+	var hours = toTime(thru).Sub(toTime(from)).Hours()
+	return int(hours / 24)
+}
+
+// AddDays returns the date the number of days after the date.
+func AddDays(date d.Date, days int) d.Date {
+	var result = toTime(date).AddDate(0, 0, days)
+	var later, err = d.New(d.Month(result.Month()), d.Day(result.Day()), d.Year(result.Year()))
+	if err != nil {
+		panic(err)
+	}
+	return later
+}
+
+// CompareDates returns a negative number if the first date is earlier, a
+// positive number if it is later, and zero if they are the same.
+func CompareDates(x d.Date, y d.Date) int {
+	switch {
+	case x.Before(y):
+		return -1
+	case x.After(y):
+		return 1
+	default:
+		return 0
+	}
+}
+
+// AddMonths returns the date the number of months after the date.  A day
+// past the end of the later month becomes the last day of that month.
+func AddMonths(date d.Date, months int) d.Date {
+	var first = time.Date(int(date.Year()), time.Month(date.Month())+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	var lastDay = first.AddDate(0, 1, -1).Day()
+	var day = min(int(date.Day()), lastDay)
+	var later, err = d.New(d.Month(first.Month()), d.Day(day), d.Year(first.Year()))
+	if err != nil {
+		panic(err)
+	}
+	return later
+}
+
+// toTime returns the date as a time at midnight UTC.
+func toTime(date d.Date) time.Time {
+	return time.Date(int(date.Year()), time.Month(date.Month()), int(date.Day()), 0, 0, 0, 0, time.UTC)
+}
+
 // CurrentMonth returns the current month range as a string.
 func CurrentMonth() string {
 	var result = currentMonth.String()
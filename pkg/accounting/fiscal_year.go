@@ -15,6 +15,9 @@ package accounting
 // ----------------------------------------------------------------------------
 
 import (
+	"strconv"
+
+	"github.com/waysys/assert/assert"
 	"github.com/waysys/waydate/pkg/daterange"
 
 	d "github.com/waysys/waydate/pkg/date"
@@ -158,6 +161,25 @@ func FiscalYearIndicator(date d.Date) FYIndicator {
 	return indicator
 }
 
+// FiscalYearNumber returns the calendar year in which the fiscal year
+// containing the date ends.  Unlike FiscalYearIndicator, it is defined for
+// dates outside the fiscal years of the analysis.
+func FiscalYearNumber(date d.Date) int {
+	var year = int(date.Year())
+	if date.Month() >= FiscalYear2023Begin.Month() {
+		year++
+	}
+	return year
+}
+
+// FiscalYearBegins returns the first day of the fiscal year that ends in the
+// calendar year.
+func FiscalYearBegins(number int) d.Date {
+	var date, err = d.New(FiscalYear2023Begin.Month(), 1, d.Year(number-1))
+	assert.Assert(err == nil, "invalid fiscal year: "+strconv.Itoa(number))
+	return date
+}
+
 // FiscalYearFromYearMonth returns a FYIndicator value based on the YearMonth.
 func FiscalYearFromYearMonth(yearMonth d.YearMonth) (FYIndicator, error) {
 	var err error = nil
@@ -204,3 +226,32 @@ func (ind FYIndicator) Prior() FYIndicator {
 	}
 	return result
 }
+
+// Begin returns the first day of the fiscal year.
+func (ind FYIndicator) Begin() d.Date {
+	var begins = [NumFiscalYears]d.Date{
+		FiscalYear2023Begin,
+		FiscalYear2024Begin,
+		FiscalYear2025Begin,
+		FiscalYear2026Begin,
+	}
+	assert.Assert(IsFYIndicator(ind), "Invalid FYIndicator: "+ind.String())
+	return begins[ind]
+}
+
+// End returns the last day of the fiscal year.
+func (ind FYIndicator) End() d.Date {
+	var ends = [NumFiscalYears]d.Date{
+		FiscalYear2023End,
+		FiscalYear2024End,
+		FiscalYear2025End,
+		FiscalYear2026End,
+	}
+	assert.Assert(IsFYIndicator(ind), "Invalid FYIndicator: "+ind.String())
+	return ends[ind]
+}
+
+// Number returns the calendar year in which the fiscal year ends.
+func (ind FYIndicator) Number() int {
+	return FiscalYearNumber(ind.Begin())
+}
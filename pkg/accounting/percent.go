@@ -0,0 +1,44 @@
+// ----------------------------------------------------------------------------
+//
+// Percents
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package accounting
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"math"
+)
+
+// ----------------------------------------------------------------------------
+// Functions
+// ----------------------------------------------------------------------------
+
+// Percent returns the part as a percent of the total, rounded to the
+// nearest whole percent, or zero if the total is not greater than zero.
+func Percent(part float64, total float64) float64 {
+	var result = 0.00
+	if total > 0.00 {
+		result = math.Round(100.0 * part / total)
+	}
+	return result
+}
+
+// PercentChange returns the percent change from the prior value to the
+// current value, rounded to the nearest whole percent, or zero if the prior
+// value is not greater than zero.
+func PercentChange(prior float64, current float64) float64 {
+	var change = 0.00
+	if prior > 0.00 {
+		change = math.Round(100.0 * (current - prior) / prior)
+	}
+	return change
+}
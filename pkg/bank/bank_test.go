@@ -0,0 +1,209 @@
+// ----------------------------------------------------------------------------
+//
+// Bank reconciliation tests
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package bank
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"testing"
+
+	dec "github.com/shopspring/decimal"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Test Support
+// ----------------------------------------------------------------------------
+
+// sgmlText is an OFX version 1 statement without closing tags.  It has a
+// deposit of one gift, a batched deposit of three gifts, a withdrawal, and
+// interest with no book entry.
+const sgmlText = "OFXHEADER:100\nDATA:OFXSGML\nVERSION:102\n\n" +
+	"<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS><CURDEF>USD\n" +
+	"<BANKACCTFROM><BANKID>053101121<ACCTID>1234567890<ACCTTYPE>CHECKING</BANKACCTFROM>\n" +
+	"<BANKTRANLIST><DTSTART>20251001<DTEND>20251031\n" +
+	"<STMTTRN><TRNTYPE>DEP<DTPOSTED>20251003120000.000[-5:EST]<TRNAMT>500.00<FITID>B1<NAME>DEPOSIT</STMTTRN>\n" +
+	"<STMTTRN><TRNTYPE>DEP<DTPOSTED>20251010<TRNAMT>1,175.00<FITID>B2<NAME>DEPOSIT<MEMO>3 ITEMS</STMTTRN>\n" +
+	"<STMTTRN><TRNTYPE>DEBIT<DTPOSTED>20251012<TRNAMT>-4000.00<FITID>B3<NAME>CHECK 1042</STMTTRN>\n" +
+	"<STMTTRN><TRNTYPE>INT<DTPOSTED>20251031<TRNAMT>2.15<FITID>B4<NAME>INTEREST &amp; DIVIDENDS</STMTTRN>\n" +
+	"</BANKTRANLIST></STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>\n"
+
+// xmlText is an OFX version 2 statement with closing tags and no statement
+// period.
+const xmlText = "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<?OFX OFXHEADER=\"200\" VERSION=\"220\"?>\n" +
+	"<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS><BANKTRANLIST>\n" +
+	"<STMTTRN><TRNTYPE>CREDIT</TRNTYPE><DTPOSTED>20251105</DTPOSTED><TRNAMT>75.00</TRNAMT>" +
+	"<FITID>X2</FITID><NAME>Deposit</NAME></STMTTRN>\n" +
+	"<STMTTRN><TRNTYPE>CREDIT</TRNTYPE><DTPOSTED>20251102</DTPOSTED><TRNAMT>25.00</TRNAMT>" +
+	"<FITID>X1</FITID><NAME>Deposit</NAME></STMTTRN>\n" +
+	"</BANKTRANLIST></STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>\n"
+
+// date returns the date for the tests.
+func date(t *testing.T, value string) d.Date {
+	t.Helper()
+	var result, err = d.NewFromString(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+// parse returns the statement in the text.
+func parse(t *testing.T, text string) Statement {
+	var statement, err = ParseStatement(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return statement
+}
+
+// entry returns a donation entry for the tests.
+func entry(t *testing.T, value string, payee string, amount int64, row int) Entry {
+	t.Helper()
+	return NewEntry(date(t, value), payee, "Payment", dec.NewFromInt(amount), SourceDonations, row)
+}
+
+// ----------------------------------------------------------------------------
+// Tests
+// ----------------------------------------------------------------------------
+
+// TestParseStatement checks the statement period, the transactions, and the
+// deposits of SGML and XML statements.
+func TestParseStatement(t *testing.T) {
+	var statement = parse(t, sgmlText)
+	if statement.Account() != "1234567890" || statement.Start().String() != "10/01/2025" ||
+		statement.End().String() != "10/31/2025" {
+		t.Errorf("got account %s from %s to %s", statement.Account(), statement.Start().String(),
+			statement.End().String())
+	}
+	if len(statement.Transactions()) != 4 || len(statement.Deposits()) != 3 {
+		t.Fatalf("got %d transactions and %d deposits", len(statement.Transactions()), len(statement.Deposits()))
+	}
+	var deposit = statement.Deposits()[1]
+	if deposit.ID() != "B2" || deposit.Date().String() != "10/10/2025" || !deposit.Amount().Equal(dec.NewFromInt(1175)) ||
+		deposit.Memo() != "3 ITEMS" {
+		t.Errorf("got deposit %s on %s of %s", deposit.ID(), deposit.Date().String(), deposit.Amount().String())
+	}
+	if name := statement.Deposits()[2].Name(); name != "INTEREST & DIVIDENDS" {
+		t.Errorf("got name %q", name)
+	}
+
+	statement = parse(t, xmlText)
+	var transactions = statement.Transactions()
+	if len(transactions) != 2 || transactions[0].ID() != "X1" || statement.Start().String() != "11/02/2025" ||
+		statement.End().String() != "11/05/2025" {
+		t.Errorf("got %d transactions from %s to %s", len(transactions), statement.Start().String(),
+			statement.End().String())
+	}
+
+	for _, text := range []string{"no statement", "<OFX></OFX>", "<OFX><STMTTRN><TRNAMT>5.00</STMTTRN></OFX>"} {
+		if _, err := ParseStatement(text); err == nil {
+			t.Errorf("invalid statement %q was accepted", text)
+		}
+	}
+}
+
+// TestReconcile checks that deposits are matched with single entries and
+// batches of entries, and the deposits and entries left unmatched.
+func TestReconcile(t *testing.T) {
+	var statement = parse(t, sgmlText)
+	var entries = []Entry{
+		entry(t, "10/08/2025", "Baker, Bob", 100, 5),
+		entry(t, "10/01/2025", "Apple, Ann", 500, 2),
+		entry(t, "10/07/2025", "Cook, Carl", 75, 4),
+		entry(t, "10/09/2025", "Dean, Dot", 1000, 6),
+		entry(t, "10/29/2025", "Eden, Eve", 50, 8),
+		entry(t, "09/20/2025", "Fox, Fay", 300, 1),
+		entry(t, "11/02/2025", "Gray, Gus", 25, 9),
+		NewEntry(date(t, "10/02/2025"), "Truist Bank", "Deposit", dec.NewFromInt(4000), SourceAccountsPayable, 0),
+	}
+	var reconciliation = Reconcile(&statement, entries)
+
+	var matched = reconciliation.Matched()
+	if len(matched) != 2 {
+		t.Fatalf("got %d matched deposits, want 2", len(matched))
+	}
+	if matched[0].Deposit().ID() != "B1" || matched[0].IsBatch() || matched[0].Entries()[0].Payee() != "Apple, Ann" {
+		t.Errorf("deposit B1 was not matched with Ann")
+	}
+	var batch = matched[1].Entries()
+	if matched[1].Deposit().ID() != "B2" || len(batch) != 3 || batch[0].Payee() != "Cook, Carl" ||
+		batch[1].Payee() != "Baker, Bob" || batch[2].Payee() != "Dean, Dot" {
+		t.Errorf("deposit B2 was not matched with the batch of Carl, Bob, and Dot: %v", batch)
+	}
+
+	var unmatchedBank = reconciliation.UnmatchedBank()
+	if len(unmatchedBank) != 1 || unmatchedBank[0].ID() != "B4" {
+		t.Errorf("got unmatched deposits %v, want B4", unmatchedBank)
+	}
+	var unmatchedBook = reconciliation.UnmatchedBook()
+	if len(unmatchedBook) != 2 || unmatchedBook[0].Source() != SourceAccountsPayable ||
+		unmatchedBook[1].Payee() != "Eden, Eve" {
+		t.Errorf("got unmatched entries %v, want the AP deposit and Eve", unmatchedBook)
+	}
+}
+
+// TestFindBatch checks that the earliest entries that make up the amount
+// are found and that a single entry is not a batch.
+func TestFindBatch(t *testing.T) {
+	var entries = []Entry{
+		entry(t, "10/01/2025", "A", 10, 2),
+		entry(t, "10/01/2025", "B", 20, 3),
+		entry(t, "10/02/2025", "C", 30, 4),
+		entry(t, "10/03/2025", "D", 40, 5),
+	}
+	var indexes = []int{0, 1, 2, 3}
+	var tests = []struct {
+		amount int64
+		want   []int
+	}{
+		{30, []int{0, 1}},
+		{50, []int{0, 3}},
+		{100, []int{0, 1, 2, 3}},
+		{40, []int{0, 2}},
+		{15, nil},
+		{110, nil},
+	}
+	for _, tt := range tests {
+		var got = findBatch(dec.NewFromInt(tt.amount), indexes, entries)
+		if len(got) != len(tt.want) {
+			t.Errorf("%d: got %v, want %v", tt.amount, got, tt.want)
+			continue
+		}
+		for index := range got {
+			if got[index] != tt.want[index] {
+				t.Errorf("%d: got %v, want %v", tt.amount, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+// TestAccountNumber checks that only the operating account is read as
+// account 1010, not an account whose number begins with 1010.
+func TestAccountNumber(t *testing.T) {
+	var tests = []struct {
+		account string
+		want    string
+	}{
+		{"1010 Cash:Cash in bank - operating", "1010"},
+		{" 1010", "1010"},
+		{"10100 Cash:Savings", "10100"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := accountNumber(tt.account); got != tt.want {
+			t.Errorf("accountNumber(%q) = %q, want %q", tt.account, got, tt.want)
+		}
+	}
+}
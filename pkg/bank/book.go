@@ -0,0 +1,193 @@
+// ----------------------------------------------------------------------------
+//
+// Book Entries
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package bank
+
+// A book entry is money recorded in Quickbooks as received into the
+// operating account, 1010 Cash.  The entries come from two workbooks: the
+// payments and deposits in the donation spreadsheet, and the deposits of
+// refunded scholarships in the accounts payable register.  Refunds and
+// other rows with an amount that is not positive are not deposits and are
+// left out.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	a "acorn_go/pkg/accounting"
+	"acorn_go/pkg/quickbooks"
+	"acorn_go/pkg/spreadsheet"
+	"slices"
+	"strings"
+
+	dec "github.com/shopspring/decimal"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Entry is a deposit to the operating account recorded in Quickbooks.
+type Entry struct {
+	date      d.Date
+	payee     string
+	transType string
+	amount    dec.Decimal
+	source    string
+	row       int
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Source of a book entry
+const (
+	SourceDonations       = "Donations"
+	SourceAccountsPayable = "Accounts Payable"
+)
+
+// Donation spreadsheet column names
+const (
+	columnDate    = "Date"
+	columnPayee   = "Payee"
+	columnType    = "Type"
+	columnAccount = "Account"
+	columnPayment = "Payment"
+)
+
+// ----------------------------------------------------------------------------
+// Factory Functions
+// ----------------------------------------------------------------------------
+
+// NewEntry creates a book entry.  The row is the row of the entry in the
+// source spreadsheet, as shown by Excel, or zero if it is not known.
+func NewEntry(date d.Date, payee string, transType string, amount dec.Decimal, source string, row int) Entry {
+	return Entry{
+		date:      date,
+		payee:     payee,
+		transType: transType,
+		amount:    amount,
+		source:    source,
+		row:       row,
+	}
+}
+
+// ReadDonationEntries returns the rows of the Quickbooks donation
+// spreadsheet that deposit a positive amount to the operating account.
+func ReadDonationEntries(sprdsht *spreadsheet.Spreadsheet) ([]Entry, error) {
+	var entries = []Entry{}
+	var numRows = sprdsht.Size()
+	for row := 1; row < numRows; row++ {
+		var entry = Entry{source: SourceDonations, row: row + 1}
+		var account string
+		var err error
+
+		account, err = sprdsht.Cell(row, columnAccount)
+		if err == nil && accountNumber(account) != quickbooks.AccountChecking {
+			continue
+		}
+		if err == nil {
+			entry.amount, err = sprdsht.CellDecimal(row, columnPayment)
+		}
+		if err == nil && !entry.amount.GreaterThan(dec.Zero) {
+			continue
+		}
+		if err == nil {
+			entry.date, err = sprdsht.CellDate(row, columnDate)
+		}
+		if err == nil {
+			entry.payee, err = sprdsht.Cell(row, columnPayee)
+		}
+		if err == nil {
+			entry.transType, err = sprdsht.Cell(row, columnType)
+		}
+		if err != nil {
+			return entries, sprdsht.WrapRow(err, "processing book entry", row)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// APEntries returns the deposits to the operating account in the accounts
+// payable transactions.
+func APEntries(transList *quickbooks.TransList) []Entry {
+	var entries = []Entry{}
+	for index := 0; index < transList.Size(); index++ {
+		var trans = transList.Get(index)
+		if !trans.IsDeposit() {
+			continue
+		}
+		entries = append(entries, NewEntry(
+			trans.TransactionDate(),
+			trans.Vendor().Name(),
+			trans.TransactionType().String(),
+			dec.Decimal(trans.Amount()),
+			SourceAccountsPayable,
+			0))
+	}
+	return entries
+}
+
+// SortEntries sorts the entries in date order.
+func SortEntries(entries []Entry) {
+	slices.SortStableFunc(entries, func(x Entry, y Entry) int {
+		return a.CompareDates(x.date, y.date)
+	})
+}
+
+// ----------------------------------------------------------------------------
+// Entry Methods
+// ----------------------------------------------------------------------------
+
+// Date returns the date of the entry.
+func (entry Entry) Date() d.Date {
+	return entry.date
+}
+
+// Payee returns the payee of the entry, such as the donor.
+func (entry Entry) Payee() string {
+	return entry.payee
+}
+
+// Type returns the Quickbooks transaction type of the entry.
+func (entry Entry) Type() string {
+	return entry.transType
+}
+
+// Amount returns the amount deposited.
+func (entry Entry) Amount() dec.Decimal {
+	return entry.amount
+}
+
+// Source returns the workbook of the entry, Donations or Accounts Payable.
+func (entry Entry) Source() string {
+	return entry.source
+}
+
+// Row returns the row of the entry in its workbook, as shown by Excel, or
+// zero if it is not known.
+func (entry Entry) Row() int {
+	return entry.row
+}
+
+// ----------------------------------------------------------------------------
+// Support Functions
+// ----------------------------------------------------------------------------
+
+// accountNumber returns the number of a Quickbooks account, the part of the
+// account name before the first space.
+func accountNumber(account string) string {
+	var number, _, _ = strings.Cut(strings.TrimSpace(account), " ")
+	return number
+}
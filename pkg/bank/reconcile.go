@@ -0,0 +1,240 @@
+// ----------------------------------------------------------------------------
+//
+// Deposit Reconciliation
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package bank
+
+// Each deposit in the bank statement is matched with the book entries it
+// received.  The bank posts a deposit on or after the date it is recorded
+// in Quickbooks, so an entry may be matched with a deposit posted up to the
+// deposit window after the date of the entry.
+//
+// The deposits are matched in date order in two passes.  The first pass
+// matches a deposit with the latest entry of the same amount, the one
+// recorded nearest the deposit date.  The second pass matches a deposit
+// with a batch of two or more entries that add up to its amount, such as
+// the checks taken to the bank together.  Only the entries nearest the
+// deposit date are searched for a batch.
+//
+// Entries dated before the statement period are used only to match the
+// deposits at the start of the period, since the others were deposited in
+// an earlier statement.  Entries dated after the period are left out.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	a "acorn_go/pkg/accounting"
+	"slices"
+
+	dec "github.com/shopspring/decimal"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Match is a bank deposit and the book entries it received.
+type Match struct {
+	deposit Transaction
+	entries []Entry
+}
+
+// Reconciliation is the result of matching the deposits in a statement
+// with the book entries.
+type Reconciliation struct {
+	matched       []Match
+	unmatchedBank []Transaction
+	unmatchedBook []Entry
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Number of days after the date of a book entry that the bank may post the
+// deposit
+const DepositWindow = 5
+
+// Largest number of entries searched for a batch that makes up a deposit
+const maxCandidates = 16
+
+// ----------------------------------------------------------------------------
+// Functions
+// ----------------------------------------------------------------------------
+
+// Reconcile matches the deposits in the statement with the book entries.
+func Reconcile(statement *Statement, entries []Entry) Reconciliation {
+	var deposits = statement.Deposits()
+	var first = a.AddDays(statement.start, -DepositWindow)
+	entries = slices.DeleteFunc(slices.Clone(entries), func(entry Entry) bool {
+		return entry.date.Before(first) || entry.date.After(statement.end)
+	})
+	SortEntries(entries)
+	var used = make([]bool, len(entries))
+	var matched = make([][]int, len(deposits))
+	//
+	// Match deposits with single entries of the same amount
+	//
+	for index, deposit := range deposits {
+		var indexes = candidates(deposit, entries, used)
+		slices.Reverse(indexes)
+		for _, candidate := range indexes {
+			if entries[candidate].amount.Equal(deposit.amount) {
+				matched[index] = []int{candidate}
+				used[candidate] = true
+				break
+			}
+		}
+	}
+	//
+	// Match the remaining deposits with batches of entries
+	//
+	for index, deposit := range deposits {
+		if matched[index] != nil {
+			continue
+		}
+		var batch = findBatch(deposit.amount, nearest(deposit, entries, candidates(deposit, entries, used)), entries)
+		for _, candidate := range batch {
+			used[candidate] = true
+		}
+		matched[index] = batch
+	}
+	//
+	// Assemble the lists
+	//
+	var reconciliation = Reconciliation{
+		matched:       []Match{},
+		unmatchedBank: []Transaction{},
+		unmatchedBook: []Entry{},
+	}
+	for index, deposit := range deposits {
+		if matched[index] == nil {
+			reconciliation.unmatchedBank = append(reconciliation.unmatchedBank, deposit)
+			continue
+		}
+		var match = Match{deposit: deposit, entries: []Entry{}}
+		for _, candidate := range matched[index] {
+			match.entries = append(match.entries, entries[candidate])
+		}
+		SortEntries(match.entries)
+		reconciliation.matched = append(reconciliation.matched, match)
+	}
+	for index, entry := range entries {
+		if !used[index] && !entry.date.Before(statement.start) {
+			reconciliation.unmatchedBook = append(reconciliation.unmatchedBook, entry)
+		}
+	}
+	return reconciliation
+}
+
+// ----------------------------------------------------------------------------
+// Reconciliation Methods
+// ----------------------------------------------------------------------------
+
+// Matched returns the deposits with the entries they received in the order
+// of the deposits.
+func (reconciliation *Reconciliation) Matched() []Match {
+	return slices.Clone(reconciliation.matched)
+}
+
+// UnmatchedBank returns the deposits in the statement with no book entries.
+func (reconciliation *Reconciliation) UnmatchedBank() []Transaction {
+	return slices.Clone(reconciliation.unmatchedBank)
+}
+
+// UnmatchedBook returns the book entries in the statement period that are
+// not in a deposit.
+func (reconciliation *Reconciliation) UnmatchedBook() []Entry {
+	return slices.Clone(reconciliation.unmatchedBook)
+}
+
+// ----------------------------------------------------------------------------
+// Match Methods
+// ----------------------------------------------------------------------------
+
+// Deposit returns the bank deposit.
+func (match Match) Deposit() Transaction {
+	return match.deposit
+}
+
+// Entries returns the book entries received by the deposit in date order.
+func (match Match) Entries() []Entry {
+	return slices.Clone(match.entries)
+}
+
+// IsBatch returns true if the deposit received more than one entry.
+func (match Match) IsBatch() bool {
+	return len(match.entries) > 1
+}
+
+// ----------------------------------------------------------------------------
+// Support Functions
+// ----------------------------------------------------------------------------
+
+// candidates returns the indexes of the unused entries that may be
+// received by the deposit, in date order.
+func candidates(deposit Transaction, entries []Entry, used []bool) []int {
+	var first = a.AddDays(deposit.date, -DepositWindow)
+	var result = []int{}
+	for index, entry := range entries {
+		if !used[index] && !entry.date.Before(first) && !entry.date.After(deposit.date) {
+			result = append(result, index)
+		}
+	}
+	return result
+}
+
+// nearest returns at most maxCandidates of the candidates, keeping those
+// dated nearest the deposit, in date order.
+func nearest(deposit Transaction, entries []Entry, indexes []int) []int {
+	if len(indexes) <= maxCandidates {
+		return indexes
+	}
+	var result = slices.Clone(indexes)
+	slices.SortStableFunc(result, func(x int, y int) int {
+		return a.DaysBetween(entries[x].date, deposit.date) - a.DaysBetween(entries[y].date, deposit.date)
+	})
+	result = result[:maxCandidates]
+	slices.Sort(result)
+	return result
+}
+
+// findBatch returns the indexes of two or more entries that add up to the
+// amount, or nil if there are none.  The search prefers the earliest
+// entries.  Since the amounts are positive, a batch is abandoned once its
+// total is more than the amount or the remaining entries cannot make it up.
+func findBatch(amount dec.Decimal, indexes []int, entries []Entry) []int {
+	var remaining = make([]dec.Decimal, len(indexes)+1)
+	remaining[len(indexes)] = dec.Zero
+	for position := len(indexes) - 1; position >= 0; position-- {
+		remaining[position] = remaining[position+1].Add(entries[indexes[position]].amount)
+	}
+	var batch = []int{}
+	var search func(position int, total dec.Decimal) bool
+	search = func(position int, total dec.Decimal) bool {
+		if total.Equal(amount) {
+			return len(batch) > 1
+		}
+		if position == len(indexes) || total.GreaterThan(amount) || total.Add(remaining[position]).LessThan(amount) {
+			return false
+		}
+		batch = append(batch, indexes[position])
+		if search(position+1, total.Add(entries[indexes[position]].amount)) {
+			return true
+		}
+		batch = batch[:len(batch)-1]
+		return search(position+1, total)
+	}
+	if search(0, dec.Zero) {
+		return batch
+	}
+	return nil
+}
@@ -0,0 +1,304 @@
+// ----------------------------------------------------------------------------
+//
+// Bank Statement
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+// The bank package reconciles the deposits in a bank statement download
+// with the deposits to the operating account recorded in Quickbooks.
+package bank
+
+// A bank statement is downloaded from the bank as an OFX or QFX file.  QFX
+// is the Quicken version of OFX and has the same structure.  Version 1 of
+// OFX is SGML, in which an element with a value may have no closing tag,
+// while version 2 is XML.  Both are read by splitting the body into tags and
+// values, so a value ends at the next tag whether or not it is a closing
+// tag.  The header before the <OFX> tag is skipped.
+//
+// Each transaction is a STMTTRN element with the elements TRNTYPE,
+// DTPOSTED, TRNAMT, FITID, NAME, and MEMO.  A deposit has a positive
+// amount.  The statement period is given by DTSTART and DTEND of the
+// BANKTRANLIST element.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"errors"
+	"html"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	a "acorn_go/pkg/accounting"
+	sp "acorn_go/pkg/support"
+
+	dec "github.com/shopspring/decimal"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Transaction is a transaction in the bank statement.
+type Transaction struct {
+	id        string
+	transType string
+	date      d.Date
+	amount    dec.Decimal
+	name      string
+	memo      string
+}
+
+// Statement is the account and the transactions of a bank statement
+// download.
+type Statement struct {
+	fileName     string
+	account      string
+	start        d.Date
+	end          d.Date
+	transactions []Transaction
+}
+
+// element is a tag and the text that follows it up to the next tag.
+type element struct {
+	tag   string
+	value string
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// OFX tags
+const (
+	tagOFX         = "OFX"
+	tagAccount     = "ACCTID"
+	tagStart       = "DTSTART"
+	tagEnd         = "DTEND"
+	tagTransaction = "STMTTRN"
+	tagType        = "TRNTYPE"
+	tagPosted      = "DTPOSTED"
+	tagAmount      = "TRNAMT"
+	tagID          = "FITID"
+	tagName        = "NAME"
+	tagMemo        = "MEMO"
+)
+
+// ----------------------------------------------------------------------------
+// Factory Functions
+// ----------------------------------------------------------------------------
+
+// ReadStatement reads an OFX or QFX bank statement download.
+func ReadStatement(fileName string) (Statement, error) {
+	var content, err = os.ReadFile(fileName)
+	if err != nil {
+		return Statement{fileName: fileName}, sp.WrapFile(err, sp.Input, "reading bank statement", fileName)
+	}
+	var statement Statement
+	statement, err = ParseStatement(string(content))
+	statement.fileName = fileName
+	if err != nil {
+		err = sp.WrapFile(err, sp.Input, "parsing bank statement", fileName)
+	}
+	return statement, err
+}
+
+// ParseStatement returns the statement in the text of an OFX or QFX file.
+// The transactions are in date order.
+func ParseStatement(text string) (Statement, error) {
+	var statement = Statement{transactions: []Transaction{}}
+	var start = strings.Index(strings.ToUpper(text), "<"+tagOFX+">")
+	if start < 0 {
+		return statement, errors.New("file does not contain an <OFX> tag")
+	}
+	var transaction *Transaction
+	var hasStart, hasEnd bool
+	var err error
+	for _, elem := range elements(text[start:]) {
+		switch {
+		case elem.tag == tagTransaction:
+			transaction = &Transaction{}
+		case elem.tag == "/"+tagTransaction && transaction != nil:
+			if transaction.id == "" {
+				err = errors.New("transaction on " + transaction.date.String() + " has no " + tagID)
+			} else {
+				statement.transactions = append(statement.transactions, *transaction)
+			}
+			transaction = nil
+		case transaction != nil:
+			err = transaction.set(elem)
+		case elem.tag == tagAccount:
+			statement.account = elem.value
+		case elem.tag == tagStart:
+			statement.start, err = parseDate(elem.value)
+			hasStart = true
+		case elem.tag == tagEnd:
+			statement.end, err = parseDate(elem.value)
+			hasEnd = true
+		}
+		if err != nil {
+			return statement, err
+		}
+	}
+	if len(statement.transactions) == 0 {
+		return statement, errors.New("statement has no transactions")
+	}
+	slices.SortStableFunc(statement.transactions, func(x Transaction, y Transaction) int {
+		return a.CompareDates(x.date, y.date)
+	})
+	if !hasStart {
+		statement.start = statement.transactions[0].date
+	}
+	if !hasEnd {
+		statement.end = statement.transactions[len(statement.transactions)-1].date
+	}
+	return statement, nil
+}
+
+// ----------------------------------------------------------------------------
+// Statement Methods
+// ----------------------------------------------------------------------------
+
+// FileName returns the name of the file the statement was read from.
+func (statement *Statement) FileName() string {
+	return statement.fileName
+}
+
+// Account returns the account number in the statement.
+func (statement *Statement) Account() string {
+	return statement.account
+}
+
+// Start returns the first date of the statement period.
+func (statement *Statement) Start() d.Date {
+	return statement.start
+}
+
+// End returns the last date of the statement period.
+func (statement *Statement) End() d.Date {
+	return statement.end
+}
+
+// Transactions returns a copy of the transactions in date order.
+func (statement *Statement) Transactions() []Transaction {
+	return slices.Clone(statement.transactions)
+}
+
+// Deposits returns the transactions with a positive amount in date order.
+func (statement *Statement) Deposits() []Transaction {
+	var deposits = []Transaction{}
+	for _, transaction := range statement.transactions {
+		if transaction.IsDeposit() {
+			deposits = append(deposits, transaction)
+		}
+	}
+	return deposits
+}
+
+// ----------------------------------------------------------------------------
+// Transaction Methods
+// ----------------------------------------------------------------------------
+
+// ID returns the identifier the bank assigned to the transaction.
+func (transaction Transaction) ID() string {
+	return transaction.id
+}
+
+// Type returns the OFX transaction type, such as DEP or CREDIT.
+func (transaction Transaction) Type() string {
+	return transaction.transType
+}
+
+// Date returns the date the transaction was posted.
+func (transaction Transaction) Date() d.Date {
+	return transaction.date
+}
+
+// Amount returns the amount of the transaction.  A withdrawal is negative.
+func (transaction Transaction) Amount() dec.Decimal {
+	return transaction.amount
+}
+
+// Name returns the name of the payer or payee given by the bank.
+func (transaction Transaction) Name() string {
+	return transaction.name
+}
+
+// Memo returns the memo given by the bank.
+func (transaction Transaction) Memo() string {
+	return transaction.memo
+}
+
+// IsDeposit returns true if the transaction adds to the account.
+func (transaction Transaction) IsDeposit() bool {
+	return transaction.amount.GreaterThan(dec.Zero)
+}
+
+// set sets the field of the transaction named by the tag of the element.
+func (transaction *Transaction) set(elem element) error {
+	var err error
+	switch elem.tag {
+	case tagType:
+		transaction.transType = elem.value
+	case tagPosted:
+		transaction.date, err = parseDate(elem.value)
+	case tagAmount:
+		transaction.amount, err = dec.NewFromString(strings.ReplaceAll(elem.value, ",", ""))
+		if err != nil {
+			err = errors.New("invalid " + tagAmount + ": " + elem.value)
+		}
+	case tagID:
+		transaction.id = elem.value
+	case tagName:
+		transaction.name = elem.value
+	case tagMemo:
+		transaction.memo = elem.value
+	}
+	return err
+}
+
+// ----------------------------------------------------------------------------
+// Support Functions
+// ----------------------------------------------------------------------------
+
+// elements splits the body of an OFX file into tags and their values.  The
+// tags are converted to upper case, and entities in the values, such as
+// &amp;, are replaced.
+func elements(text string) []element {
+	var result = []element{}
+	for _, part := range strings.Split(text, "<")[1:] {
+		var tag, value, found = strings.Cut(part, ">")
+		if !found {
+			continue
+		}
+		result = append(result, element{
+			tag:   strings.ToUpper(strings.TrimSpace(tag)),
+			value: html.UnescapeString(strings.TrimSpace(value)),
+		})
+	}
+	return result
+}
+
+// parseDate returns the date of an OFX date and time, which begins with
+// YYYYMMDD.  The time and the time zone are ignored.
+func parseDate(value string) (d.Date, error) {
+	var date d.Date
+	if len(value) < 8 {
+		return date, errors.New("invalid OFX date: " + value)
+	}
+	var year, errYear = strconv.Atoi(value[0:4])
+	var month, errMonth = strconv.Atoi(value[4:6])
+	var day, errDay = strconv.Atoi(value[6:8])
+	if errYear != nil || errMonth != nil || errDay != nil {
+		return date, errors.New("invalid OFX date: " + value)
+	}
+	return d.New(d.Month(month), d.Day(day), d.Year(year))
+}
@@ -0,0 +1,187 @@
+// ----------------------------------------------------------------------------
+//
+// Campaign
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package campaigns
+
+// A campaign matches a gift when the campaign column contains one of its
+// values, ignoring case, or when the gift falls in one of its date windows.
+// A window given as MM/DD applies every year and may wrap past December 31.
+// A window given as MM/DD/YYYY applies only to those dates.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"acorn_go/pkg/spreadsheet"
+	"errors"
+	"strconv"
+	"strings"
+
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Campaign is an appeal or event with the values and date windows that
+// identify its gifts.
+type Campaign struct {
+	Name    string   `json:"name"`
+	Values  []string `json:"values,omitempty"`
+	Windows []Window `json:"windows,omitempty"`
+}
+
+// Window is a range of dates, inclusive, in the form MM/DD or MM/DD/YYYY.
+type Window struct {
+	From     string `json:"from"`
+	Thru     string `json:"thru"`
+	annual   bool
+	fromDay  int
+	thruDay  int
+	fromDate d.Date
+	thruDate d.Date
+}
+
+// ----------------------------------------------------------------------------
+// Functions
+// ----------------------------------------------------------------------------
+
+// Attribute returns the campaign of the gift in a row of the donation
+// spreadsheet.  If the campaign column is not in the spreadsheet, only the
+// date windows are used.
+func (config *Config) Attribute(sprdsht *spreadsheet.Spreadsheet, row int, date d.Date) (string, error) {
+	var value string
+	var err error
+	if config.Column != "" && sprdsht.HasColumn(config.Column) {
+		value, err = sprdsht.Cell(row, config.Column)
+		if err != nil {
+			return NoCampaign, err
+		}
+	}
+	return config.Match(value, date), err
+}
+
+// Match returns the name of the first campaign that matches the value of
+// the campaign column or the date, or NoCampaign.
+func (config *Config) Match(value string, date d.Date) string {
+	for _, campaign := range config.Campaigns {
+		if campaign.Matches(value, date) {
+			return campaign.Name
+		}
+	}
+	return NoCampaign
+}
+
+// ----------------------------------------------------------------------------
+// Campaign Methods
+// ----------------------------------------------------------------------------
+
+// Matches returns true if the value contains one of the campaign's values
+// or the date is in one of its windows.
+func (campaign *Campaign) Matches(value string, date d.Date) bool {
+	var text = strings.ToLower(value)
+	for _, entry := range campaign.Values {
+		var entryText = strings.ToLower(strings.TrimSpace(entry))
+		if entryText != "" && strings.Contains(text, entryText) {
+			return true
+		}
+	}
+	for _, window := range campaign.Windows {
+		if window.Contains(date) {
+			return true
+		}
+	}
+	return false
+}
+
+// validate checks that the campaign has values or windows and converts the
+// windows.
+func (campaign *Campaign) validate() error {
+	if len(campaign.Values) == 0 && len(campaign.Windows) == 0 {
+		return errors.New("campaign has no values or windows")
+	}
+	for index := range campaign.Windows {
+		var err = campaign.Windows[index].validate()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+// Window Methods
+// ----------------------------------------------------------------------------
+
+// Contains returns true if the date is in the window.
+func (window *Window) Contains(date d.Date) bool {
+	if !window.annual {
+		return !date.Before(window.fromDate) && !date.After(window.thruDate)
+	}
+	var day = dayOfYear(int(date.Month()), int(date.Day()))
+	if window.fromDay <= window.thruDay {
+		return window.fromDay <= day && day <= window.thruDay
+	}
+	return day >= window.fromDay || day <= window.thruDay
+}
+
+// validate converts the limits of the window.  Both limits must have the
+// same form.
+func (window *Window) validate() error {
+	var fromParts = strings.Split(window.From, "/")
+	var thruParts = strings.Split(window.Thru, "/")
+	var err error
+	switch {
+	case len(fromParts) == 2 && len(thruParts) == 2:
+		window.annual = true
+		window.fromDay, err = parseMonthDay(fromParts)
+		if err == nil {
+			window.thruDay, err = parseMonthDay(thruParts)
+		}
+	case len(fromParts) == 3 && len(thruParts) == 3:
+		window.fromDate, err = d.NewFromString(window.From)
+		if err == nil {
+			window.thruDate, err = d.NewFromString(window.Thru)
+		}
+		if err == nil && window.thruDate.Before(window.fromDate) {
+			err = errors.New("from date is after thru date")
+		}
+	default:
+		err = errors.New("window must be MM/DD to MM/DD or MM/DD/YYYY to MM/DD/YYYY")
+	}
+	if err != nil {
+		err = errors.New("window " + window.From + " - " + window.Thru + ": " + err.Error())
+	}
+	return err
+}
+
+// ----------------------------------------------------------------------------
+// Support Functions
+// ----------------------------------------------------------------------------
+
+// parseMonthDay returns the day of the year of a month and day.
+func parseMonthDay(parts []string) (int, error) {
+	var month, err = strconv.Atoi(strings.TrimSpace(parts[0]))
+	var day int
+	if err == nil {
+		day, err = strconv.Atoi(strings.TrimSpace(parts[1]))
+	}
+	if err == nil && (month < 1 || month > 12 || day < 1 || day > 31) {
+		err = errors.New("month or day is out of range")
+	}
+	return dayOfYear(month, day), err
+}
+
+// dayOfYear returns a number that orders the month and day within a year.
+func dayOfYear(month int, day int) int {
+	return month*100 + day
+}
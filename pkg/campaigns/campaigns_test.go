@@ -0,0 +1,139 @@
+// ----------------------------------------------------------------------------
+//
+// Campaign tests
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package campaigns
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Test Support
+// ----------------------------------------------------------------------------
+
+// date returns the date for the tests.
+func date(t *testing.T, value string) d.Date {
+	t.Helper()
+	var result, err = d.NewFromString(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+// writeConfig writes a campaigns file and returns its name.
+func writeConfig(t *testing.T, content string) string {
+	var fileName = filepath.Join(t.TempDir(), "campaigns.json")
+	var err = os.WriteFile(fileName, []byte(content), 0o600)
+	if err != nil {
+		t.Fatal("unable to write campaigns file: " + err.Error())
+	}
+	return fileName
+}
+
+// ----------------------------------------------------------------------------
+// Tests
+// ----------------------------------------------------------------------------
+
+// TestBuiltInCampaigns checks that the built-in campaigns match the memos.
+func TestBuiltInCampaigns(t *testing.T) {
+	var config, err = Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tests = []struct {
+		memo     string
+		campaign string
+	}{
+		{"Giving Tuesday", GivingTuesday},
+		{"Year-end appeal", YearEndAppeal},
+		{"YEAR END gift", YearEndAppeal},
+		{"Spring celebration", SpringEvent},
+		{"", NoCampaign},
+		{"Refund of gift", NoCampaign},
+	}
+	for _, test := range tests {
+		if campaign := config.Match(test.memo, date(t, "12/01/2025")); campaign != test.campaign {
+			t.Errorf("%q: got %s, want %s", test.memo, campaign, test.campaign)
+		}
+	}
+	var names = config.Names()
+	if len(names) != 4 || names[3] != NoCampaign {
+		t.Errorf("incorrect campaign names: %v", names)
+	}
+}
+
+// TestWindows checks the campaigns found by date windows.
+func TestWindows(t *testing.T) {
+	var content = `{
+		"column": "Class",
+		"campaigns": [
+			{"name": "Giving Tuesday", "windows": [
+				{"from": "12/02/2025", "thru": "12/03/2025"},
+				{"from": "12/03/2024", "thru": "12/04/2024"}]},
+			{"name": "Year-End Appeal", "values": ["Appeal"], "windows": [{"from": "11/15", "thru": "01/15"}]},
+			{"name": "Spring Event", "windows": [{"from": "04/01", "thru": "05/31"}]}
+		]
+	}`
+	var config, err = Load(writeConfig(t, content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tests = []struct {
+		class    string
+		date     string
+		campaign string
+	}{
+		{"", "12/02/2025", "Giving Tuesday"},
+		{"", "12/02/2024", "Year-End Appeal"},
+		{"", "01/10/2026", "Year-End Appeal"},
+		{"", "01/16/2026", NoCampaign},
+		{"Appeal", "03/01/2026", "Year-End Appeal"},
+		{"", "05/31/2026", "Spring Event"},
+	}
+	for _, test := range tests {
+		if campaign := config.Match(test.class, date(t, test.date)); campaign != test.campaign {
+			t.Errorf("%s %s: got %s, want %s", test.class, test.date, campaign, test.campaign)
+		}
+	}
+}
+
+// TestInvalidCampaigns checks that invalid campaigns files are rejected.
+func TestInvalidCampaigns(t *testing.T) {
+	var tests = []struct {
+		name    string
+		content string
+	}{
+		{"unknown field", `{"column": "Memo", "campaigns": [{"name": "a", "value": ["a"]}]}`},
+		{"no name", `{"column": "Memo", "campaigns": [{"values": ["a"]}]}`},
+		{"duplicate name", `{"column": "Memo", "campaigns": [{"name": "a", "values": ["a"]}, {"name": "a", "values": ["b"]}]}`},
+		{"reserved name", `{"column": "Memo", "campaigns": [{"name": "No Campaign", "values": ["a"]}]}`},
+		{"no column", `{"campaigns": [{"name": "a", "values": ["a"]}]}`},
+		{"no conditions", `{"column": "Memo", "campaigns": [{"name": "a"}]}`},
+		{"bad window", `{"campaigns": [{"name": "a", "windows": [{"from": "13/01", "thru": "12/31"}]}]}`},
+		{"mixed window", `{"campaigns": [{"name": "a", "windows": [{"from": "11/01", "thru": "12/31/2025"}]}]}`},
+		{"reversed window", `{"campaigns": [{"name": "a", "windows": [{"from": "12/31/2025", "thru": "11/01/2025"}]}]}`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := Load(writeConfig(t, test.content)); err == nil {
+				t.Error("invalid campaigns were accepted")
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+// ----------------------------------------------------------------------------
+//
+// Campaign configuration
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+// The campaigns package attributes each gift to the appeal or event that
+// raised it, such as the year-end appeal, the spring event, or Giving
+// Tuesday.  A gift belongs to the first campaign whose values appear in a
+// column of the donation spreadsheet, such as the Quickbooks Class, Memo,
+// or Location, or whose date window contains the date of the gift.  Gifts
+// that match no campaign are attributed to NoCampaign.
+package campaigns
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+
+	sp "acorn_go/pkg/support"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Config is the content of a campaigns file.  Column names the column of
+// the donation spreadsheet that holds the campaign values.
+type Config struct {
+	Column    string     `json:"column"`
+	Campaigns []Campaign `json:"campaigns"`
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// FileVariable is the environmental variable that names the campaigns file.
+const FileVariable = "ACORN_CAMPAIGNS_FILE"
+
+// NoCampaign is the campaign of gifts that match no campaign.
+const NoCampaign = "No Campaign"
+
+// Names of the built-in campaigns
+const (
+	GivingTuesday = "Giving Tuesday"
+	YearEndAppeal = "Year-End Appeal"
+	SpringEvent   = "Spring Event"
+)
+
+// columnMemo is the column the built-in campaigns examine.
+const columnMemo = "Memo"
+
+// active is the configuration used by the donation loaders.  It is loaded
+// from the environment the first time it is needed.
+var active *Config
+var activeErr error
+
+// ----------------------------------------------------------------------------
+// Factory Functions
+// ----------------------------------------------------------------------------
+
+// Load returns the configuration in the campaigns file.  If the file name is
+// empty, the built-in campaigns are used.
+func Load(fileName string) (Config, error) {
+	var config = builtInConfig()
+	if fileName == "" {
+		return config, config.validate()
+	}
+	var content, err = os.ReadFile(fileName)
+	if err == nil {
+		config = Config{}
+		var decoder = json.NewDecoder(bytes.NewReader(content))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&config)
+	}
+	if err == nil {
+		err = config.validate()
+	}
+	return config, sp.WrapFile(err, sp.Usage, "reading campaigns", fileName)
+}
+
+// ----------------------------------------------------------------------------
+// Functions
+// ----------------------------------------------------------------------------
+
+// Active returns the configuration in the file named by
+// ACORN_CAMPAIGNS_FILE, or the built-in campaigns if it is not defined.
+func Active() (Config, error) {
+	if active == nil && activeErr == nil {
+		var config Config
+		config, activeErr = Load(os.Getenv(FileVariable))
+		active = &config
+	}
+	return *active, activeErr
+}
+
+// SetActive replaces the configuration used by the donation loaders.
+func SetActive(config Config) error {
+	var err = config.validate()
+	if err == nil {
+		active = &config
+		activeErr = nil
+	}
+	return err
+}
+
+// builtInConfig returns the campaigns found in the memos that Quickbooks
+// records for the appeals and events.
+func builtInConfig() Config {
+	return Config{
+		Column: columnMemo,
+		Campaigns: []Campaign{
+			{Name: GivingTuesday, Values: []string{"giving tuesday"}},
+			{Name: YearEndAppeal, Values: []string{"year-end", "year end"}},
+			{Name: SpringEvent, Values: []string{"spring"}},
+		},
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Methods
+// ----------------------------------------------------------------------------
+
+// Names returns the names of the campaigns in the order they are tried,
+// followed by NoCampaign.
+func (config *Config) Names() []string {
+	var names = []string{}
+	for _, campaign := range config.Campaigns {
+		names = append(names, campaign.Name)
+	}
+	return append(names, NoCampaign)
+}
+
+// validate checks the campaigns and converts their date windows.
+func (config *Config) validate() error {
+	var names = map[string]bool{NoCampaign: true}
+	for index := range config.Campaigns {
+		var campaign = &config.Campaigns[index]
+		if campaign.Name == "" || names[campaign.Name] {
+			return errors.New("campaign names must be unique and not empty: " + campaign.Name)
+		}
+		names[campaign.Name] = true
+		if len(campaign.Values) > 0 && config.Column == "" {
+			return errors.New("campaign " + campaign.Name + " has values but no column is named")
+		}
+		var err = campaign.validate()
+		if err != nil {
+			return errors.New("campaign " + campaign.Name + ": " + err.Error())
+		}
+	}
+	return nil
+}
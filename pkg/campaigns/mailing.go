@@ -0,0 +1,127 @@
+// ----------------------------------------------------------------------------
+//
+// Appeal mailings
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package campaigns
+
+// A mailing list names the people an appeal was sent to.  It is kept in a
+// spreadsheet with a row for each person and the columns Campaign, Mail
+// Date, and "Donor full name".  The mail date places the mailing in a
+// fiscal year, so the response rate compares the people mailed with the
+// donors who gave to the campaign in that year.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	a "acorn_go/pkg/accounting"
+	"acorn_go/pkg/spreadsheet"
+	"errors"
+	"slices"
+
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Mailing is the people an appeal was sent to in a fiscal year.
+type Mailing struct {
+	campaign string
+	fy       a.FYIndicator
+	names    []string
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Mailing list column names
+const (
+	columnCampaign = "Campaign"
+	columnMailDate = "Mail Date"
+	columnName     = "Donor full name"
+)
+
+// ----------------------------------------------------------------------------
+// Factory Functions
+// ----------------------------------------------------------------------------
+
+// NewMailings returns the mailings in the rows of a mailing list
+// spreadsheet, one for each campaign and fiscal year, in the order they
+// first appear.  Each name is listed once in a mailing.
+func NewMailings(sprdsht *spreadsheet.Spreadsheet) ([]Mailing, error) {
+	var mailings = []Mailing{}
+	var numRows = sprdsht.Size()
+	for row := 1; row < numRows; row++ {
+		var campaign, err = sprdsht.Cell(row, columnCampaign)
+		var name string
+		var date d.Date
+		if err == nil {
+			name, err = sprdsht.Cell(row, columnName)
+		}
+		if err == nil {
+			date, err = sprdsht.CellDate(row, columnMailDate)
+		}
+		if err == nil && (campaign == "" || name == "") {
+			err = errors.New("campaign and name must not be empty")
+		}
+		var fy = a.OutOfRange
+		if err == nil {
+			fy = a.FiscalYearIndicator(date)
+		}
+		if err == nil && !a.IsFYIndicator(fy) {
+			err = errors.New("mail date is not in a fiscal year of the analysis")
+		}
+		if err != nil {
+			return mailings, sprdsht.WrapRow(err, "processing mailing", row)
+		}
+		var index = slices.IndexFunc(mailings, func(mailing Mailing) bool {
+			return mailing.campaign == campaign && mailing.fy == fy
+		})
+		if index < 0 {
+			mailings = append(mailings, Mailing{campaign: campaign, fy: fy, names: []string{}})
+			index = len(mailings) - 1
+		}
+		if !slices.Contains(mailings[index].names, name) {
+			mailings[index].names = append(mailings[index].names, name)
+		}
+	}
+	return mailings, nil
+}
+
+// ReadMailings reads the mailings from a mailing list spreadsheet.
+func ReadMailings(fileName string, tab string) ([]Mailing, error) {
+	var sprdsht, err = spreadsheet.ProcessData(fileName, tab)
+	if err != nil {
+		return []Mailing{}, err
+	}
+	return NewMailings(&sprdsht)
+}
+
+// ----------------------------------------------------------------------------
+// Methods
+// ----------------------------------------------------------------------------
+
+// Campaign returns the name of the campaign of the appeal.
+func (mailing Mailing) Campaign() string {
+	return mailing.campaign
+}
+
+// FiscalYear returns the fiscal year of the mailing.
+func (mailing Mailing) FiscalYear() a.FYIndicator {
+	return mailing.fy
+}
+
+// Names returns the names of the people the appeal was sent to.
+func (mailing Mailing) Names() []string {
+	return slices.Clone(mailing.names)
+}
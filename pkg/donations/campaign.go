@@ -0,0 +1,210 @@
+// ----------------------------------------------------------------------------
+//
+// Campaign Analysis
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package donations
+
+// The campaign analysis reports the giving to each campaign in each fiscal
+// year: the dollars, the number of gifts and donors, the new donors, and
+// the average gift.  A new donor is one whose first gift was to the
+// campaign in that year.  When the mailing list of an appeal is added, the
+// response rate is the percent of the people mailed who gave to the
+// campaign in the fiscal year of the mailing.  Only gifts with an amount
+// kept greater than zero are counted, but the dollars are net of any
+// reversals that matched no gift.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	a "acorn_go/pkg/accounting"
+	"math"
+	"slices"
+
+	dec "github.com/shopspring/decimal"
+	"github.com/waysys/assert/assert"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// CampaignResult is the giving to a campaign in a fiscal year.
+type CampaignResult struct {
+	amount     dec.Decimal
+	gifts      int
+	donors     map[string]bool
+	newDonors  int
+	mailed     int
+	responders int
+}
+
+// CampaignAnalysis holds the results of each campaign in each fiscal year.
+type CampaignAnalysis struct {
+	names   []string
+	results map[string]*[a.NumFiscalYears]CampaignResult
+}
+
+// ----------------------------------------------------------------------------
+// Factory Functions
+// ----------------------------------------------------------------------------
+
+// ComputeCampaigns returns the results of the campaigns.  The names set the
+// order of the campaigns.  A campaign found in the gifts but not in the
+// names is added at the end.
+func ComputeCampaigns(donationList DonationList, names []string) CampaignAnalysis {
+	var analysis = CampaignAnalysis{
+		names:   []string{},
+		results: make(map[string]*[a.NumFiscalYears]CampaignResult),
+	}
+	for _, name := range names {
+		analysis.add(name)
+	}
+	for _, key := range donationList.DonorKeys() {
+		var first = true
+		for _, gift := range donationList.Get(key).Gifts() {
+			var fy = a.FiscalYearIndicator(gift.Date())
+			var kept = gift.Amount().GreaterThan(dec.Zero)
+			if !a.IsFYIndicator(fy) {
+				first = first && !kept
+				continue
+			}
+			var result = &analysis.add(gift.Campaign())[fy]
+			result.amount = result.amount.Add(gift.Amount())
+			if !kept {
+				continue
+			}
+			result.gifts++
+			result.donors[key] = true
+			if first {
+				result.newDonors++
+				first = false
+			}
+		}
+	}
+	return analysis
+}
+
+// ----------------------------------------------------------------------------
+// Methods
+// ----------------------------------------------------------------------------
+
+// add returns the results of the campaign, adding the campaign if it is not
+// already in the analysis.
+func (analysis *CampaignAnalysis) add(name string) *[a.NumFiscalYears]CampaignResult {
+	var results, found = analysis.results[name]
+	if !found {
+		results = new([a.NumFiscalYears]CampaignResult)
+		for _, fy := range a.FYIndicators {
+			results[fy] = CampaignResult{amount: dec.Zero, donors: make(map[string]bool)}
+		}
+		analysis.names = append(analysis.names, name)
+		analysis.results[name] = results
+	}
+	return results
+}
+
+// result returns the result of the campaign in the fiscal year.
+func (analysis *CampaignAnalysis) result(name string, fy a.FYIndicator) *CampaignResult {
+	assert.Assert(a.IsFYIndicator(fy), "Invalid fiscal year indicator: "+fy.String())
+	var results, found = analysis.results[name]
+	assert.Assert(found, "campaign is not in the analysis: "+name)
+	return &results[fy]
+}
+
+// AddMailing records the donor keys an appeal of the campaign was sent to
+// in the fiscal year.  The unmatched names, which match no donor, are
+// counted as mailed but never as responders.  Mailing the same donor or
+// name twice counts once.
+func (analysis *CampaignAnalysis) AddMailing(name string, fy a.FYIndicator, keys []string, unmatched []string) {
+	var result = &analysis.add(name)[fy]
+	var mailed = []string{}
+	for _, key := range keys {
+		if !slices.Contains(mailed, key) {
+			mailed = append(mailed, key)
+		}
+	}
+	for _, key := range mailed {
+		if result.donors[key] {
+			result.responders++
+		}
+	}
+	var names = []string{}
+	for _, unmatchedName := range unmatched {
+		if !slices.Contains(names, unmatchedName) {
+			names = append(names, unmatchedName)
+		}
+	}
+	result.mailed += len(mailed) + len(names)
+}
+
+// Names returns the names of the campaigns in the analysis.
+func (analysis *CampaignAnalysis) Names() []string {
+	return slices.Clone(analysis.names)
+}
+
+// Amount returns the dollars given to the campaign in the fiscal year.
+func (analysis *CampaignAnalysis) Amount(name string, fy a.FYIndicator) dec.Decimal {
+	return analysis.result(name, fy).amount
+}
+
+// Gifts returns the number of gifts to the campaign in the fiscal year.
+func (analysis *CampaignAnalysis) Gifts(name string, fy a.FYIndicator) int {
+	return analysis.result(name, fy).gifts
+}
+
+// Donors returns the number of donors who gave to the campaign in the
+// fiscal year.
+func (analysis *CampaignAnalysis) Donors(name string, fy a.FYIndicator) int {
+	return len(analysis.result(name, fy).donors)
+}
+
+// NewDonors returns the number of donors whose first gift was to the
+// campaign in the fiscal year.
+func (analysis *CampaignAnalysis) NewDonors(name string, fy a.FYIndicator) int {
+	return analysis.result(name, fy).newDonors
+}
+
+// AverageGift returns the average gift to the campaign in the fiscal year.
+func (analysis *CampaignAnalysis) AverageGift(name string, fy a.FYIndicator) float64 {
+	var average = 0.00
+	var result = analysis.result(name, fy)
+	if result.gifts > 0 {
+		average = math.Round(result.amount.InexactFloat64() / float64(result.gifts))
+	}
+	return average
+}
+
+// HasMailing returns true if a mailing list was added for the campaign in
+// the fiscal year.
+func (analysis *CampaignAnalysis) HasMailing(name string, fy a.FYIndicator) bool {
+	return analysis.result(name, fy).mailed > 0
+}
+
+// Mailed returns the number of donors the appeal was sent to.
+func (analysis *CampaignAnalysis) Mailed(name string, fy a.FYIndicator) int {
+	return analysis.result(name, fy).mailed
+}
+
+// Responders returns the number of donors mailed who gave to the campaign.
+func (analysis *CampaignAnalysis) Responders(name string, fy a.FYIndicator) int {
+	return analysis.result(name, fy).responders
+}
+
+// ResponseRate returns the percent of the donors mailed who gave to the
+// campaign in the fiscal year.
+func (analysis *CampaignAnalysis) ResponseRate(name string, fy a.FYIndicator) float64 {
+	var percent = 0.00
+	var result = analysis.result(name, fy)
+	if result.mailed > 0 {
+		percent = math.Round(float64(result.responders) * 100.00 / float64(result.mailed))
+	}
+	return percent
+}
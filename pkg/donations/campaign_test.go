@@ -0,0 +1,87 @@
+// ----------------------------------------------------------------------------
+//
+// Campaign Analysis Tests
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package donations
+
+import (
+	"testing"
+
+	a "acorn_go/pkg/accounting"
+	"acorn_go/pkg/campaigns"
+	dn "acorn_go/pkg/donors"
+
+	dec "github.com/shopspring/decimal"
+)
+
+// campaignGift returns a gift of the amount on the date to the campaign.
+func campaignGift(t *testing.T, value string, amount int64, campaign string) dn.Gift {
+	t.Helper()
+	var gift = dn.NewGift(date(t, value), dec.NewFromInt(amount), "Payment", "", 0)
+	gift.SetCampaign(campaign)
+	return gift
+}
+
+// Test_ComputeCampaigns checks the dollars, donors, new donors, average
+// gift, and response rate of the campaigns.
+func Test_ComputeCampaigns(t *testing.T) {
+	dl := make(DonationList)
+	for _, key := range []string{"old", "new", "refunded", "unmailed"} {
+		var donor = dn.NewDonorWithDonation(key)
+		dl[key] = &donor
+	}
+	dl["old"].AddGift(campaignGift(t, "12/10/2024", 100, campaigns.YearEndAppeal))
+	dl["old"].AddGift(campaignGift(t, "12/10/2025", 300, campaigns.YearEndAppeal))
+	dl["old"].AddGift(campaignGift(t, "12/20/2025", 100, campaigns.YearEndAppeal))
+	dl["new"].AddGift(campaignGift(t, "12/15/2025", 200, campaigns.YearEndAppeal))
+	dl["new"].AddGift(campaignGift(t, "04/15/2026", 50, campaigns.SpringEvent))
+	dl["refunded"].AddGift(campaignGift(t, "12/01/2025", 500, campaigns.YearEndAppeal))
+	dl["refunded"].AddGift(campaignGift(t, "12/05/2025", -500, campaigns.NoCampaign))
+	dl["unmailed"].AddGift(campaignGift(t, "03/01/2026", 75, ""))
+
+	var names = []string{campaigns.YearEndAppeal, campaigns.SpringEvent, campaigns.NoCampaign}
+	var analysis = ComputeCampaigns(dl, names)
+	analysis.AddMailing(campaigns.YearEndAppeal, a.FY2026, []string{"old", "refunded", "unmailed", "old"},
+		[]string{"Olde", "Olde"})
+
+	var tests = []struct {
+		campaign  string
+		amount    int64
+		gifts     int
+		donors    int
+		newDonors int
+		average   float64
+	}{
+		{campaigns.YearEndAppeal, 600, 3, 2, 1, 200},
+		{campaigns.SpringEvent, 50, 1, 1, 0, 50},
+		{campaigns.NoCampaign, 75, 1, 1, 1, 75},
+	}
+	for _, tt := range tests {
+		if analysis.Amount(tt.campaign, a.FY2026).IntPart() != tt.amount ||
+			analysis.Gifts(tt.campaign, a.FY2026) != tt.gifts ||
+			analysis.Donors(tt.campaign, a.FY2026) != tt.donors ||
+			analysis.NewDonors(tt.campaign, a.FY2026) != tt.newDonors ||
+			analysis.AverageGift(tt.campaign, a.FY2026) != tt.average {
+			t.Errorf("%s: got %s, %d gifts, %d donors, %d new, average %v", tt.campaign,
+				analysis.Amount(tt.campaign, a.FY2026).String(), analysis.Gifts(tt.campaign, a.FY2026),
+				analysis.Donors(tt.campaign, a.FY2026), analysis.NewDonors(tt.campaign, a.FY2026),
+				analysis.AverageGift(tt.campaign, a.FY2026))
+		}
+	}
+	if analysis.NewDonors(campaigns.YearEndAppeal, a.FY2025) != 1 {
+		t.Error("first gift in FY2025 is not a new donor")
+	}
+	if !analysis.HasMailing(campaigns.YearEndAppeal, a.FY2026) || analysis.HasMailing(campaigns.SpringEvent, a.FY2026) {
+		t.Error("mailing is not recorded for the appeal only")
+	}
+	if analysis.Mailed(campaigns.YearEndAppeal, a.FY2026) != 4 || analysis.Responders(campaigns.YearEndAppeal, a.FY2026) != 1 ||
+		analysis.ResponseRate(campaigns.YearEndAppeal, a.FY2026) != 25 {
+		t.Errorf("response rate %v, want 25", analysis.ResponseRate(campaigns.YearEndAppeal, a.FY2026))
+	}
+}
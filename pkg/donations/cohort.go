@@ -0,0 +1,165 @@
+// ----------------------------------------------------------------------------
+//
+// Donor Cohorts
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package donations
+
+// A cohort is the group of donors whose first gift was made in the same
+// fiscal year.  Donors whose first gift was made before the fiscal years of
+// the analysis form a single earlier cohort.  The cohort analysis tracks how
+// many donors of each cohort give, and how much, in each later fiscal year.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	a "acorn_go/pkg/accounting"
+	"math"
+	"slices"
+
+	dec "github.com/shopspring/decimal"
+	"github.com/waysys/assert/assert"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Cohort identifies the fiscal year of the first gift of a group of donors.
+type Cohort int
+
+// CohortAnalysis holds the size of each cohort and the donors and donations
+// of each cohort in each fiscal year.
+type CohortAnalysis struct {
+	size      [NumCohorts]int
+	donors    [NumCohorts][a.NumFiscalYears]int
+	donations [NumCohorts][a.NumFiscalYears]dec.Decimal
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const NumCohorts = a.NumFiscalYears + 1
+
+// EarlierCohort holds the donors whose first gift was made before FY2023.
+const EarlierCohort = Cohort(0)
+
+var Cohorts = [NumCohorts]Cohort{
+	EarlierCohort,
+	CohortOf(a.FY2023),
+	CohortOf(a.FY2024),
+	CohortOf(a.FY2025),
+	CohortOf(a.FY2026),
+}
+
+// ----------------------------------------------------------------------------
+// Functions
+// ----------------------------------------------------------------------------
+
+// CohortOf returns the cohort of donors whose first gift was made in the
+// fiscal year.
+func CohortOf(fy a.FYIndicator) Cohort {
+	return Cohort(fy + 1)
+}
+
+// IsCohort returns true if the cohort is a valid value.
+func IsCohort(cohort Cohort) bool {
+	return EarlierCohort <= cohort && cohort < NumCohorts
+}
+
+// ComputeCohorts places each donor in the cohort of the fiscal year of the
+// donor's first gift and totals the cohort's giving in each fiscal year.
+// Donors who have not given an amount greater than zero in any fiscal year
+// through the current fiscal year are not in a cohort.
+func ComputeCohorts(donationList DonationList) CohortAnalysis {
+	var analysis CohortAnalysis
+	for _, donor := range donationList {
+		var years = givingYears(donor.Gifts())
+		var numbers = []int{}
+		for number := range years {
+			numbers = append(numbers, number)
+		}
+		if len(numbers) == 0 {
+			continue
+		}
+		var first = slices.Min(numbers)
+		var cohort = EarlierCohort
+		switch {
+		case first > a.CurrentFiscalYear.Number():
+			continue
+		case first >= a.FY2023.Number():
+			cohort = CohortOf(a.FYIndicator(first - a.FY2023.Number()))
+		}
+		analysis.size[cohort]++
+		for _, fy := range a.FYIndicators {
+			if donor.IsDonor(fy) {
+				analysis.donors[cohort][fy]++
+				analysis.donations[cohort][fy] = analysis.donations[cohort][fy].Add(donor.Donation(fy))
+			}
+		}
+	}
+	return analysis
+}
+
+// ----------------------------------------------------------------------------
+// Methods
+// ----------------------------------------------------------------------------
+
+// String returns the name of the cohort.
+func (cohort Cohort) String() string {
+	assert.Assert(IsCohort(cohort), "Invalid cohort")
+	var name = "Before " + a.FY2023.String()
+	if cohort != EarlierCohort {
+		name = a.FYIndicator(cohort - 1).String()
+	}
+	return name
+}
+
+// Includes returns true if the fiscal year is on or after the fiscal year
+// of the cohort's first gift.
+func (cohort Cohort) Includes(fy a.FYIndicator) bool {
+	assert.Assert(IsCohort(cohort), "Invalid cohort")
+	return cohort == EarlierCohort || CohortOf(fy) >= cohort
+}
+
+// Size returns the number of donors in the cohort.
+func (analysis *CohortAnalysis) Size(cohort Cohort) int {
+	assert.Assert(IsCohort(cohort), "Invalid cohort")
+	return analysis.size[cohort]
+}
+
+// Donors returns the number of donors in the cohort who gave in the fiscal
+// year.
+func (analysis *CohortAnalysis) Donors(cohort Cohort, fy a.FYIndicator) int {
+	assert.Assert(IsCohort(cohort), "Invalid cohort")
+	assert.Assert(a.IsFYIndicator(fy), "Invalid fiscal year indicator: "+fy.String())
+	return analysis.donors[cohort][fy]
+}
+
+// Donations returns the amount the cohort gave in the fiscal year.
+func (analysis *CohortAnalysis) Donations(cohort Cohort, fy a.FYIndicator) dec.Decimal {
+	assert.Assert(IsCohort(cohort), "Invalid cohort")
+	assert.Assert(a.IsFYIndicator(fy), "Invalid fiscal year indicator: "+fy.String())
+	return analysis.donations[cohort][fy]
+}
+
+// Retention returns the percent of the donors in the cohort who gave in the
+// fiscal year.
+func (analysis *CohortAnalysis) Retention(cohort Cohort, fy a.FYIndicator) float64 {
+	var retention float64 = 0.0
+	var size = analysis.Size(cohort)
+	if size == 0 {
+		return retention
+	}
+	retention = float64(analysis.Donors(cohort, fy)) * 100.00 / float64(size)
+	retention = math.Round(retention)
+	return retention
+}
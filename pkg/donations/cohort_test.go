@@ -0,0 +1,65 @@
+// ----------------------------------------------------------------------------
+//
+// Donor Cohort Tests
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package donations
+
+import (
+	"testing"
+
+	a "acorn_go/pkg/accounting"
+)
+
+// Test_ComputeCohorts checks the cohort sizes, donors, donations, and
+// retention.
+func Test_ComputeCohorts(t *testing.T) {
+	dl := make(DonationList)
+	dl["A"] = lifecycleDonor(t, "A", "10/01/2022", "10/01/2023", "10/01/2025")
+	dl["B"] = lifecycleDonor(t, "B", "10/01/2022")
+	dl["C"] = lifecycleDonor(t, "C", "10/01/2018", "10/01/2023", "11/01/2023")
+	dl["D"] = lifecycleDonor(t, "D", "10/01/2024")
+	dl["E"] = lifecycleDonor(t, "E", "10/01/2026")
+
+	analysis := ComputeCohorts(dl)
+	fy2023 := CohortOf(a.FY2023)
+	if analysis.Size(fy2023) != 2 || analysis.Size(EarlierCohort) != 1 || analysis.Size(CohortOf(a.FY2025)) != 1 {
+		t.Errorf("unexpected cohort sizes: %d %d %d",
+			analysis.Size(fy2023), analysis.Size(EarlierCohort), analysis.Size(CohortOf(a.FY2025)))
+	}
+	if analysis.Donors(fy2023, a.FY2024) != 1 || analysis.Retention(fy2023, a.FY2024) != 50 {
+		t.Errorf("FY2023 cohort in FY2024: %d donors, %.0f%%",
+			analysis.Donors(fy2023, a.FY2024), analysis.Retention(fy2023, a.FY2024))
+	}
+	if !analysis.Donations(EarlierCohort, a.FY2024).Equal(dl["C"].Donation(a.FY2024)) {
+		t.Errorf("earlier cohort donations in FY2024: %s", analysis.Donations(EarlierCohort, a.FY2024).String())
+	}
+	if analysis.Retention(CohortOf(a.FY2024), a.FY2025) != 0 {
+		t.Error("empty cohort should have zero retention")
+	}
+	var total = 0
+	for _, cohort := range Cohorts {
+		total += analysis.Size(cohort)
+	}
+	if total != 4 {
+		t.Errorf("expected 4 donors in cohorts, got %d", total)
+	}
+}
+
+// Test_CohortIncludes checks the triangle of fiscal years for each cohort.
+func Test_CohortIncludes(t *testing.T) {
+	if !EarlierCohort.Includes(a.FY2023) || !CohortOf(a.FY2024).Includes(a.FY2024) {
+		t.Error("cohort should include the fiscal year")
+	}
+	if CohortOf(a.FY2025).Includes(a.FY2024) {
+		t.Error("FY2025 cohort should not include FY2024")
+	}
+	if EarlierCohort.String() != "Before FY2023" || CohortOf(a.FY2026).String() != "FY2026" {
+		t.Error("unexpected cohort names: " + EarlierCohort.String() + ", " + CohortOf(a.FY2026).String())
+	}
+}
@@ -0,0 +1,161 @@
+// ----------------------------------------------------------------------------
+//
+// Dollar Retention
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package donations
+
+// The dollar retention analysis compares each donor's giving in a fiscal
+// year with the fiscal year before it.  Retained donors are upgraded, the
+// same, or downgraded.  The gain and loss of each category follows the
+// Fundraising Effectiveness Project: gains from new, recaptured, and upgraded
+// donors, and losses from downgraded and lapsed donors.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	a "acorn_go/pkg/accounting"
+	dn "acorn_go/pkg/donors"
+	"math"
+
+	dec "github.com/shopspring/decimal"
+	"github.com/waysys/assert/assert"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// DollarRetention holds the number of donors and their giving in the fiscal
+// year and the prior fiscal year for each category of change.
+type DollarRetention struct {
+	counts  [a.NumFiscalYears][dn.NumChanges]int
+	prior   [a.NumFiscalYears][dn.NumChanges]dec.Decimal
+	current [a.NumFiscalYears][dn.NumChanges]dec.Decimal
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// RetainedChanges are the categories of donors who gave in both years.
+var RetainedChanges = []dn.Change{dn.ChangeUpgraded, dn.ChangeSame, dn.ChangeDowngraded}
+
+// GainLossChanges are the categories of the gain and loss analysis.
+var GainLossChanges = []dn.Change{
+	dn.ChangeNew,
+	dn.ChangeRecaptured,
+	dn.ChangeUpgraded,
+	dn.ChangeDowngraded,
+	dn.ChangeLapsed,
+}
+
+// ----------------------------------------------------------------------------
+// Factory Functions
+// ----------------------------------------------------------------------------
+
+// ComputeDollarRetention compares the giving of every donor in the list
+// with the prior fiscal year for each fiscal year.
+func ComputeDollarRetention(donationList DonationList) DollarRetention {
+	var retention DollarRetention
+	for _, donor := range donationList {
+		for _, fy := range a.FYIndicators {
+			var yoy = donor.YearOverYear(fy)
+			var change = yoy.Change()
+			retention.counts[fy][change]++
+			retention.prior[fy][change] = retention.prior[fy][change].Add(yoy.Prior())
+			retention.current[fy][change] = retention.current[fy][change].Add(yoy.Current())
+		}
+	}
+	return retention
+}
+
+// ----------------------------------------------------------------------------
+// Methods
+// ----------------------------------------------------------------------------
+
+// Count returns the number of donors in the category in the fiscal year.
+func (retention *DollarRetention) Count(fy a.FYIndicator, change dn.Change) int {
+	assert.Assert(a.IsFYIndicator(fy), "Invalid fiscal year indicator: "+fy.String())
+	assert.Assert(dn.IsChange(change), "Invalid change")
+	return retention.counts[fy][change]
+}
+
+// Prior returns the giving in the prior fiscal year of the donors in the
+// category.
+func (retention *DollarRetention) Prior(fy a.FYIndicator, change dn.Change) dec.Decimal {
+	assert.Assert(a.IsFYIndicator(fy), "Invalid fiscal year indicator: "+fy.String())
+	assert.Assert(dn.IsChange(change), "Invalid change")
+	return retention.prior[fy][change]
+}
+
+// Current returns the giving in the fiscal year of the donors in the
+// category.
+func (retention *DollarRetention) Current(fy a.FYIndicator, change dn.Change) dec.Decimal {
+	assert.Assert(a.IsFYIndicator(fy), "Invalid fiscal year indicator: "+fy.String())
+	assert.Assert(dn.IsChange(change), "Invalid change")
+	return retention.current[fy][change]
+}
+
+// GainLoss returns the change in giving of the donors in the category.  It
+// is negative for a loss.
+func (retention *DollarRetention) GainLoss(fy a.FYIndicator, change dn.Change) dec.Decimal {
+	return retention.Current(fy, change).Sub(retention.Prior(fy, change))
+}
+
+// Gains returns the total of the gains in the fiscal year.
+func (retention *DollarRetention) Gains(fy a.FYIndicator) dec.Decimal {
+	var total = dec.Zero
+	for _, change := range GainLossChanges {
+		var amount = retention.GainLoss(fy, change)
+		if amount.GreaterThan(dec.Zero) {
+			total = total.Add(amount)
+		}
+	}
+	return total
+}
+
+// Losses returns the total of the losses in the fiscal year as a positive
+// amount.
+func (retention *DollarRetention) Losses(fy a.FYIndicator) dec.Decimal {
+	var total = dec.Zero
+	for _, change := range GainLossChanges {
+		var amount = retention.GainLoss(fy, change)
+		if amount.LessThan(dec.Zero) {
+			total = total.Sub(amount)
+		}
+	}
+	return total
+}
+
+// Net returns the gains less the losses.  It equals the change in total
+// giving from the prior fiscal year.
+func (retention *DollarRetention) Net(fy a.FYIndicator) dec.Decimal {
+	return retention.Gains(fy).Sub(retention.Losses(fy))
+}
+
+// RevenueRetention returns the percent of the prior fiscal year's giving
+// that the same donors gave again in the fiscal year.
+func (retention *DollarRetention) RevenueRetention(fy a.FYIndicator) float64 {
+	var result float64 = 0.0
+	var priorTotal = dec.Zero
+	var retained = dec.Zero
+	for _, change := range dn.Changes {
+		priorTotal = priorTotal.Add(retention.Prior(fy, change))
+	}
+	for _, change := range RetainedChanges {
+		retained = retained.Add(retention.Current(fy, change))
+	}
+	if priorTotal.GreaterThan(dec.Zero) {
+		result = retained.InexactFloat64() * 100.00 / priorTotal.InexactFloat64()
+		result = math.Round(result)
+	}
+	return result
+}
@@ -0,0 +1,83 @@
+// ----------------------------------------------------------------------------
+//
+// Dollar Retention Tests
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package donations
+
+import (
+	"testing"
+
+	a "acorn_go/pkg/accounting"
+	dn "acorn_go/pkg/donors"
+
+	dec "github.com/shopspring/decimal"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// date returns the date for the tests.
+func date(t *testing.T, value string) d.Date {
+	t.Helper()
+	var result, err = d.NewFromString(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+// amountDonor returns a donor who gave the amounts on the dates.
+func amountDonor(t *testing.T, name string, gifts map[string]int64) *dn.Donor {
+	t.Helper()
+	var donor = dn.NewDonorWithDonation(name)
+	for value, amount := range gifts {
+		donor.AddGift(dn.NewGift(date(t, value), dec.NewFromInt(amount), "Payment", "", 0))
+	}
+	return &donor
+}
+
+// Test_ComputeDollarRetention checks the categories and the gain and loss
+// of giving from FY2025 to FY2026.
+func Test_ComputeDollarRetention(t *testing.T) {
+	dl := make(DonationList)
+	dl["up"] = amountDonor(t, "up", map[string]int64{"10/01/2024": 100, "10/01/2025": 250})
+	dl["same"] = amountDonor(t, "same", map[string]int64{"10/01/2024": 100, "10/01/2025": 100})
+	dl["down"] = amountDonor(t, "down", map[string]int64{"10/01/2024": 400, "10/01/2025": 100})
+	dl["lapsed"] = amountDonor(t, "lapsed", map[string]int64{"10/01/2024": 300})
+	dl["new"] = amountDonor(t, "new", map[string]int64{"10/01/2025": 50})
+	dl["back"] = amountDonor(t, "back", map[string]int64{"10/01/2022": 75, "10/01/2025": 80})
+
+	retention := ComputeDollarRetention(dl)
+	fy := a.FY2026
+	tests := []struct {
+		change   dn.Change
+		count    int
+		gainLoss int64
+	}{
+		{dn.ChangeUpgraded, 1, 150},
+		{dn.ChangeSame, 1, 0},
+		{dn.ChangeDowngraded, 1, -300},
+		{dn.ChangeLapsed, 1, -300},
+		{dn.ChangeNew, 1, 50},
+		{dn.ChangeRecaptured, 1, 80},
+	}
+	for _, tt := range tests {
+		if retention.Count(fy, tt.change) != tt.count {
+			t.Errorf("%s: count %d, want %d", tt.change.String(), retention.Count(fy, tt.change), tt.count)
+		}
+		if retention.GainLoss(fy, tt.change).IntPart() != tt.gainLoss {
+			t.Errorf("%s: gain or loss %s, want %d", tt.change.String(), retention.GainLoss(fy, tt.change).String(), tt.gainLoss)
+		}
+	}
+	if retention.Gains(fy).IntPart() != 280 || retention.Losses(fy).IntPart() != 600 || retention.Net(fy).IntPart() != -320 {
+		t.Errorf("gains %s, losses %s, net %s", retention.Gains(fy).String(), retention.Losses(fy).String(), retention.Net(fy).String())
+	}
+	// Retained donors gave 450 of the 900 given in FY2025.
+	if retention.RevenueRetention(fy) != 50 {
+		t.Errorf("revenue retention %.0f, want 50", retention.RevenueRetention(fy))
+	}
+}
@@ -15,12 +15,12 @@ package donations
 // ----------------------------------------------------------------------------
 
 import (
-	a "acorn_go/pkg/accounting"
 	dn "acorn_go/pkg/donors"
+	"acorn_go/pkg/identity"
+	"acorn_go/pkg/rules"
 	"acorn_go/pkg/spreadsheet"
 	"fmt"
 	"sort"
-	"strings"
 
 	d "github.com/waysys/waydate/pkg/date"
 
@@ -43,18 +43,6 @@ type DonationList map[string]*dn.Donor
 // Constants
 // ----------------------------------------------------------------------------
 
-const (
-	columnNameDonor       = "Payee"
-	columnTransactionType = "Type"
-	columnDate            = "Date"
-	columnPayment         = "Payment"
-)
-
-const (
-	payment       = "Payment"
-	excludedDonor = "Nadine L. Tolman Trust"
-)
-
 // ----------------------------------------------------------------------------
 // Factory Methods
 // ----------------------------------------------------------------------------
@@ -63,85 +51,33 @@ const (
 func NewDonationList(sprdsht *spreadsheet.Spreadsheet) (DonationList, error) {
 	var donationList = make(DonationList)
 	var numRows = sprdsht.Size()
-	var err error
 	var okToSelect bool
 	//
+	// Obtain the rules that select donations
+	//
+	var ruleSet, err = rules.Active()
+	if err != nil {
+		return donationList, err
+	}
+	//
 	// Loop through all the rows in the spreadsheet
 	//
 	for row := 1; row < numRows; row++ {
-		okToSelect, err = selectRow(sprdsht, row)
-		if err != nil {
-			return donationList, err
-		}
-		if okToSelect {
+		okToSelect, err = ruleSet.Select(sprdsht, row)
+		if err == nil && okToSelect {
 			err = processPayment(donationList, sprdsht, row)
 		}
+		if err != nil {
+			return donationList, sprdsht.WrapRow(err, "processing donation", row)
+		}
 	}
 	return donationList, err
 }
 
-// selectRow decides whether a row should be included in the analysis.
-func selectRow(sprdsht *spreadsheet.Spreadsheet, row int) (bool, error) {
-	var err error = nil
-	var tranType string
-	var nameDonor string
-	var result bool
-	//
-	// Fetch transaction type
-	//
-	tranType, err = sprdsht.Cell(row, columnTransactionType)
-	if err != nil {
-		return false, err
-	}
-	tranType = strings.TrimSpace(tranType)
-	//
-	// Fetch donor name
-	//
-	nameDonor, err = sprdsht.Cell(row, columnNameDonor)
-	if err != nil {
-		return false, err
-	}
-	nameDonor = strings.TrimSpace(nameDonor)
-	//
-	// Include row if value == Payment and nameDonor != Nadine L. Tolman Trust
-	//
-	if (tranType == payment) && (nameDonor != excludedDonor) {
-		result = true
-	} else {
-		result = false
-	}
-	return result, err
-}
-
-// processPayment creates a Donor structure
+// processPayment adds the gift in a row to the donor's ledger, creating the
+// donor if necessary.
 func processPayment(donationList DonationList, sprdsht *spreadsheet.Spreadsheet, row int) error {
-	var value string
-	var err error = nil
-	var amountDonation dec.Decimal
-	var dateDonation d.Date
-	//
-	// Obtain donor name
-	//
-	value, err = sprdsht.Cell(row, columnNameDonor)
-	if err != nil {
-		return err
-	}
-	var nameDonor = value
-	//
-	// Obtain date of donation
-	//
-	value, err = sprdsht.Cell(row, columnDate)
-	if err != nil {
-		return err
-	}
-	dateDonation, err = d.NewFromString(value)
-	if err != nil {
-		return err
-	}
-	//
-	// Obtain amount of donation
-	//
-	amountDonation, err = sprdsht.CellDecimal(row, columnPayment)
+	var nameDonor, gift, err = dn.ReadGift(sprdsht, row)
 	if err != nil {
 		return err
 	}
@@ -149,14 +85,14 @@ func processPayment(donationList DonationList, sprdsht *spreadsheet.Spreadsheet,
 	// Create an entry in the donor list if there is not already one
 	// for this donor.
 	//
-	if !donationList.Contains(nameDonor) {
+	if nameDonor != "" && !donationList.Contains(nameDonor) {
 		var donor = dn.NewDonorWithDonation(nameDonor)
 		donationList[nameDonor] = &donor
 	}
 	//
 	// Update the donor information
 	//
-	err = donationList.AddDonation(nameDonor, amountDonation, dateDonation)
+	err = donationList.AddGift(nameDonor, gift)
 
 	return err
 }
@@ -167,11 +103,19 @@ func processPayment(donationList DonationList, sprdsht *spreadsheet.Spreadsheet,
 
 // AddDonation adds a donation to the list.  If the donor is not already
 // in the list, a non-nil error is returned.  If the donor is in the list,
-// the donation is added to the donors values, based on the donation date.
+// the donation is added to the donor's ledger.
 func (donationList DonationList) AddDonation(
 	nameDonor string,
 	amountDonation dec.Decimal,
 	dateDonation d.Date) error {
+	var gift = dn.NewGift(dateDonation, amountDonation, "", "", 0)
+	return donationList.AddGift(nameDonor, gift)
+}
+
+// AddGift adds a gift to the ledger of a donor in the list.  If the donor
+// is not in the list, a non-nil error is returned.  A negative amount is a
+// reversal and is netted against the gift it reverses.
+func (donationList DonationList) AddGift(nameDonor string, gift dn.Gift) error {
 	var err error = nil
 	var donorPtr *dn.Donor
 	//
@@ -180,9 +124,6 @@ func (donationList DonationList) AddDonation(
 	if nameDonor == "" {
 		return fmt.Errorf("name of donor must not be empty")
 	}
-	if amountDonation.LessThan(dec.Zero) {
-		return fmt.Errorf("amount of donation must not be negative: %s", amountDonation.String())
-	}
 	//
 	// Retrieve donor structure for the named donor
 	//
@@ -190,14 +131,7 @@ func (donationList DonationList) AddDonation(
 		return fmt.Errorf("donor name not found in donation list: %s", nameDonor)
 	}
 	donorPtr = donationList.Get(nameDonor)
-	//
-	// Update donation amounts based on donation date.
-	//
-	var fy = a.FiscalYearIndicator(dateDonation)
-	donorPtr.AddDonation(amountDonation, fy)
-	var year = a.YIndicator(dateDonation)
-	donorPtr.AddCalDonation(amountDonation, year)
-	donorPtr.AddMonthDonation(amountDonation, dateDonation)
+	donorPtr.AddGift(gift)
 	return err
 }
 
@@ -232,3 +166,46 @@ func (donationList DonationList) DonorKeys() []string {
 	sort.Strings(keys)
 	return keys
 }
+
+// Through returns a donation list with the gifts made on or before the
+// date.  Donors who had not given by the date are left out.
+func (donationList DonationList) Through(date d.Date) DonationList {
+	var result = make(DonationList)
+	for key, donor := range donationList {
+		var through = donor.Through(date)
+		if through.GiftCount() > 0 || len(through.InKindGifts()) > 0 {
+			result[key] = &through
+		}
+	}
+	return result
+}
+
+// ByHousehold returns a donation list with a single donor for each
+// household.  The household donor holds the gifts of all its payees.
+func (donationList DonationList) ByHousehold(households *dn.HouseholdList) DonationList {
+	return DonationList(households.Rollup(dn.DonorList(donationList)))
+}
+
+// Resolve returns a donation list keyed by the donors the payees match.
+// Payees that match no donor keep their own names.  The gifts of payees
+// that match the same donor are combined.
+func (donationList DonationList) Resolve(resolver *identity.Resolver) DonationList {
+	var payees = make(map[string][]string)
+	for _, payee := range donationList.DonorKeys() {
+		var key = resolver.KeyOf(payee)
+		payees[key] = append(payees[key], payee)
+	}
+	var result = make(DonationList)
+	for key, names := range payees {
+		if len(names) == 1 && names[0] == key {
+			result[key] = donationList.Get(key)
+			continue
+		}
+		var donor = dn.NewDonorWithDonation(key)
+		for _, payee := range names {
+			donor.Merge(donationList.Get(payee))
+		}
+		result[key] = &donor
+	}
+	return result
+}
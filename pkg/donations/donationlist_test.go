@@ -15,6 +15,7 @@ import (
 	"testing"
 
 	dn "acorn_go/pkg/donors"
+	"acorn_go/pkg/identity"
 
 	dec "github.com/shopspring/decimal"
 	d "github.com/waysys/waydate/pkg/date"
@@ -31,15 +32,25 @@ func Test_AddDonation_MissingDonor_Error(t *testing.T) {
 	}
 }
 
-// Test_AddDonation_NegativeAmount_Error ensures AddDonation rejects negative amounts
-func Test_AddDonation_NegativeAmount_Error(t *testing.T) {
+// Test_AddDonation_NegativeAmount_Reversal ensures AddDonation nets a
+// negative amount against the gift it reverses, even in a later fiscal year
+func Test_AddDonation_NegativeAmount_Reversal(t *testing.T) {
 	dl := make(DonationList)
 	donor := dn.NewDonorWithDonation("Bob")
 	dl["Bob"] = &donor
-	date, _ := d.New(9, 1, 2025)
-	err := dl.AddDonation("Bob", dec.NewFromInt(-5), date)
-	if err == nil || !strings.Contains(err.Error(), "must not be negative") {
-		t.Fatalf("expected negative-amount error, got: %v", err)
+	giftDate, _ := d.New(8, 15, 2025)
+	refundDate, _ := d.New(9, 10, 2025)
+	if err := dl.AddDonation("Bob", dec.NewFromInt(50), giftDate); err != nil {
+		t.Fatal(err)
+	}
+	if err := dl.AddDonation("Bob", dec.NewFromInt(-50), refundDate); err != nil {
+		t.Fatalf("expected the reversal to be accepted, got: %v", err)
+	}
+	if donor.GiftCount() != 1 || len(donor.Reversals()) != 1 {
+		t.Fatalf("expected 1 gift and 1 reversal, got %d and %d", donor.GiftCount(), len(donor.Reversals()))
+	}
+	if !donor.TotalBetween(giftDate, giftDate).IsZero() || !donor.TotalBetween(refundDate, refundDate).IsZero() {
+		t.Errorf("expected the gift to be netted to zero, got %s", donor.TotalBetween(giftDate, refundDate).String())
 	}
 }
 
@@ -72,3 +83,42 @@ func Test_Get_PanicOnMissing(t *testing.T) {
 	dl := make(DonationList)
 	_ = dl.Get("does-not-exist")
 }
+
+// Test_Resolve checks that payees are keyed by the donors they match and
+// that unmatched payees are kept.
+func Test_Resolve(t *testing.T) {
+	dl := make(DonationList)
+	dl["Abernathy, Michael"] = amountDonor(t, "Abernathy, Michael", map[string]int64{"10/01/2024": 100})
+	dl["Michael Abernathy"] = amountDonor(t, "Michael Abernathy", map[string]int64{"11/01/2024": 50})
+	dl["Zzyzx Foundation"] = amountDonor(t, "Zzyzx Foundation", map[string]int64{"12/01/2024": 25})
+	resolver := identity.NewResolver([]string{"Abernathy, Michael", "Caldwell, Susan"}, make(identity.AliasTable))
+
+	result := dl.Resolve(&resolver)
+	if result.DonorCount() != 2 {
+		t.Fatalf("expected 2 donors, got %d", result.DonorCount())
+	}
+	if result.Get("Abernathy, Michael").GiftCount() != 2 || !result.Contains("Zzyzx Foundation") {
+		t.Error("payees were not combined by donor")
+	}
+	if dl.Get("Abernathy, Michael").GiftCount() != 1 {
+		t.Error("resolve changed the ledger of a payee")
+	}
+}
+
+// Test_ResolveSimilar checks that different donors with similar names are
+// kept apart.
+func Test_ResolveSimilar(t *testing.T) {
+	dl := make(DonationList)
+	dl["Smith, Joan"] = amountDonor(t, "Smith, Joan", map[string]int64{"10/01/2024": 100})
+	dl["Smith, John"] = amountDonor(t, "Smith, John", map[string]int64{"11/01/2024": 50})
+	dl["Baker, Tim"] = amountDonor(t, "Baker, Tim", map[string]int64{"12/01/2024": 25})
+	resolver := identity.NewResolver([]string{"Smith, John", "Baker, Tom"}, make(identity.AliasTable))
+
+	result := dl.Resolve(&resolver)
+	if result.DonorCount() != 3 || !result.Contains("Smith, Joan") || !result.Contains("Baker, Tim") {
+		t.Fatalf("similar payees were merged: %v", result.DonorKeys())
+	}
+	if result.Get("Smith, John").GiftCount() != 1 {
+		t.Error("gifts of Smith, Joan were given to Smith, John")
+	}
+}
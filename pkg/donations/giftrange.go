@@ -0,0 +1,247 @@
+// ----------------------------------------------------------------------------
+//
+// Gift Range Chart
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package donations
+
+// The gift range chart, or donor pyramid, places each donor in a range by
+// the total the donor gave in a fiscal year.  The ranges are set by their
+// lower bounds.  The default bounds can be replaced with ACORN_GIFT_RANGES,
+// a comma separated list of whole dollar amounts such as
+// "100,250,500,1000,2500,5000".  The first range is always under the first
+// bound and the last range has no upper bound.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	a "acorn_go/pkg/accounting"
+	sp "acorn_go/pkg/support"
+	"errors"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	dec "github.com/shopspring/decimal"
+	"github.com/waysys/assert/assert"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// GiftRange is a range of total giving by a donor in a fiscal year.  The
+// range includes the low amount and excludes the high amount.  The last
+// range has no high amount.
+type GiftRange struct {
+	low     int64
+	high    int64
+	hasHigh bool
+}
+
+// GiftRangeChart holds the number of donors and their total giving in each
+// gift range for each fiscal year.
+type GiftRangeChart struct {
+	ranges  []GiftRange
+	counts  [][a.NumFiscalYears]int
+	amounts [][a.NumFiscalYears]dec.Decimal
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// RangesVariable is the environmental variable with the lower bounds of
+// the gift ranges.
+const RangesVariable = "ACORN_GIFT_RANGES"
+
+// DefaultRangeBounds are the lower bounds used when ACORN_GIFT_RANGES is not
+// defined.
+var DefaultRangeBounds = []int64{100, 250, 500, 1000, 2500, 5000}
+
+// ----------------------------------------------------------------------------
+// Factory Functions
+// ----------------------------------------------------------------------------
+
+// NewGiftRanges returns the gift ranges with the lower bounds.  The bounds
+// must be greater than zero and increasing.
+func NewGiftRanges(bounds []int64) ([]GiftRange, error) {
+	var ranges = []GiftRange{}
+	if len(bounds) == 0 {
+		return ranges, errors.New("at least one gift range bound is required")
+	}
+	var low int64 = 0
+	for _, bound := range bounds {
+		if bound <= low {
+			return ranges, errors.New("gift range bounds must be greater than zero and increasing: " +
+				strconv.FormatInt(bound, 10))
+		}
+		ranges = append(ranges, GiftRange{low: low, high: bound, hasHigh: true})
+		low = bound
+	}
+	ranges = append(ranges, GiftRange{low: low})
+	return ranges, nil
+}
+
+// ParseGiftRanges returns the gift ranges for a comma separated list of
+// lower bounds.
+func ParseGiftRanges(value string) ([]GiftRange, error) {
+	var bounds = []int64{}
+	for _, field := range strings.Split(value, ",") {
+		var bound, err = strconv.ParseInt(strings.TrimSpace(field), 10, 64)
+		if err != nil {
+			return []GiftRange{}, errors.New("gift range bound is not a whole dollar amount: " + field)
+		}
+		bounds = append(bounds, bound)
+	}
+	return NewGiftRanges(bounds)
+}
+
+// ConfiguredGiftRanges returns the gift ranges from ACORN_GIFT_RANGES or the
+// default ranges if it is not defined.
+func ConfiguredGiftRanges() ([]GiftRange, error) {
+	var value = strings.TrimSpace(os.Getenv(RangesVariable))
+	if value == "" {
+		return NewGiftRanges(DefaultRangeBounds)
+	}
+	var ranges, err = ParseGiftRanges(value)
+	if err != nil {
+		err = sp.Wrap(err, sp.Usage, "reading "+RangesVariable)
+	}
+	return ranges, err
+}
+
+// ComputeGiftRangeChart places every donor who gave in a fiscal year in the
+// gift range of the donor's total for that year.
+func ComputeGiftRangeChart(donationList DonationList, ranges []GiftRange) GiftRangeChart {
+	assert.Assert(len(ranges) > 0, "gift ranges are required")
+	var chart = GiftRangeChart{
+		ranges:  ranges,
+		counts:  make([][a.NumFiscalYears]int, len(ranges)),
+		amounts: make([][a.NumFiscalYears]dec.Decimal, len(ranges)),
+	}
+	for _, donor := range donationList {
+		for _, fy := range a.FYIndicators {
+			if !donor.IsDonor(fy) {
+				continue
+			}
+			var amount = donor.Donation(fy)
+			for index, rng := range ranges {
+				if rng.Contains(amount) {
+					chart.counts[index][fy]++
+					chart.amounts[index][fy] = chart.amounts[index][fy].Add(amount)
+					break
+				}
+			}
+		}
+	}
+	return chart
+}
+
+// ----------------------------------------------------------------------------
+// Gift Range Methods
+// ----------------------------------------------------------------------------
+
+// Contains returns true if the amount is in the range.
+func (rng GiftRange) Contains(amount dec.Decimal) bool {
+	var result = amount.GreaterThanOrEqual(dec.NewFromInt(rng.low))
+	if rng.hasHigh {
+		result = result && amount.LessThan(dec.NewFromInt(rng.high))
+	}
+	return result
+}
+
+// String returns a label for the range such as "$100 - $249".
+func (rng GiftRange) String() string {
+	var label string
+	switch {
+	case rng.low == 0:
+		label = "Under " + dollars(rng.high)
+	case !rng.hasHigh:
+		label = dollars(rng.low) + " and over"
+	default:
+		label = dollars(rng.low) + " - " + dollars(rng.high-1)
+	}
+	return label
+}
+
+// dollars formats a whole dollar amount with a dollar sign and commas.
+func dollars(amount int64) string {
+	var digits = strconv.FormatInt(amount, 10)
+	var result = ""
+	for len(digits) > 3 {
+		result = "," + digits[len(digits)-3:] + result
+		digits = digits[:len(digits)-3]
+	}
+	return "$" + digits + result
+}
+
+// ----------------------------------------------------------------------------
+// Gift Range Chart Methods
+// ----------------------------------------------------------------------------
+
+// Ranges returns the gift ranges of the chart from lowest to highest.
+func (chart *GiftRangeChart) Ranges() []GiftRange {
+	return chart.ranges
+}
+
+// Count returns the number of donors in the range in the fiscal year.
+func (chart *GiftRangeChart) Count(fy a.FYIndicator, index int) int {
+	assert.Assert(a.IsFYIndicator(fy), "Invalid fiscal year indicator: "+fy.String())
+	return chart.counts[index][fy]
+}
+
+// Amount returns the total giving of the donors in the range in the fiscal
+// year.
+func (chart *GiftRangeChart) Amount(fy a.FYIndicator, index int) dec.Decimal {
+	assert.Assert(a.IsFYIndicator(fy), "Invalid fiscal year indicator: "+fy.String())
+	return chart.amounts[index][fy]
+}
+
+// TotalCount returns the number of donors in the fiscal year.
+func (chart *GiftRangeChart) TotalCount(fy a.FYIndicator) int {
+	var total = 0
+	for index := range chart.ranges {
+		total += chart.Count(fy, index)
+	}
+	return total
+}
+
+// TotalAmount returns the total giving in the fiscal year.
+func (chart *GiftRangeChart) TotalAmount(fy a.FYIndicator) dec.Decimal {
+	var total = dec.Zero
+	for index := range chart.ranges {
+		total = total.Add(chart.Amount(fy, index))
+	}
+	return total
+}
+
+// PercentDonors returns the percent of the fiscal year's donors in the
+// range.
+func (chart *GiftRangeChart) PercentDonors(fy a.FYIndicator, index int) float64 {
+	var percent float64 = 0.0
+	var total = chart.TotalCount(fy)
+	if total > 0 {
+		percent = math.Round(float64(chart.Count(fy, index)) * 100.00 / float64(total))
+	}
+	return percent
+}
+
+// PercentDollars returns the percent of the fiscal year's giving from the
+// donors in the range.
+func (chart *GiftRangeChart) PercentDollars(fy a.FYIndicator, index int) float64 {
+	var percent float64 = 0.0
+	var total = chart.TotalAmount(fy)
+	if total.GreaterThan(dec.Zero) {
+		percent = math.Round(chart.Amount(fy, index).InexactFloat64() * 100.00 / total.InexactFloat64())
+	}
+	return percent
+}
@@ -0,0 +1,75 @@
+// ----------------------------------------------------------------------------
+//
+// Gift Range Chart Tests
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package donations
+
+import (
+	"testing"
+
+	a "acorn_go/pkg/accounting"
+)
+
+// Test_GiftRanges checks the default ranges, their labels, and invalid
+// bounds.
+func Test_GiftRanges(t *testing.T) {
+	ranges, err := NewGiftRanges(DefaultRangeBounds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	labels := []string{"Under $100", "$100 - $249", "$250 - $499", "$500 - $999",
+		"$1,000 - $2,499", "$2,500 - $4,999", "$5,000 and over"}
+	if len(ranges) != len(labels) {
+		t.Fatalf("expected %d ranges, got %d", len(labels), len(ranges))
+	}
+	for index, rng := range ranges {
+		if rng.String() != labels[index] {
+			t.Errorf("range %d: got %q, want %q", index, rng.String(), labels[index])
+		}
+	}
+	for _, value := range []string{"", "100,abc", "250,100", "0,100"} {
+		if _, err = ParseGiftRanges(value); err == nil {
+			t.Errorf("bounds %q were accepted", value)
+		}
+	}
+	t.Setenv(RangesVariable, " 50, 500 ")
+	ranges, err = ConfiguredGiftRanges()
+	if err != nil || len(ranges) != 3 || ranges[2].String() != "$500 and over" {
+		t.Errorf("configured ranges were not used: %v", err)
+	}
+}
+
+// Test_ComputeGiftRangeChart checks the counts, amounts, and percents.
+func Test_ComputeGiftRangeChart(t *testing.T) {
+	dl := make(DonationList)
+	dl["A"] = amountDonor(t, "A", map[string]int64{"10/01/2025": 50})
+	dl["B"] = amountDonor(t, "B", map[string]int64{"10/01/2025": 100, "11/01/2025": 150})
+	dl["C"] = amountDonor(t, "C", map[string]int64{"10/01/2025": 700})
+	dl["D"] = amountDonor(t, "D", map[string]int64{"10/01/2024": 5000})
+	ranges, _ := NewGiftRanges([]int64{100, 500})
+
+	chart := ComputeGiftRangeChart(dl, ranges)
+	fy := a.FY2026
+	counts := []int{1, 1, 1}
+	amounts := []int64{50, 250, 700}
+	for index := range chart.Ranges() {
+		if chart.Count(fy, index) != counts[index] || chart.Amount(fy, index).IntPart() != amounts[index] {
+			t.Errorf("range %d: %d donors, %s", index, chart.Count(fy, index), chart.Amount(fy, index).String())
+		}
+	}
+	if chart.TotalCount(fy) != 3 || chart.TotalAmount(fy).IntPart() != 1000 {
+		t.Errorf("totals: %d donors, %s", chart.TotalCount(fy), chart.TotalAmount(fy).String())
+	}
+	if chart.PercentDonors(fy, 0) != 33 || chart.PercentDollars(fy, 2) != 70 {
+		t.Errorf("percents: %.0f donors, %.0f dollars", chart.PercentDonors(fy, 0), chart.PercentDollars(fy, 2))
+	}
+	if chart.Count(a.FY2025, 2) != 1 || chart.TotalCount(a.FY2025) != 1 {
+		t.Error("FY2025 donor is not in the top range")
+	}
+}
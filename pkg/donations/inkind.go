@@ -0,0 +1,57 @@
+// ----------------------------------------------------------------------------
+//
+// In-Kind Gifts
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package donations
+
+// This file adds the gifts in the in-kind spreadsheet to the donors in the
+// donation list.  A donor who has given only in-kind gifts is added to the
+// list with an empty cash ledger, so the cash analyses do not count the
+// donor as giving.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	dn "acorn_go/pkg/donors"
+	"acorn_go/pkg/spreadsheet"
+)
+
+// ----------------------------------------------------------------------------
+// Methods
+// ----------------------------------------------------------------------------
+
+// AddInKind adds the in-kind gifts in the rows of an in-kind spreadsheet to
+// the donors in the list and returns the number of gifts added.
+func (donationList DonationList) AddInKind(sprdsht *spreadsheet.Spreadsheet) (int, error) {
+	var numRows = sprdsht.Size()
+	for row := 1; row < numRows; row++ {
+		var nameDonor, gift, err = dn.ReadInKindGift(sprdsht, row)
+		if err != nil {
+			return row - 1, sprdsht.WrapRow(err, "processing in-kind gift", row)
+		}
+		if !donationList.Contains(nameDonor) {
+			var donor = dn.NewDonorWithDonation(nameDonor)
+			donationList[nameDonor] = &donor
+		}
+		donationList.Get(nameDonor).AddInKind(gift)
+	}
+	return max(numRows-1, 0), nil
+}
+
+// ReadInKind adds the in-kind gifts in an in-kind spreadsheet to the donors
+// in the list and returns the number of gifts added.
+func (donationList DonationList) ReadInKind(fileName string, tab string) (int, error) {
+	var sprdsht, err = spreadsheet.ProcessData(fileName, tab)
+	if err != nil {
+		return 0, err
+	}
+	return donationList.AddInKind(&sprdsht)
+}
@@ -0,0 +1,160 @@
+// ----------------------------------------------------------------------------
+//
+// Lapsed Donors
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package donations
+
+// This file finds the LYBUNT donors, who gave last fiscal year but not this
+// one, and the SYBUNT donors, who gave in an earlier fiscal year but not last
+// year or this one.  Donors are found as of a date, so only the gifts made
+// on or before that date are used.  The fiscal year of the date is "this
+// fiscal year".
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	a "acorn_go/pkg/accounting"
+	dn "acorn_go/pkg/donors"
+
+	dec "github.com/shopspring/decimal"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Lapse identifies how long a donor has not given.
+type Lapse int
+
+// LapsedDonor is a donor who has not given this fiscal year with the
+// donor's giving history as of a date.
+type LapsedDonor struct {
+	donor         *dn.Donor
+	lapse         Lapse
+	lastGift      dn.Gift
+	lastYearTotal dec.Decimal
+	lifetimeTotal dec.Decimal
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const (
+	Current = Lapse(0) // gave this fiscal year or never gave
+	Lybunt  = Lapse(1) // gave last year but unfortunately not this year
+	Sybunt  = Lapse(2) // gave some year but unfortunately not this year
+)
+
+// ----------------------------------------------------------------------------
+// Factory Functions
+// ----------------------------------------------------------------------------
+
+// NewLapsedDonor returns the giving history of the donor as of the date.
+// The second value is false if the donor gave in the fiscal year of the date
+// or has never given.
+func NewLapsedDonor(donor *dn.Donor, asOf d.Date) (LapsedDonor, bool) {
+	var lapsed = LapsedDonor{
+		donor:         donor,
+		lapse:         Current,
+		lastYearTotal: dec.Zero,
+		lifetimeTotal: dec.Zero,
+	}
+	//
+	// Use only the gifts and reversals made by the date
+	//
+	var gifts = donor.Through(asOf).Gifts()
+	var years = givingYears(gifts)
+	var number = a.FiscalYearNumber(asOf)
+	switch classifyYear(years, number) {
+	case Lapsed:
+		lapsed.lapse = Lybunt
+	case Lost:
+		lapsed.lapse = Sybunt
+	}
+	if lapsed.lapse == Current {
+		return lapsed, false
+	}
+	//
+	// Summarize the giving history
+	//
+	for _, gift := range gifts {
+		lapsed.lifetimeTotal = lapsed.lifetimeTotal.Add(gift.Amount())
+		if gift.Amount().GreaterThan(dec.Zero) {
+			lapsed.lastGift = gift
+		}
+	}
+	var lastYear = a.FiscalYearNumber(lapsed.lastGift.Date())
+	for _, gift := range gifts {
+		if a.FiscalYearNumber(gift.Date()) == lastYear {
+			lapsed.lastYearTotal = lapsed.lastYearTotal.Add(gift.Amount())
+		}
+	}
+	return lapsed, true
+}
+
+// LapsedDonors returns the donors in the list with the lapse as of the date
+// in the order of their keys.
+func LapsedDonors(donationList DonationList, asOf d.Date, lapse Lapse) []LapsedDonor {
+	var result = []LapsedDonor{}
+	for _, key := range donationList.DonorKeys() {
+		var lapsed, ok = NewLapsedDonor(donationList.Get(key), asOf)
+		if ok && lapsed.lapse == lapse {
+			result = append(result, lapsed)
+		}
+	}
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Methods
+// ----------------------------------------------------------------------------
+
+// String returns the name of the lapse.
+func (lapse Lapse) String() string {
+	var names = []string{"Current", "LYBUNT", "SYBUNT"}
+	var name = "Unknown"
+	if Current <= lapse && int(lapse) < len(names) {
+		name = names[lapse]
+	}
+	return name
+}
+
+// Donor returns the donor.
+func (lapsed LapsedDonor) Donor() *dn.Donor {
+	return lapsed.donor
+}
+
+// Lapse returns whether the donor is a LYBUNT or SYBUNT donor.
+func (lapsed LapsedDonor) Lapse() Lapse {
+	return lapsed.lapse
+}
+
+// LastGift returns the most recent gift greater than zero.
+func (lapsed LapsedDonor) LastGift() dn.Gift {
+	return lapsed.lastGift
+}
+
+// LastYear returns the fiscal year number of the last gift.
+func (lapsed LapsedDonor) LastYear() int {
+	return a.FiscalYearNumber(lapsed.lastGift.Date())
+}
+
+// LastYearTotal returns the total given in the fiscal year of the last gift.
+func (lapsed LapsedDonor) LastYearTotal() dec.Decimal {
+	return lapsed.lastYearTotal
+}
+
+// LifetimeTotal returns the total of all gifts made by the date.
+func (lapsed LapsedDonor) LifetimeTotal() dec.Decimal {
+	return lapsed.lifetimeTotal
+}
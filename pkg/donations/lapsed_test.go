@@ -0,0 +1,105 @@
+// ----------------------------------------------------------------------------
+//
+// Lapsed Donor Tests
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package donations
+
+import (
+	dn "acorn_go/pkg/donors"
+	"testing"
+
+	dec "github.com/shopspring/decimal"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// Test_NewLapsedDonor checks the lapse and giving history of donors.
+func Test_NewLapsedDonor(t *testing.T) {
+	tests := []struct {
+		name     string
+		dates    []string
+		asOf     string
+		lapse    Lapse
+		lastGift string
+		lastYear int
+		lifetime int64
+	}{
+		{"lybunt", []string{"10/01/2023", "10/01/2024", "03/01/2025"}, "08/31/2026", Lybunt, "03/01/2025", 2025, 300},
+		{"sybunt", []string{"10/01/2018", "10/01/2023"}, "08/31/2026", Sybunt, "10/01/2023", 2024, 200},
+		{"current", []string{"10/01/2024", "10/01/2025"}, "08/31/2026", Current, "", 0, 0},
+		{"mid-year lybunt", []string{"10/01/2024", "04/01/2026"}, "03/01/2026", Lybunt, "10/01/2024", 2025, 100},
+		{"mid-year current", []string{"10/01/2024", "10/01/2025"}, "03/01/2026", Current, "", 0, 0},
+		{"not yet a donor", []string{"04/01/2026"}, "03/01/2026", Current, "", 0, 0},
+	}
+
+	for _, tt := range tests {
+		var asOf = date(t, tt.asOf)
+		var lapsed, ok = NewLapsedDonor(lifecycleDonor(t, tt.name, tt.dates...), asOf)
+		if ok != (tt.lapse != Current) || lapsed.Lapse() != tt.lapse {
+			t.Errorf("%s: got %s %v, want %s", tt.name, lapsed.Lapse().String(), ok, tt.lapse.String())
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if lapsed.LastGift().Date().String() != lastGiftDate(t, tt.lastGift).String() {
+			t.Errorf("%s: last gift %s, want %s", tt.name, lapsed.LastGift().Date().String(), tt.lastGift)
+		}
+		if lapsed.LastYear() != tt.lastYear {
+			t.Errorf("%s: last year %d, want %d", tt.name, lapsed.LastYear(), tt.lastYear)
+		}
+		if lapsed.LifetimeTotal().IntPart() != tt.lifetime {
+			t.Errorf("%s: lifetime %s, want %d", tt.name, lapsed.LifetimeTotal().String(), tt.lifetime)
+		}
+	}
+}
+
+// Test_LapsedDonors checks that donors are selected by lapse in key order.
+func Test_LapsedDonors(t *testing.T) {
+	dl := make(DonationList)
+	dl["B"] = lifecycleDonor(t, "B", "10/01/2024")
+	dl["A"] = lifecycleDonor(t, "A", "10/01/2024")
+	dl["C"] = lifecycleDonor(t, "C", "10/01/2022")
+	dl["D"] = lifecycleDonor(t, "D", "10/01/2025")
+	var asOf, _ = d.New(8, 31, 2026)
+
+	lybunt := LapsedDonors(dl, asOf, Lybunt)
+	if len(lybunt) != 2 || lybunt[0].Donor().Key() != "A" || lybunt[1].Donor().Key() != "B" {
+		t.Errorf("unexpected LYBUNT donors: %d", len(lybunt))
+	}
+	sybunt := LapsedDonors(dl, asOf, Sybunt)
+	if len(sybunt) != 1 || sybunt[0].Donor().Key() != "C" {
+		t.Errorf("unexpected SYBUNT donors: %d", len(sybunt))
+	}
+}
+
+// Test_LapsedDonorLaterRefund checks that a refund made after the date
+// does not change the lapse as of the date.
+func Test_LapsedDonorLaterRefund(t *testing.T) {
+	var donor = amountDonor(t, "refunded", map[string]int64{"10/01/2024": 100, "03/01/2025": 100})
+	var refundDate = date(t, "10/15/2025")
+	donor.AddGift(dn.NewGift(refundDate, dec.NewFromInt(-100), "Refund", "", 0))
+	donor.AddGift(dn.NewGift(refundDate, dec.NewFromInt(-100), "Refund", "", 0))
+
+	var asOf = date(t, "09/30/2025")
+	var lapsed, ok = NewLapsedDonor(donor, asOf)
+	if !ok || lapsed.Lapse() != Lybunt || lapsed.LifetimeTotal().IntPart() != 200 {
+		t.Errorf("got %s %v with lifetime %s, want LYBUNT with 200", lapsed.Lapse().String(), ok,
+			lapsed.LifetimeTotal().String())
+	}
+	asOf = date(t, "08/31/2026")
+	if _, ok = NewLapsedDonor(donor, asOf); ok {
+		t.Error("donor whose gifts were all refunded is lapsed")
+	}
+}
+
+// lastGiftDate parses the date of the expected last gift.
+func lastGiftDate(t *testing.T, value string) d.Date {
+	t.Helper()
+	return date(t, value)
+}
@@ -0,0 +1,179 @@
+// ----------------------------------------------------------------------------
+//
+// Donor Lifecycle
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package donations
+
+// The donor lifecycle places each donor in a segment for a fiscal year based
+// on the donor's full gift history, including gifts made before the fiscal
+// years of the analysis.  The flows count the donors moving from their
+// segment in the prior fiscal year to their segment in the fiscal year.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	a "acorn_go/pkg/accounting"
+	dn "acorn_go/pkg/donors"
+
+	dec "github.com/shopspring/decimal"
+	"github.com/waysys/assert/assert"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Segment is the lifecycle segment of a donor in a fiscal year.
+type Segment int
+
+// Lifecycle holds the segment counts and the segment flows for each fiscal
+// year.
+type Lifecycle struct {
+	counts [a.NumFiscalYears][NumSegments]int
+	flows  [a.NumFiscalYears][NumSegments][NumSegments]int
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const NumSegments = 6
+
+const (
+	Prospect    = Segment(0) // has not yet given
+	New         = Segment(1) // first gift in this fiscal year
+	Retained    = Segment(2) // gave in this and the prior fiscal year
+	Reactivated = Segment(3) // gave in this fiscal year after a gap
+	Lapsed      = Segment(4) // gave in the prior fiscal year but not this one
+	Lost        = Segment(5) // has not given for two or more fiscal years
+)
+
+var Segments = [NumSegments]Segment{
+	Prospect,
+	New,
+	Retained,
+	Reactivated,
+	Lapsed,
+	Lost,
+}
+
+var SegmentNames = [NumSegments]string{
+	"Not Yet a Donor",
+	"New",
+	"Retained",
+	"Reactivated",
+	"Lapsed",
+	"Lost",
+}
+
+// ----------------------------------------------------------------------------
+// Functions
+// ----------------------------------------------------------------------------
+
+// IsSegment returns true if the segment is a valid value.
+func IsSegment(segment Segment) bool {
+	return Prospect <= segment && segment < NumSegments
+}
+
+// Classify returns the lifecycle segment of the donor in the fiscal year.
+func Classify(donor *dn.Donor, fy a.FYIndicator) Segment {
+	assert.Assert(a.IsFYIndicator(fy), "Invalid fiscal year indicator: "+fy.String())
+	var years = givingYears(donor.Gifts())
+	return classifyYear(years, fy.Number())
+}
+
+// givingYears returns the fiscal year numbers in which the gifts total an
+// amount greater than zero.
+func givingYears(gifts []dn.Gift) map[int]bool {
+	var totals = make(map[int]dec.Decimal)
+	for _, gift := range gifts {
+		var number = a.FiscalYearNumber(gift.Date())
+		totals[number] = totals[number].Add(gift.Amount())
+	}
+	var years = make(map[int]bool)
+	for number, total := range totals {
+		if total.GreaterThan(dec.Zero) {
+			years[number] = true
+		}
+	}
+	return years
+}
+
+// classifyYear returns the segment for the fiscal year number given the
+// fiscal years in which the donor gave.
+func classifyYear(years map[int]bool, number int) Segment {
+	var gaveBefore = false
+	for year := range years {
+		if year < number {
+			gaveBefore = true
+			break
+		}
+	}
+	var segment Segment
+	switch {
+	case years[number] && !gaveBefore:
+		segment = New
+	case years[number] && years[number-1]:
+		segment = Retained
+	case years[number]:
+		segment = Reactivated
+	case years[number-1]:
+		segment = Lapsed
+	case gaveBefore:
+		segment = Lost
+	default:
+		segment = Prospect
+	}
+	return segment
+}
+
+// ComputeLifecycle classifies every donor in the list for each fiscal year
+// and counts the flows between segments.  The flows into the first fiscal
+// year come from the segments of the fiscal year before it.
+func ComputeLifecycle(donationList DonationList) Lifecycle {
+	var lifecycle Lifecycle
+	for _, donor := range donationList {
+		var years = givingYears(donor.Gifts())
+		for _, fy := range a.FYIndicators {
+			var number = fy.Number()
+			var from = classifyYear(years, number-1)
+			var to = classifyYear(years, number)
+			lifecycle.counts[fy][to]++
+			lifecycle.flows[fy][from][to]++
+		}
+	}
+	return lifecycle
+}
+
+// ----------------------------------------------------------------------------
+// Methods
+// ----------------------------------------------------------------------------
+
+// String returns the name of the segment.
+func (segment Segment) String() string {
+	assert.Assert(IsSegment(segment), "Invalid segment")
+	return SegmentNames[segment]
+}
+
+// Count returns the number of donors in the segment in the fiscal year.
+func (lifecycle *Lifecycle) Count(fy a.FYIndicator, segment Segment) int {
+	assert.Assert(a.IsFYIndicator(fy), "Invalid fiscal year indicator: "+fy.String())
+	assert.Assert(IsSegment(segment), "Invalid segment")
+	return lifecycle.counts[fy][segment]
+}
+
+// Flow returns the number of donors who were in the from segment in the
+// prior fiscal year and are in the to segment in the fiscal year.
+func (lifecycle *Lifecycle) Flow(fy a.FYIndicator, from Segment, to Segment) int {
+	assert.Assert(a.IsFYIndicator(fy), "Invalid fiscal year indicator: "+fy.String())
+	assert.Assert(IsSegment(from) && IsSegment(to), "Invalid segment")
+	return lifecycle.flows[fy][from][to]
+}
@@ -0,0 +1,95 @@
+// ----------------------------------------------------------------------------
+//
+// Donor Lifecycle Tests
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package donations
+
+import (
+	"testing"
+
+	a "acorn_go/pkg/accounting"
+	dn "acorn_go/pkg/donors"
+
+	dec "github.com/shopspring/decimal"
+)
+
+// lifecycleDonor returns a donor who gave 100 on each of the dates.
+func lifecycleDonor(t *testing.T, name string, dates ...string) *dn.Donor {
+	t.Helper()
+	var donor = dn.NewDonorWithDonation(name)
+	for _, value := range dates {
+		donor.AddGift(dn.NewGift(date(t, value), dec.NewFromInt(100), "Payment", "", 0))
+	}
+	return &donor
+}
+
+// Test_Classify checks the segment of donors in each fiscal year.
+func Test_Classify(t *testing.T) {
+	tests := []struct {
+		name  string
+		donor *dn.Donor
+		want  [a.NumFiscalYears]Segment
+	}{
+		{"consecutive", lifecycleDonor(t, "A", "10/01/2022", "10/01/2023", "10/01/2024", "10/01/2025"),
+			[a.NumFiscalYears]Segment{New, Retained, Retained, Retained}},
+		{"lapsed then lost", lifecycleDonor(t, "B", "10/01/2022"),
+			[a.NumFiscalYears]Segment{New, Lapsed, Lost, Lost}},
+		{"reactivated", lifecycleDonor(t, "C", "10/01/2022", "10/01/2024"),
+			[a.NumFiscalYears]Segment{New, Lapsed, Reactivated, Lapsed}},
+		{"history before analysis", lifecycleDonor(t, "D", "10/01/2018", "10/01/2025"),
+			[a.NumFiscalYears]Segment{Lost, Lost, Lost, Reactivated}},
+		{"retained from before analysis", lifecycleDonor(t, "E", "08/31/2022", "09/01/2022"),
+			[a.NumFiscalYears]Segment{Retained, Lapsed, Lost, Lost}},
+		{"late start", lifecycleDonor(t, "F", "10/01/2024", "10/01/2025"),
+			[a.NumFiscalYears]Segment{Prospect, Prospect, New, Retained}},
+	}
+
+	for _, tt := range tests {
+		for _, fy := range a.FYIndicators {
+			got := Classify(tt.donor, fy)
+			if got != tt.want[fy] {
+				t.Errorf("%s in %s: got %s, want %s", tt.name, fy.String(), got.String(), tt.want[fy].String())
+			}
+		}
+	}
+}
+
+// Test_ComputeLifecycle checks the segment counts and flows.
+func Test_ComputeLifecycle(t *testing.T) {
+	dl := make(DonationList)
+	dl["A"] = lifecycleDonor(t, "A", "10/01/2022", "10/01/2023")
+	dl["B"] = lifecycleDonor(t, "B", "10/01/2022")
+	dl["C"] = lifecycleDonor(t, "C", "10/01/2018", "10/01/2023")
+
+	lifecycle := ComputeLifecycle(dl)
+	if lifecycle.Count(a.FY2024, Retained) != 1 {
+		t.Errorf("expected 1 retained donor in FY2024, got %d", lifecycle.Count(a.FY2024, Retained))
+	}
+	if lifecycle.Count(a.FY2024, Reactivated) != 1 {
+		t.Errorf("expected 1 reactivated donor in FY2024, got %d", lifecycle.Count(a.FY2024, Reactivated))
+	}
+	if lifecycle.Flow(a.FY2023, Prospect, New) != 2 {
+		t.Errorf("expected 2 new donors in FY2023, got %d", lifecycle.Flow(a.FY2023, Prospect, New))
+	}
+	if lifecycle.Flow(a.FY2024, New, Lapsed) != 1 {
+		t.Errorf("expected 1 donor from new to lapsed in FY2024, got %d", lifecycle.Flow(a.FY2024, New, Lapsed))
+	}
+	if lifecycle.Flow(a.FY2024, Lost, Reactivated) != 1 {
+		t.Errorf("expected 1 donor from lost to reactivated in FY2024, got %d", lifecycle.Flow(a.FY2024, Lost, Reactivated))
+	}
+	for _, fy := range a.FYIndicators {
+		var total = 0
+		for _, segment := range Segments {
+			total += lifecycle.Count(fy, segment)
+		}
+		if total != dl.DonorCount() {
+			t.Errorf("%s: segment counts total %d, want %d", fy.String(), total, dl.DonorCount())
+		}
+	}
+}
@@ -0,0 +1,246 @@
+// ----------------------------------------------------------------------------
+//
+// Recurring Gifts
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package donations
+
+// This file finds the sustainers, donors who give monthly, quarterly, or
+// annually at a consistent time.  Gifts are installments when each follows
+// the one before within the window of the cadence, such as 20 to 40 days
+// for a monthly gift.  A gift that comes sooner than the window, such as an
+// extra gift at year end, is not an installment and does not break the
+// series.  A donor is a sustainer when a series has at least MinInstallments
+// installments, and the most recent such series is reported.  Only the
+// gifts made on or before a date are used, and the recurring gift has
+// stopped when the next installment is past the end of its window on that
+// date.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	a "acorn_go/pkg/accounting"
+	dn "acorn_go/pkg/donors"
+	"slices"
+
+	dec "github.com/shopspring/decimal"
+	"github.com/waysys/assert/assert"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Cadence is how often a sustainer gives.
+type Cadence int
+
+// Sustainer is a donor with a recurring gift as of a date.
+type Sustainer struct {
+	donor        *dn.Donor
+	cadence      Cadence
+	installments []dn.Gift
+	asOf         d.Date
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const NumCadences = 3
+
+const (
+	Monthly   = Cadence(0)
+	Quarterly = Cadence(1)
+	Annual    = Cadence(2)
+)
+
+var Cadences = [NumCadences]Cadence{
+	Monthly,
+	Quarterly,
+	Annual,
+}
+
+var cadenceNames = [NumCadences]string{
+	"Monthly",
+	"Quarterly",
+	"Annual",
+}
+
+// Days from one installment to the next: the usual number and the
+// shortest and longest accepted
+var cadencePeriods = [NumCadences]int{30, 91, 365}
+var cadenceLows = [NumCadences]int{20, 75, 335}
+var cadenceHighs = [NumCadences]int{40, 105, 395}
+
+// MinInstallments is the number of installments needed to recognize a
+// recurring gift.
+const MinInstallments = 3
+
+// ----------------------------------------------------------------------------
+// Factory Functions
+// ----------------------------------------------------------------------------
+
+// NewSustainer returns the recurring gift of the donor as of the date.  The
+// second value is false if the donor has no recurring gift.  If the gifts
+// fit more than one cadence, the one with the latest installment is used.
+func NewSustainer(donor *dn.Donor, asOf d.Date) (Sustainer, bool) {
+	var sustainer = Sustainer{donor: donor, asOf: asOf}
+	var found = false
+	//
+	// Use only the gifts greater than zero, net of the reversals made by
+	// the date
+	//
+	var gifts = []dn.Gift{}
+	for _, gift := range donor.Through(asOf).Gifts() {
+		if gift.Amount().GreaterThan(dec.Zero) {
+			gifts = append(gifts, gift)
+		}
+	}
+	//
+	// Find the most recent series of each cadence
+	//
+	for _, cadence := range Cadences {
+		var series = latestSeries(gifts, cadence)
+		if len(series) < MinInstallments {
+			continue
+		}
+		if !found || series[len(series)-1].Date().After(sustainer.LastInstallment().Date()) {
+			sustainer.cadence = cadence
+			sustainer.installments = series
+			found = true
+		}
+	}
+	return sustainer, found
+}
+
+// Sustainers returns the donors in the list with a recurring gift as of the
+// date in the order of their keys.
+func Sustainers(donationList DonationList, asOf d.Date) []Sustainer {
+	var result = []Sustainer{}
+	for _, key := range donationList.DonorKeys() {
+		var sustainer, ok = NewSustainer(donationList.Get(key), asOf)
+		if ok {
+			result = append(result, sustainer)
+		}
+	}
+	return result
+}
+
+// latestSeries returns the installments of the most recent series of gifts
+// with the cadence that has at least MinInstallments installments, or the
+// last series if none has.  The gifts must be in date order.
+func latestSeries(gifts []dn.Gift, cadence Cadence) []dn.Gift {
+	var series = []dn.Gift{}
+	var complete = []dn.Gift{}
+	for _, gift := range gifts {
+		if len(series) == 0 {
+			series = append(series, gift)
+			continue
+		}
+		var days = a.DaysBetween(series[len(series)-1].Date(), gift.Date())
+		switch {
+		case days < cadenceLows[cadence]:
+			continue
+		case days <= cadenceHighs[cadence]:
+			series = append(series, gift)
+		default:
+			if len(series) >= MinInstallments {
+				complete = series
+			}
+			series = []dn.Gift{gift}
+		}
+	}
+	if len(series) < MinInstallments && len(complete) > 0 {
+		series = complete
+	}
+	return series
+}
+
+// ----------------------------------------------------------------------------
+// Cadence Methods
+// ----------------------------------------------------------------------------
+
+// String returns the name of the cadence.
+func (cadence Cadence) String() string {
+	assert.Assert(Monthly <= cadence && cadence < NumCadences, "Invalid cadence")
+	return cadenceNames[cadence]
+}
+
+// Period returns the usual number of days from one installment to the
+// next.
+func (cadence Cadence) Period() int {
+	assert.Assert(Monthly <= cadence && cadence < NumCadences, "Invalid cadence")
+	return cadencePeriods[cadence]
+}
+
+// ----------------------------------------------------------------------------
+// Sustainer Methods
+// ----------------------------------------------------------------------------
+
+// Donor returns the donor.
+func (sustainer Sustainer) Donor() *dn.Donor {
+	return sustainer.donor
+}
+
+// Cadence returns how often the donor gives.
+func (sustainer Sustainer) Cadence() Cadence {
+	return sustainer.cadence
+}
+
+// Installments returns the number of installments in the series.
+func (sustainer Sustainer) Installments() int {
+	return len(sustainer.installments)
+}
+
+// FirstInstallment returns the first installment of the series.
+func (sustainer Sustainer) FirstInstallment() dn.Gift {
+	return sustainer.installments[0]
+}
+
+// LastInstallment returns the most recent installment.
+func (sustainer Sustainer) LastInstallment() dn.Gift {
+	return sustainer.installments[len(sustainer.installments)-1]
+}
+
+// TypicalAmount returns the median amount of the installments.  With an
+// even number of installments, it is the lower of the two middle amounts.
+func (sustainer Sustainer) TypicalAmount() dec.Decimal {
+	var amounts = []dec.Decimal{}
+	for _, gift := range sustainer.installments {
+		amounts = append(amounts, gift.Amount())
+	}
+	slices.SortFunc(amounts, func(x dec.Decimal, y dec.Decimal) int {
+		return x.Cmp(y)
+	})
+	return amounts[(len(amounts)-1)/2]
+}
+
+// NextExpected returns the date the next installment is expected.
+func (sustainer Sustainer) NextExpected() d.Date {
+	return a.AddDays(sustainer.LastInstallment().Date(), sustainer.cadence.Period())
+}
+
+// MissedInstallments returns the number of installments expected but not
+// made by the date.  It is zero while the next installment is within its
+// window.
+func (sustainer Sustainer) MissedInstallments() int {
+	var days = a.DaysBetween(sustainer.LastInstallment().Date(), sustainer.asOf)
+	var missed = 0
+	if days > cadenceHighs[sustainer.cadence] {
+		missed = days / sustainer.cadence.Period()
+	}
+	return missed
+}
+
+// Missed returns true if the recurring gift has stopped.
+func (sustainer Sustainer) Missed() bool {
+	return sustainer.MissedInstallments() > 0
+}
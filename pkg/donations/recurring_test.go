@@ -0,0 +1,115 @@
+// ----------------------------------------------------------------------------
+//
+// Recurring Gift Tests
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package donations
+
+import (
+	dn "acorn_go/pkg/donors"
+	"testing"
+
+	dec "github.com/shopspring/decimal"
+)
+
+// Test_NewSustainer checks the cadence, installments, and missed
+// installments of recurring gifts.
+func Test_NewSustainer(t *testing.T) {
+	tests := []struct {
+		name         string
+		gifts        map[string]int64
+		ok           bool
+		cadence      Cadence
+		installments int
+		typical      int64
+		last         string
+		next         string
+		missed       int
+	}{
+		{"monthly", map[string]int64{"05/15/2026": 25, "06/15/2026": 25, "06/20/2026": 500, "07/15/2026": 30, "08/15/2026": 25},
+			true, Monthly, 4, 25, "08/15/2026", "09/14/2026", 0},
+		{"quarterly", map[string]int64{"11/01/2025": 100, "02/01/2026": 100, "05/01/2026": 150, "08/01/2026": 150},
+			true, Quarterly, 4, 100, "08/01/2026", "10/31/2026", 0},
+		{"annual", map[string]int64{"12/15/2022": 500, "12/20/2023": 500, "12/10/2024": 600, "12/18/2025": 600},
+			true, Annual, 4, 500, "12/18/2025", "12/18/2026", 0},
+		{"stopped", map[string]int64{"01/10/2026": 50, "02/10/2026": 50, "03/10/2026": 50, "04/10/2026": 50},
+			true, Monthly, 4, 50, "04/10/2026", "05/10/2026", 4},
+		{"stopped then one gift", map[string]int64{"01/10/2026": 50, "02/10/2026": 50, "03/10/2026": 50, "07/20/2026": 200},
+			true, Monthly, 3, 50, "03/10/2026", "04/09/2026", 5},
+		{"too few", map[string]int64{"07/01/2026": 50, "08/01/2026": 50}, false, Monthly, 0, 0, "", "", 0},
+		{"after the date", map[string]int64{"07/01/2026": 50, "08/01/2026": 50, "09/01/2026": 50}, false, Monthly, 0, 0, "", "", 0},
+	}
+
+	var asOf = date(t, "08/31/2026")
+	for _, tt := range tests {
+		var sustainer, ok = NewSustainer(amountDonor(t, tt.name, tt.gifts), asOf)
+		if ok != tt.ok {
+			t.Errorf("%s: sustainer %v, want %v", tt.name, ok, tt.ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if sustainer.Cadence() != tt.cadence || sustainer.Installments() != tt.installments {
+			t.Errorf("%s: got %s with %d installments, want %s with %d", tt.name,
+				sustainer.Cadence().String(), sustainer.Installments(), tt.cadence.String(), tt.installments)
+		}
+		if sustainer.TypicalAmount().IntPart() != tt.typical {
+			t.Errorf("%s: typical amount %s, want %d", tt.name, sustainer.TypicalAmount().String(), tt.typical)
+		}
+		if sustainer.LastInstallment().Date().String() != lastGiftDate(t, tt.last).String() {
+			t.Errorf("%s: last installment %s, want %s", tt.name, sustainer.LastInstallment().Date().String(), tt.last)
+		}
+		if sustainer.NextExpected().String() != lastGiftDate(t, tt.next).String() {
+			t.Errorf("%s: next expected %s, want %s", tt.name, sustainer.NextExpected().String(), tt.next)
+		}
+		if sustainer.MissedInstallments() != tt.missed || sustainer.Missed() != (tt.missed > 0) {
+			t.Errorf("%s: missed %d, want %d", tt.name, sustainer.MissedInstallments(), tt.missed)
+		}
+	}
+}
+
+// Test_Sustainers checks that only donors with a recurring gift are listed,
+// in key order.
+func Test_Sustainers(t *testing.T) {
+	dl := make(DonationList)
+	dl["b"] = amountDonor(t, "b", map[string]int64{"05/15/2026": 25, "06/15/2026": 25, "07/15/2026": 25})
+	dl["a"] = amountDonor(t, "a", map[string]int64{"11/01/2025": 100, "02/01/2026": 100, "05/01/2026": 100})
+	dl["c"] = amountDonor(t, "c", map[string]int64{"10/01/2025": 100})
+
+	var asOf = date(t, "08/31/2026")
+	var sustainers = Sustainers(dl, asOf)
+	if len(sustainers) != 2 {
+		t.Fatalf("number of sustainers %d, want 2", len(sustainers))
+	}
+	if sustainers[0].Donor().Key() != "a" || sustainers[1].Donor().Key() != "b" {
+		t.Errorf("sustainers %s, %s, want a, b", sustainers[0].Donor().Key(), sustainers[1].Donor().Key())
+	}
+	if sustainers[0].Cadence() != Quarterly || sustainers[1].Cadence() != Monthly {
+		t.Errorf("cadences %s, %s, want Quarterly, Monthly",
+			sustainers[0].Cadence().String(), sustainers[1].Cadence().String())
+	}
+}
+
+// Test_SustainerLaterRefund checks that a refund made after the date does
+// not remove an installment as of the date.
+func Test_SustainerLaterRefund(t *testing.T) {
+	var donor = amountDonor(t, "refunded", map[string]int64{"05/15/2026": 25, "06/15/2026": 25, "07/15/2026": 25})
+	var refundDate = date(t, "08/20/2026")
+	donor.AddGift(dn.NewGift(refundDate, dec.NewFromInt(-25), "Refund", "", 0))
+
+	var asOf = date(t, "07/31/2026")
+	var sustainer, ok = NewSustainer(donor, asOf)
+	if !ok || sustainer.Installments() != 3 {
+		t.Errorf("got sustainer %v with %d installments, want 3", ok, sustainer.Installments())
+	}
+	asOf = date(t, "08/31/2026")
+	if _, ok = NewSustainer(donor, asOf); ok {
+		t.Error("refunded installment was counted")
+	}
+}
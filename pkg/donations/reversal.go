@@ -0,0 +1,158 @@
+// ----------------------------------------------------------------------------
+//
+// Reversed Gifts
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package donations
+
+// This file lists the refunds, chargebacks, and other reversals in the
+// donation list and summarizes them by fiscal year.  A reversal counts in
+// the fiscal year of the gift it reverses, so the totals of each year are
+// the money actually kept.  A reversal that matched no gift counts in the
+// fiscal year of its own date.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	a "acorn_go/pkg/accounting"
+	dn "acorn_go/pkg/donors"
+
+	dec "github.com/shopspring/decimal"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// ReversedGift is a reversal with the donor whose gift it reverses.
+type ReversedGift struct {
+	donor    *dn.Donor
+	reversal dn.Gift
+}
+
+// ReversalSummary is the giving and the reversals of a fiscal year.
+type ReversalSummary struct {
+	fy        a.FYIndicator
+	received  dec.Decimal
+	reversals int
+	reversed  dec.Decimal
+}
+
+// ----------------------------------------------------------------------------
+// Factory Functions
+// ----------------------------------------------------------------------------
+
+// ReversedGifts returns the reversals in the donation list in the order of
+// the donor keys and, for each donor, in the order they were added.
+func ReversedGifts(donationList DonationList) []ReversedGift {
+	var result = []ReversedGift{}
+	for _, key := range donationList.DonorKeys() {
+		var donor = donationList.Get(key)
+		for _, reversal := range donor.Reversals() {
+			result = append(result, ReversedGift{donor: donor, reversal: reversal})
+		}
+	}
+	return result
+}
+
+// SummarizeReversals returns the giving received, the reversals, and the
+// giving kept in each fiscal year.
+func SummarizeReversals(donationList DonationList) [a.NumFiscalYears]ReversalSummary {
+	var summaries [a.NumFiscalYears]ReversalSummary
+	for _, fy := range a.FYIndicators {
+		summaries[fy] = ReversalSummary{fy: fy, received: dec.Zero, reversed: dec.Zero}
+	}
+	//
+	// Add the gifts received
+	//
+	for _, donor := range donationList {
+		for _, gift := range donor.Gifts() {
+			var fy = a.FiscalYearIndicator(gift.Date())
+			if a.IsFYIndicator(fy) && !gift.IsReversal() {
+				summaries[fy].received = summaries[fy].received.Add(gift.Received())
+			}
+		}
+	}
+	//
+	// Add the reversals
+	//
+	for _, reversed := range ReversedGifts(donationList) {
+		var fy = reversed.FiscalYear()
+		if a.IsFYIndicator(fy) {
+			summaries[fy].reversals++
+			summaries[fy].reversed = summaries[fy].reversed.Add(reversed.Amount())
+		}
+	}
+	return summaries
+}
+
+// ----------------------------------------------------------------------------
+// Reversed Gift Methods
+// ----------------------------------------------------------------------------
+
+// Donor returns the donor whose gift was reversed.
+func (reversed ReversedGift) Donor() *dn.Donor {
+	return reversed.donor
+}
+
+// Reversal returns the refund, chargeback, or other reversal.
+func (reversed ReversedGift) Reversal() dn.Gift {
+	return reversed.reversal
+}
+
+// Amount returns the amount reversed as a positive number.
+func (reversed ReversedGift) Amount() dec.Decimal {
+	return reversed.reversal.Received().Neg()
+}
+
+// Original returns the gift the reversal was netted against.  The second
+// value is false if the reversal matched no gift.
+func (reversed ReversedGift) Original() (dn.Gift, bool) {
+	return reversed.reversal.Reverses()
+}
+
+// FiscalYear returns the fiscal year the reversal counts in: that of the
+// gift it reverses, or that of the reversal if it matched no gift.
+func (reversed ReversedGift) FiscalYear() a.FYIndicator {
+	var date = reversed.reversal.Date()
+	if original, found := reversed.Original(); found {
+		date = original.Date()
+	}
+	return a.FiscalYearIndicator(date)
+}
+
+// ----------------------------------------------------------------------------
+// Reversal Summary Methods
+// ----------------------------------------------------------------------------
+
+// FiscalYear returns the fiscal year of the summary.
+func (summary ReversalSummary) FiscalYear() a.FYIndicator {
+	return summary.fy
+}
+
+// Received returns the total of the gifts received before reversals.
+func (summary ReversalSummary) Received() dec.Decimal {
+	return summary.received
+}
+
+// Reversals returns the number of reversals.
+func (summary ReversalSummary) Reversals() int {
+	return summary.reversals
+}
+
+// Reversed returns the total amount reversed.
+func (summary ReversalSummary) Reversed() dec.Decimal {
+	return summary.reversed
+}
+
+// Kept returns the total of the gifts received less the amount reversed.
+func (summary ReversalSummary) Kept() dec.Decimal {
+	return summary.received.Sub(summary.reversed)
+}
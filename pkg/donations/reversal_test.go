@@ -0,0 +1,71 @@
+// ----------------------------------------------------------------------------
+//
+// Reversed Gift Tests
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package donations
+
+import (
+	"testing"
+
+	a "acorn_go/pkg/accounting"
+	dn "acorn_go/pkg/donors"
+
+	dec "github.com/shopspring/decimal"
+)
+
+// reversal returns a refund of the amount on the date.
+func reversal(t *testing.T, value string, amount int64) dn.Gift {
+	t.Helper()
+	return dn.NewGift(date(t, value), dec.NewFromInt(amount), "Refund", "", 0)
+}
+
+// Test_SummarizeReversals checks that reversals count in the fiscal year of
+// the gift they reverse and that the totals are the money kept.
+func Test_SummarizeReversals(t *testing.T) {
+	dl := make(DonationList)
+	dl["refund"] = amountDonor(t, "refund", map[string]int64{"08/20/2025": 200})
+	dl["refund"].AddGift(reversal(t, "09/15/2025", -200))
+	dl["partial"] = amountDonor(t, "partial", map[string]int64{"10/01/2025": 300})
+	dl["partial"].AddGift(reversal(t, "10/20/2025", -100))
+	dl["unmatched"] = amountDonor(t, "unmatched", map[string]int64{"10/01/2025": 50})
+	dl["unmatched"].AddGift(reversal(t, "11/01/2025", -75))
+	dl["none"] = amountDonor(t, "none", map[string]int64{"10/01/2025": 100})
+
+	var reversed = ReversedGifts(dl)
+	if len(reversed) != 3 || reversed[0].Donor().Key() != "partial" {
+		t.Fatalf("number of reversed gifts %d, want 3 in key order", len(reversed))
+	}
+	if reversed[1].FiscalYear() != a.FY2025 || reversed[1].Amount().IntPart() != 200 {
+		t.Errorf("refund counts in %s for %s, want FY2025 for 200",
+			reversed[1].FiscalYear().String(), reversed[1].Amount().String())
+	}
+	if _, found := reversed[2].Original(); found {
+		t.Error("reversal larger than the gift is netted against it")
+	}
+
+	var summaries = SummarizeReversals(dl)
+	var tests = []struct {
+		fy        a.FYIndicator
+		received  int64
+		reversals int
+		kept      int64
+	}{
+		{a.FY2025, 200, 1, 0},
+		{a.FY2026, 450, 2, 275},
+	}
+	for _, tt := range tests {
+		var summary = summaries[tt.fy]
+		if summary.Received().IntPart() != tt.received || summary.Reversals() != tt.reversals ||
+			summary.Kept().IntPart() != tt.kept {
+			t.Errorf("%s: received %s, reversals %d, kept %s, want %d, %d, %d", tt.fy.String(),
+				summary.Received().String(), summary.Reversals(), summary.Kept().String(),
+				tt.received, tt.reversals, tt.kept)
+		}
+	}
+}
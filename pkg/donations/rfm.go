@@ -0,0 +1,341 @@
+// ----------------------------------------------------------------------------
+//
+// RFM Scoring
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package donations
+
+// RFM scoring rates each donor from 1 to 5 on recency (months since the last
+// gift), frequency (number of gifts), and monetary value (total giving),
+// using the gifts made on or before a date.  By default the scores are
+// quintiles of the donors scored.  ACORN_RFM_SCORING may instead give four
+// breakpoints for each measure, for example
+// "recency:3,6,12,24;frequency:2,3,5,10;monetary:100,250,1000,5000".  A
+// donor scores 5 on recency within the first breakpoint and 5 on frequency
+// or monetary value at or above the last breakpoint.  The segment of a donor
+// comes from the recency score and the average of the frequency and
+// monetary scores.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	dn "acorn_go/pkg/donors"
+	sp "acorn_go/pkg/support"
+	"errors"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	dec "github.com/shopspring/decimal"
+	"github.com/waysys/assert/assert"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// RFMScoring holds the breakpoints of each measure.  A measure without
+// breakpoints is scored by quintile.
+type RFMScoring struct {
+	Recency   []float64
+	Frequency []float64
+	Monetary  []float64
+}
+
+// RFMSegment is a group of donors with similar RFM scores.
+type RFMSegment int
+
+// RFMScore is the RFM score of a donor as of a date.
+type RFMScore struct {
+	donor     *dn.Donor
+	lastGift  d.Date
+	months    int
+	gifts     int
+	total     dec.Decimal
+	recency   int
+	frequency int
+	monetary  int
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// ScoringVariable is the environmental variable with the RFM breakpoints.
+const ScoringVariable = "ACORN_RFM_SCORING"
+
+const NumRFMSegments = 7
+
+const (
+	Champions      = RFMSegment(0) // recent, frequent, and generous
+	Loyal          = RFMSegment(1) // give regularly
+	Promising      = RFMSegment(2) // recent but few or small gifts
+	NeedsAttention = RFMSegment(3) // neither recent nor lapsed, few or small gifts
+	AtRisk         = RFMSegment(4) // frequent or generous but not recent
+	Hibernating    = RFMSegment(5) // not recent with few or small gifts
+	LostDonor      = RFMSegment(6) // least recent with few or small gifts
+)
+
+var RFMSegments = [NumRFMSegments]RFMSegment{
+	Champions,
+	Loyal,
+	Promising,
+	NeedsAttention,
+	AtRisk,
+	Hibernating,
+	LostDonor,
+}
+
+var rfmSegmentNames = [NumRFMSegments]string{
+	"Champions",
+	"Loyal",
+	"Promising",
+	"Needs Attention",
+	"At Risk",
+	"Hibernating",
+	"Lost",
+}
+
+// numScores is the number of scores of each measure.
+const numScores = 5
+
+// ----------------------------------------------------------------------------
+// Factory Functions
+// ----------------------------------------------------------------------------
+
+// ParseRFMScoring returns the scoring for a value of ACORN_RFM_SCORING.
+// "quintile" selects quintiles for every measure.
+func ParseRFMScoring(value string) (RFMScoring, error) {
+	var scoring RFMScoring
+	if strings.EqualFold(strings.TrimSpace(value), "quintile") {
+		return scoring, nil
+	}
+	for _, field := range strings.Split(value, ";") {
+		var name, list, found = strings.Cut(field, ":")
+		if !found {
+			return scoring, errors.New("RFM measure must be a name and breakpoints: " + field)
+		}
+		var breakpoints = []float64{}
+		for _, item := range strings.Split(list, ",") {
+			var breakpoint, err = strconv.ParseFloat(strings.TrimSpace(item), 64)
+			if err != nil {
+				return scoring, errors.New("RFM breakpoint is not a number: " + item)
+			}
+			breakpoints = append(breakpoints, breakpoint)
+		}
+		if len(breakpoints) != numScores-1 || !slices.IsSorted(breakpoints) {
+			return scoring, errors.New("RFM measure must have four increasing breakpoints: " + field)
+		}
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "recency":
+			scoring.Recency = breakpoints
+		case "frequency":
+			scoring.Frequency = breakpoints
+		case "monetary":
+			scoring.Monetary = breakpoints
+		default:
+			return scoring, errors.New("unknown RFM measure: " + name)
+		}
+	}
+	return scoring, nil
+}
+
+// ConfiguredRFMScoring returns the scoring from ACORN_RFM_SCORING, or
+// quintile scoring if it is not defined.
+func ConfiguredRFMScoring() (RFMScoring, error) {
+	var value = strings.TrimSpace(os.Getenv(ScoringVariable))
+	if value == "" {
+		return RFMScoring{}, nil
+	}
+	var scoring, err = ParseRFMScoring(value)
+	if err != nil {
+		err = sp.Wrap(err, sp.Usage, "reading "+ScoringVariable)
+	}
+	return scoring, err
+}
+
+// ComputeRFM scores every donor in the list who made a gift greater than
+// zero on or before the date.  The scores are in the order of the donor
+// keys.
+func ComputeRFM(donationList DonationList, asOf d.Date, scoring RFMScoring) []RFMScore {
+	var scores = []RFMScore{}
+	//
+	// Measure each donor
+	//
+	for _, key := range donationList.DonorKeys() {
+		var score = RFMScore{
+			donor: donationList.Get(key),
+			total: dec.Zero,
+		}
+		var through = score.donor.Through(asOf)
+		for _, gift := range through.Gifts() {
+			if !gift.Amount().GreaterThan(dec.Zero) {
+				continue
+			}
+			score.gifts++
+			score.total = score.total.Add(gift.Amount())
+			score.lastGift = gift.Date()
+		}
+		if score.gifts > 0 {
+			score.months = monthsBetween(score.lastGift, asOf)
+			scores = append(scores, score)
+		}
+	}
+	//
+	// Score each measure.  Fewer months is better, so recency quintiles are
+	// taken of the negative of the months.
+	//
+	var recency = make([]float64, len(scores))
+	var frequency = make([]float64, len(scores))
+	var monetary = make([]float64, len(scores))
+	for index, score := range scores {
+		recency[index] = -float64(score.months)
+		frequency[index] = float64(score.gifts)
+		monetary[index] = score.total.InexactFloat64()
+	}
+	for index := range scores {
+		scores[index].recency = measureScore(recency, recency[index], []float64{})
+		if len(scoring.Recency) > 0 {
+			scores[index].recency = recencyScore(scores[index].months, scoring.Recency)
+		}
+		scores[index].frequency = measureScore(frequency, frequency[index], scoring.Frequency)
+		scores[index].monetary = measureScore(monetary, monetary[index], scoring.Monetary)
+	}
+	return scores
+}
+
+// monthsBetween returns the number of whole calendar months from the first
+// date to the second.
+func monthsBetween(from d.Date, thru d.Date) int {
+	var months = (int(thru.Year())-int(from.Year()))*12 + int(thru.Month()) - int(from.Month())
+	if thru.Day() < from.Day() {
+		months--
+	}
+	return max(months, 0)
+}
+
+// measureScore returns the score from 1 to 5 of a value where a higher
+// value is better.  Without breakpoints, the score is the quintile of the
+// value among all the values, and equal values have the same score.  With
+// breakpoints, the score is one more than the number of breakpoints the value
+// is at or above.
+func measureScore(values []float64, value float64, breakpoints []float64) int {
+	var score = 1
+	if len(breakpoints) == 0 {
+		var below = 0
+		for _, other := range values {
+			if other < value {
+				below++
+			}
+		}
+		score = 1 + below*numScores/len(values)
+	} else {
+		for _, breakpoint := range breakpoints {
+			if value >= breakpoint {
+				score++
+			}
+		}
+	}
+	return min(score, numScores)
+}
+
+// recencyScore returns the score from 1 to 5 of the months since the last
+// gift.  The score is one more than the number of breakpoints the months
+// are at or below.
+func recencyScore(months int, breakpoints []float64) int {
+	var score = 1
+	for _, breakpoint := range breakpoints {
+		if float64(months) <= breakpoint {
+			score++
+		}
+	}
+	return score
+}
+
+// ----------------------------------------------------------------------------
+// Methods
+// ----------------------------------------------------------------------------
+
+// String returns the name of the segment.
+func (segment RFMSegment) String() string {
+	assert.Assert(Champions <= segment && segment < NumRFMSegments, "Invalid RFM segment")
+	return rfmSegmentNames[segment]
+}
+
+// Donor returns the donor.
+func (score RFMScore) Donor() *dn.Donor {
+	return score.donor
+}
+
+// LastGift returns the date of the last gift.
+func (score RFMScore) LastGift() d.Date {
+	return score.lastGift
+}
+
+// Months returns the number of months from the last gift to the date of
+// the scores.
+func (score RFMScore) Months() int {
+	return score.months
+}
+
+// Gifts returns the number of gifts.
+func (score RFMScore) Gifts() int {
+	return score.gifts
+}
+
+// Total returns the total giving.
+func (score RFMScore) Total() dec.Decimal {
+	return score.total
+}
+
+// Recency returns the recency score.
+func (score RFMScore) Recency() int {
+	return score.recency
+}
+
+// Frequency returns the frequency score.
+func (score RFMScore) Frequency() int {
+	return score.frequency
+}
+
+// Monetary returns the monetary score.
+func (score RFMScore) Monetary() int {
+	return score.monetary
+}
+
+// Code returns the three scores as a string such as "545".
+func (score RFMScore) Code() string {
+	return strconv.Itoa(score.recency) + strconv.Itoa(score.frequency) + strconv.Itoa(score.monetary)
+}
+
+// Segment returns the segment of the donor.
+func (score RFMScore) Segment() RFMSegment {
+	var fm = (score.frequency + score.monetary + 1) / 2
+	var segment RFMSegment
+	switch {
+	case score.recency >= 4 && fm >= 4:
+		segment = Champions
+	case score.recency >= 3 && fm >= 3:
+		segment = Loyal
+	case score.recency >= 4:
+		segment = Promising
+	case score.recency == 3:
+		segment = NeedsAttention
+	case fm >= 3:
+		segment = AtRisk
+	case score.recency == 2:
+		segment = Hibernating
+	default:
+		segment = LostDonor
+	}
+	return segment
+}
@@ -0,0 +1,88 @@
+// ----------------------------------------------------------------------------
+//
+// RFM Scoring Tests
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package donations
+
+import (
+	dn "acorn_go/pkg/donors"
+	"testing"
+
+	dec "github.com/shopspring/decimal"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// Test_ComputeRFM_Quintile checks quintile scores and segments.
+func Test_ComputeRFM_Quintile(t *testing.T) {
+	dl := make(DonationList)
+	dl["A"] = amountDonor(t, "A", map[string]int64{"10/01/2023": 500, "10/01/2024": 500, "10/01/2025": 500, "01/15/2026": 1000})
+	dl["B"] = amountDonor(t, "B", map[string]int64{"10/01/2024": 100, "01/01/2026": 100})
+	dl["C"] = amountDonor(t, "C", map[string]int64{"10/01/2023": 2000, "11/01/2023": 2000, "12/01/2023": 2000})
+	dl["D"] = amountDonor(t, "D", map[string]int64{"10/01/2020": 25})
+	dl["E"] = amountDonor(t, "E", map[string]int64{"02/01/2026": 50})
+	dl["F"] = amountDonor(t, "F", map[string]int64{"04/01/2026": 5000})
+	var asOf, _ = d.New(3, 1, 2026)
+
+	scores := ComputeRFM(dl, asOf, RFMScoring{})
+	if len(scores) != 5 {
+		t.Fatalf("expected 5 donors scored, got %d", len(scores))
+	}
+	tests := []struct {
+		key     string
+		code    string
+		segment RFMSegment
+		months  int
+	}{
+		{"A", "454", Champions, 1},
+		{"B", "333", Loyal, 2},
+		{"C", "245", AtRisk, 27},
+		{"D", "111", LostDonor, 65},
+		{"E", "412", Promising, 1},
+	}
+	for index, tt := range tests {
+		score := scores[index]
+		if score.Donor().Key() != tt.key || score.Code() != tt.code || score.Segment() != tt.segment || score.Months() != tt.months {
+			t.Errorf("%s: got %s %s %s %d months, want %s %s %d months", tt.key, score.Donor().Key(),
+				score.Code(), score.Segment().String(), score.Months(), tt.code, tt.segment.String(), tt.months)
+		}
+	}
+}
+
+// Test_ComputeRFM_Breakpoints checks scores with configured breakpoints.
+func Test_ComputeRFM_Breakpoints(t *testing.T) {
+	scoring, err := ParseRFMScoring("recency:3,6,12,24; frequency:2,3,5,10; monetary:100,250,1000,5000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dl := make(DonationList)
+	dl["A"] = amountDonor(t, "A", map[string]int64{"12/01/2025": 100, "01/01/2026": 150})
+	var asOf, _ = d.New(3, 1, 2026)
+
+	scores := ComputeRFM(dl, asOf, scoring)
+	if len(scores) != 1 || scores[0].Code() != "523" || scores[0].Segment() != Loyal {
+		t.Errorf("got %s %s", scores[0].Code(), scores[0].Segment().String())
+	}
+	//
+	// A refund after the date does not change the scores as of the date
+	//
+	var refundDate, _ = d.New(4, 1, 2026)
+	dl["A"].AddGift(dn.NewGift(refundDate, dec.NewFromInt(-150), "Refund", "", 0))
+	scores = ComputeRFM(dl, asOf, scoring)
+	if len(scores) != 1 || scores[0].Code() != "523" {
+		t.Errorf("refund after the date changed the scores: %s", scores[0].Code())
+	}
+	for _, value := range []string{"recency:1,2,3", "recency:4,3,2,1", "speed:1,2,3,4", "recency", "monetary:a,b,c,d"} {
+		if _, err = ParseRFMScoring(value); err == nil {
+			t.Errorf("scoring %q was accepted", value)
+		}
+	}
+	if scoring, err = ParseRFMScoring("Quintile"); err != nil || len(scoring.Recency) != 0 {
+		t.Error("quintile scoring was not accepted")
+	}
+}
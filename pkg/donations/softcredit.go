@@ -0,0 +1,68 @@
+// ----------------------------------------------------------------------------
+//
+// Soft Credits
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package donations
+
+// This file links the gifts in the donation list to the donors named in the
+// soft credit spreadsheet.  The soft credits are added before the payees
+// are matched to donors, since the spreadsheet names the Quickbooks payee.
+// The donation list keeps the hard credit with the payee.  BySoftCredit
+// returns the list that counts the gifts for the donors with soft credit.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	dn "acorn_go/pkg/donors"
+	"acorn_go/pkg/spreadsheet"
+	"errors"
+)
+
+// ----------------------------------------------------------------------------
+// Methods
+// ----------------------------------------------------------------------------
+
+// AddSoftCredits gives soft credit for the gifts in the rows of a soft
+// credit spreadsheet and returns the number of gifts credited.  The payee
+// of each gift must be in the list.
+func (donationList DonationList) AddSoftCredits(sprdsht *spreadsheet.Spreadsheet) (int, error) {
+	var numRows = sprdsht.Size()
+	for row := 1; row < numRows; row++ {
+		var credit, err = dn.ReadSoftCredit(sprdsht, row)
+		if err == nil && !donationList.Contains(credit.Payee()) {
+			err = errors.New("payee not found in donation list: " + credit.Payee())
+		}
+		if err == nil {
+			err = donationList.Get(credit.Payee()).ApplySoftCredit(credit)
+		}
+		if err != nil {
+			return row - 1, sprdsht.WrapRow(err, "processing soft credit", row)
+		}
+	}
+	return max(numRows-1, 0), nil
+}
+
+// ReadSoftCredits gives soft credit for the gifts in a soft credit
+// spreadsheet and returns the number of gifts credited.
+func (donationList DonationList) ReadSoftCredits(fileName string, tab string) (int, error) {
+	var sprdsht, err = spreadsheet.ProcessData(fileName, tab)
+	if err != nil {
+		return 0, err
+	}
+	return donationList.AddSoftCredits(&sprdsht)
+}
+
+// BySoftCredit returns a donation list in which the gifts that give soft
+// credit are counted for the donors with the soft credit rather than their
+// payees.
+func (donationList DonationList) BySoftCredit() DonationList {
+	return DonationList(dn.BySoftCredit(dn.DonorList(donationList)))
+}
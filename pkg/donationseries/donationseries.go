@@ -17,6 +17,7 @@ package donationseries
 // ----------------------------------------------------------------------------
 
 import (
+	"acorn_go/pkg/rules"
 	"acorn_go/pkg/spreadsheet"
 	"strings"
 
@@ -36,15 +37,9 @@ type DonationSeries map[d.YearMonth]*DonationInfo
 // ----------------------------------------------------------------------------
 
 const (
-	columnNameDonor       = "Payee"
-	columnTransactionType = "Type"
-	columnDate            = "Date"
-	columnPayment         = "Payment"
-	columnPayee           = "Payee"
-)
-
-const (
-	unusualDonor = "Nadine L. Tolman Trust"
+	columnNameDonor = "Payee"
+	columnDate      = "Date"
+	columnPayment   = "Payment"
 )
 
 // ----------------------------------------------------------------------------
@@ -54,42 +49,31 @@ const (
 // NewDonationSeries creates a donation series from the donation spreadsheet
 func NewDonationSeries(sprdsht *spreadsheet.Spreadsheet) (DonationSeries, error) {
 	var donationSeries = make(DonationSeries)
-	var err error = nil
 	var numRows = sprdsht.Size()
-	var value string
-	var donor string
+	var selected bool
+	//
+	// Obtain the rules that select donations
+	//
+	var ruleSet, err = rules.Active()
+	if err != nil {
+		return donationSeries, err
+	}
 	//
 	// Loop through all rows in the spreadsheet except row 0 which has
 	// the column headings
 	//
 	for row := 1; row < numRows; row++ {
-		value, err = sprdsht.Cell(row, columnTransactionType)
-		if err != nil {
-			return donationSeries, err
+		selected, err = ruleSet.Select(sprdsht, row)
+		if err == nil && selected {
+			err = processSeries(&donationSeries, sprdsht, row)
 		}
-
-		donor, err = sprdsht.Cell(row, columnPayee)
 		if err != nil {
-			return donationSeries, err
-		}
-
-		if selectRow(value, donor) {
-			err = processSeries(&donationSeries, sprdsht, row)
-			if err != nil {
-				return donationSeries, err
-			}
+			return donationSeries, sprdsht.WrapRow(err, "processing donation", row)
 		}
 	}
 	return donationSeries, err
 }
 
-// selectRow determines if a row will be processed
-func selectRow(value string, donor string) bool {
-	var result = value == columnPayment
-	result = result && donor != unusualDonor
-	return result
-}
-
 // processSeries processes row in the spreadsheet and adds the data
 // to the donation series.
 func processSeries(dsPtr *DonationSeries, sprdsht *spreadsheet.Spreadsheet, row int) error {
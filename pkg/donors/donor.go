@@ -20,6 +20,8 @@ package donors
 import (
 	ac "acorn_go/pkg/accounting"
 	a "acorn_go/pkg/address"
+	r "acorn_go/pkg/redact"
+	"slices"
 	s "strings"
 
 	dec "github.com/shopspring/decimal"
@@ -31,16 +33,18 @@ import (
 // Types
 // ----------------------------------------------------------------------------
 
+// Donor holds a donor's name and address and the ledger of the donor's
+// gifts.  The donation totals are computed from the ledger.
 type Donor struct {
-	key                   string
-	name                  string
-	address               a.Address
-	email                 string
-	numberHousehold       int
-	donations             []dec.Decimal
-	donationsCalendarYear []dec.Decimal
-	donationsCurrentMonth dec.Decimal
-	deceased              bool
+	key             string
+	name            string
+	address         a.Address
+	email           string
+	numberHousehold int
+	gifts           []Gift
+	reversals       []Gift
+	inKind          []InKindGift
+	deceased        bool
 }
 
 // ----------------------------------------------------------------------------
@@ -56,24 +60,16 @@ var MajorDonorLimit = dec.NewFromInt(1000)
 
 // New creates a new donor
 func New(ky string, nm string, adr a.Address, eml string, count int, decsd bool) Donor {
-	var dn1 []dec.Decimal
-	var dn2 []dec.Decimal
-	var numYears = ac.MaxYear - ac.MinYear + 1
-	for index := 0; index < numYears; index++ {
-		dn1 = append(dn1, ZERO)
-		dn2 = append(dn2, ZERO)
-	}
-
 	donor := Donor{
-		key:                   ky,
-		name:                  nm,
-		address:               adr,
-		email:                 eml,
-		numberHousehold:       count,
-		donations:             dn1,
-		donationsCalendarYear: dn2,
-		donationsCurrentMonth: ZERO,
-		deceased:              decsd,
+		key:             ky,
+		name:            nm,
+		address:         adr,
+		email:           eml,
+		numberHousehold: count,
+		gifts:           []Gift{},
+		reversals:       []Gift{},
+		inKind:          []InKindGift{},
+		deceased:        decsd,
 	}
 	return donor
 }
@@ -94,9 +90,14 @@ func (donor Donor) Key() string {
 	return donor.key
 }
 
-// Name returns the full names of the donors
+// Name returns the full names of the donors.  If redaction is enabled,
+// the pseudonym of the donor is returned.
 func (donor Donor) Name() string {
-	return donor.name
+	var name = donor.name
+	if r.Enabled() {
+		name = r.Name(r.Donor, donor.key)
+	}
+	return name
 }
 
 // Street returns the street address of the donor
@@ -105,6 +106,7 @@ func (donor Donor) Street() string {
 	street = s.Replace(street, "DriveUnit", "Drive, Unit", 1)
 	street = s.Replace(street, "\n", ", ", 1)
 	street = s.Replace(street, "DrUnit", "Drive, Unit", 1)
+	street = r.Street(r.Donor, donor.key, street)
 	return street
 }
 
@@ -120,24 +122,30 @@ func (donor Donor) State() string {
 
 // Zip returns the zip code of the donor
 func (donor Donor) Zip() string {
-	return donor.address.Zip
+	return r.Zip(donor.address.Zip)
 }
 
 // Address returns the address structure associated with this
 // donor
 func (donor Donor) Address() a.Address {
-	return donor.address
+	var address = donor.address
+	if r.Enabled() {
+		address.Street = r.Street(r.Donor, donor.key, address.Street)
+		address.Unit = ""
+		address.Zip = r.Zip(address.Zip)
+	}
+	return address
 }
 
 // Email returns the email address of the donor
 func (donor Donor) Email() string {
-	return donor.email
+	return r.Email(r.Donor, donor.key, donor.email)
 }
 
 // HasEmail returns true if the donor has an email address.
 func (donor Donor) HasEmail() bool {
 	var result = true
-	var email = donor.Email()
+	var email = donor.email
 	if s.TrimSpace(email) == "--" {
 		result = false
 	}
@@ -162,7 +170,25 @@ func (donor Donor) Deceased() bool {
 // Donation returns the donation for the specified fiscal year
 func (donor Donor) Donation(fy ac.FYIndicator) dec.Decimal {
 	assert.Assert(ac.IsFYIndicator(fy), "Invalid FYIndicator: "+fy.String())
-	var amount = donor.donations[fy]
+	var amount = ZERO
+	for _, gift := range donor.gifts {
+		if ac.FiscalYearIndicator(gift.date) == fy {
+			amount = amount.Add(gift.Amount())
+		}
+	}
+	return amount
+}
+
+// NetDonation returns the donation for the specified fiscal year less the
+// processing fees of the gifts.
+func (donor Donor) NetDonation(fy ac.FYIndicator) dec.Decimal {
+	assert.Assert(ac.IsFYIndicator(fy), "Invalid FYIndicator: "+fy.String())
+	var amount = ZERO
+	for _, gift := range donor.gifts {
+		if ac.FiscalYearIndicator(gift.date) == fy {
+			amount = amount.Add(gift.Net())
+		}
+	}
 	return amount
 }
 
@@ -217,10 +243,11 @@ func (donor Donor) IsNonRepeatDonor(fy ac.FYIndicator) bool {
 // Donation Properties - Setters - Fiscal Year
 // ----------------------------------------------------------------------------
 
-// AddDonation adds the amount to the donations for the specified fical year.
-func (donor Donor) AddDonation(amount dec.Decimal, fy ac.FYIndicator) {
+// AddDonation adds a gift of the amount on the first day of the specified
+// fiscal year.  It is used when only the fiscal year of a gift is known.
+func (donor *Donor) AddDonation(amount dec.Decimal, fy ac.FYIndicator) {
 	assert.Assert(ac.IsFYIndicator(fy), "Invalid FYIndicator: "+fy.String())
-	donor.donations[fy] = donor.donations[fy].Add(amount)
+	donor.AddGift(NewGift(fy.Begin(), amount, "", "", 0))
 }
 
 // ----------------------------------------------------------------------------
@@ -230,7 +257,12 @@ func (donor Donor) AddDonation(amount dec.Decimal, fy ac.FYIndicator) {
 // CalDonation returns the donations for a donor for the specified calendar year.
 func (donor Donor) CalDonation(year ac.YearIndicator) dec.Decimal {
 	assert.Assert(ac.IsYearIndicator(year), "Invalid year indicator: "+year.String())
-	var amount = donor.donationsCalendarYear[year]
+	var amount = ZERO
+	for _, gift := range donor.gifts {
+		if ac.YIndicator(gift.date) == year {
+			amount = amount.Add(gift.Amount())
+		}
+	}
 	return amount
 }
 
@@ -242,16 +274,6 @@ func (donor Donor) IsCalDonor(year ac.YearIndicator) bool {
 	return result
 }
 
-// ----------------------------------------------------------------------------
-// Donation Method - Setters - Calendar Year
-// ----------------------------------------------------------------------------
-
-// AddCalDonation adds an amount to the calendar donations for the specified year.
-func (donor *Donor) AddCalDonation(amount dec.Decimal, year ac.YearIndicator) {
-	assert.Assert(ac.IsYearIndicator(year), "Invalid year indicator: "+year.String())
-	donor.donationsCalendarYear[year] = donor.donationsCalendarYear[year].Add(amount)
-}
-
 // ----------------------------------------------------------------------------
 // Donation Properties - Current Month
 // ----------------------------------------------------------------------------
@@ -266,17 +288,186 @@ func (donor Donor) IsCurrentDonor() bool {
 // CurrentMonthDonation returns the total amount of current month donations
 // for this donor.
 func (donor Donor) CurrentMonthDonation() dec.Decimal {
-	return donor.donationsCurrentMonth
+	var amount = ZERO
+	for _, gift := range donor.gifts {
+		if ac.IsCurrentMonth(gift.date) {
+			amount = amount.Add(gift.Amount())
+		}
+	}
+	return amount
 }
 
 // ----------------------------------------------------------------------------
-// Donation Setters - Current Month
+// Gift Ledger
 // ----------------------------------------------------------------------------
 
-// AddMonthDonation adds the amount of the donation if the payment date
-// is in the current month date range.
-func (donor *Donor) AddMonthDonation(amount dec.Decimal, date d.Date) {
-	if ac.IsCurrentMonth(date) {
-		donor.donationsCurrentMonth = donor.donationsCurrentMonth.Add(amount)
+// AddGift adds a gift to the ledger.  The ledger is kept in date order, and
+// gifts on the same date are kept in the order they were added.  A reversal
+// is netted against the gift it reverses.  If it matches no gift, it is kept
+// in the ledger as a negative entry so that the totals are still net.
+func (donor *Donor) AddGift(gift Gift) {
+	if gift.IsReversal() {
+		if gift.reverses == nil {
+			donor.reverse(&gift)
+		}
+		donor.reversals = append(donor.reversals, gift)
+		if gift.reverses != nil {
+			return
+		}
+	}
+	donor.insert(gift)
+}
+
+// insert places a gift in the ledger in date order.
+func (donor *Donor) insert(gift Gift) {
+	var index = len(donor.gifts)
+	for index > 0 && donor.gifts[index-1].date.After(gift.date) {
+		index--
+	}
+	donor.gifts = slices.Insert(donor.gifts, index, gift)
+}
+
+// reverse nets a reversal against the most recent gift made on or before
+// its date that has enough left to cover it, preferring a gift of the same
+// amount.  The reversal records the gift it was netted against.
+func (donor *Donor) reverse(reversal *Gift) {
+	var amount = reversal.amount.Neg()
+	var match = -1
+	for index := len(donor.gifts) - 1; index >= 0; index-- {
+		var gift = donor.gifts[index]
+		if gift.date.After(reversal.date) || gift.IsReversal() || gift.Amount().LessThan(amount) {
+			continue
+		}
+		if gift.amount.Equal(amount) {
+			match = index
+			break
+		}
+		if match < 0 {
+			match = index
+		}
+	}
+	if match >= 0 {
+		var original = &donor.gifts[match]
+		original.reversed = original.reversed.Add(amount)
+		var snapshot = *original
+		reversal.reverses = &snapshot
+	}
+}
+
+// Merge adds the gifts, reversals, and in-kind gifts of another donor.
+// Reversals already netted against a gift are not netted again.
+func (donor *Donor) Merge(other *Donor) {
+	for _, gift := range other.gifts {
+		donor.AddGift(gift)
+	}
+	for _, reversal := range other.reversals {
+		if reversal.reverses != nil {
+			donor.AddGift(reversal)
+		}
+	}
+	for _, gift := range other.inKind {
+		donor.AddInKind(gift)
+	}
+}
+
+// Reversals returns the reversals of the donor's gifts in the order they
+// were added, including those that matched no gift.
+func (donor Donor) Reversals() []Gift {
+	return slices.Clone(donor.reversals)
+}
+
+// Gifts returns a copy of the ledger in date order.
+func (donor Donor) Gifts() []Gift {
+	return slices.Clone(donor.gifts)
+}
+
+// GiftCount returns the number of gifts in the ledger.
+func (donor Donor) GiftCount() int {
+	return len(donor.gifts)
+}
+
+// FirstGift returns the earliest gift.  The second value is false if the
+// donor has made no gifts.
+func (donor Donor) FirstGift() (Gift, bool) {
+	var gift Gift
+	var found = len(donor.gifts) > 0
+	if found {
+		gift = donor.gifts[0]
+	}
+	return gift, found
+}
+
+// LastGift returns the most recent gift.  The second value is false if the
+// donor has made no gifts.
+func (donor Donor) LastGift() (Gift, bool) {
+	var gift Gift
+	var found = len(donor.gifts) > 0
+	if found {
+		gift = donor.gifts[len(donor.gifts)-1]
+	}
+	return gift, found
+}
+
+// LargestGift returns the gift with the largest amount.  If there is a tie,
+// the earliest gift is returned.  The second value is false if the donor has
+// made no gifts.
+func (donor Donor) LargestGift() (Gift, bool) {
+	var gift Gift
+	var found = false
+	for _, candidate := range donor.gifts {
+		if !found || candidate.Amount().GreaterThan(gift.Amount()) {
+			gift = candidate
+			found = true
+		}
+	}
+	return gift, found
+}
+
+// GiftsBetween returns the gifts made on or between the dates in date
+// order.
+func (donor Donor) GiftsBetween(from d.Date, thru d.Date) []Gift {
+	var gifts = []Gift{}
+	for _, gift := range donor.gifts {
+		if gift.InRange(from, thru) {
+			gifts = append(gifts, gift)
+		}
+	}
+	return gifts
+}
+
+// TotalBetween returns the total of the gifts made on or between the dates.
+func (donor Donor) TotalBetween(from d.Date, thru d.Date) dec.Decimal {
+	var amount = ZERO
+	for _, gift := range donor.GiftsBetween(from, thru) {
+		amount = amount.Add(gift.Amount())
+	}
+	return amount
+}
+
+// Through returns a copy of the donor with the gifts, reversals, and in-kind
+// gifts made on or before the date.  Reversals after the date are not
+// netted against the gifts.
+func (donor Donor) Through(date d.Date) Donor {
+	var result = donor
+	result.gifts = []Gift{}
+	result.reversals = []Gift{}
+	result.inKind = []InKindGift{}
+	for _, gift := range donor.gifts {
+		if !gift.IsReversal() && !gift.date.After(date) {
+			gift.reversed = ZERO
+			result.insert(gift)
+		}
 	}
+	for _, reversal := range donor.reversals {
+		if !reversal.date.After(date) {
+			reversal.reverses = nil
+			result.AddGift(reversal)
+		}
+	}
+	for _, gift := range donor.inKind {
+		if !gift.date.After(date) {
+			result.inKind = append(result.inKind, gift)
+		}
+	}
+	return result
 }
@@ -16,16 +16,17 @@ package donors
 // ----------------------------------------------------------------------------
 
 import (
-	ac "acorn_go/pkg/accounting"
 	a "acorn_go/pkg/address"
+	"acorn_go/pkg/identity"
+	l "acorn_go/pkg/logging"
+	"acorn_go/pkg/rules"
 	"acorn_go/pkg/spreadsheet"
 	"errors"
 	"sort"
 	"strconv"
+	"strings"
 
-	dec "github.com/shopspring/decimal"
 	"github.com/waysys/assert/assert"
-	d "github.com/waysys/waydate/pkg/date"
 )
 
 // This file contains functions that manage a map of donors with their names,
@@ -56,11 +57,6 @@ const (
 	columnPayment         = "Payment"
 )
 
-const (
-	payment       = "Payment"
-	excludedDonor = "Nadine L. Tolman Trust"
-)
-
 // ----------------------------------------------------------------------------
 // Types
 // ----------------------------------------------------------------------------
@@ -83,7 +79,7 @@ func NewDonorAddressList(sprdsht *spreadsheet.Spreadsheet) (DonorList, error) {
 	for row := 1; row < numRows; row++ {
 		err = processDonor(&donorList, sprdsht, row)
 		if err != nil {
-			return donorList, err
+			return donorList, sprdsht.WrapRow(err, "processing donor", row)
 		}
 	}
 	return donorList, err
@@ -161,77 +157,52 @@ func processAddress(sprdsht *spreadsheet.Spreadsheet, row int) (a.Address, error
 	return addr, err
 }
 
-// AddDonations adds donation information to donor list
-func AddDonations(sprdsht *spreadsheet.Spreadsheet, donarList *DonorList) error {
+// AddDonations adds donation information to donor list.  The resolver
+// finds the donor of a payee whose name differs from the donor list.
+func AddDonations(sprdsht *spreadsheet.Spreadsheet, donarList *DonorList, resolver *identity.Resolver) error {
 	var numRows = sprdsht.Size()
-	var err error
-
-	for row := 1; row < numRows; row++ {
-		err = processDonation(sprdsht, donarList, row)
-	}
-	return err
-}
-
-// processDonation processes a row in the donation spreadsheet
-func processDonation(sprdsht *spreadsheet.Spreadsheet, donorList *DonorList, row int) error {
-	var err error = nil
-	var value = ""
-	var dateDonation d.Date
-	var amountDonation dec.Decimal
-
-	//
-	// Obtain donor name
-	//
-	value, err = sprdsht.Cell(row, columnNameDonor)
-	if err != nil {
-		return err
-	}
-	var nameDonor = value
+	var selected bool
 	//
-	// Obtain transaction type
+	// Obtain the rules that select donations
 	//
-	value, err = sprdsht.Cell(row, columnTransactionType)
+	var ruleSet, err = rules.Active()
 	if err != nil {
 		return err
 	}
-	var transType = value
-	//
-	// Obtain date of donation
-	//
-	value, err = sprdsht.Cell(row, columnDate)
-	if err != nil {
-		return err
-	}
-	dateDonation, err = d.NewFromString(value)
-	if err != nil {
-		return err
-	}
-	//
-	// Obtain amount of donation
-	//
-	amountDonation, err = sprdsht.CellDecimal(row, columnPayment)
-	if err != nil {
-		return err
-	}
-	//
-	// Add contribution to the donor for the right calendar year
-	//
-	if donorList.Contains(nameDonor) {
-		var donor = donorList.Get(nameDonor)
-		if selectDonation(transType, nameDonor) {
-			donor.AddCalDonation(amountDonation, ac.YIndicator(dateDonation))
 
+	for row := 1; row < numRows; row++ {
+		selected, err = ruleSet.Select(sprdsht, row)
+		if err == nil && selected {
+			err = processDonation(sprdsht, donarList, resolver, row)
+		}
+		if err != nil {
+			return sprdsht.WrapRow(err, "processing donation", row)
 		}
 	}
 	return err
 }
 
-// selectDonation returns true if the donation should be added to the
-// donor.
-func selectDonation(transType string, nameDonor string) bool {
-	var result = transType == payment
-	result = result && (nameDonor != excludedDonor)
-	return result
+// processDonation adds the gift in a selected row of the donation
+// spreadsheet to the donor's ledger.  A payee that matches no donor is
+// added to the list with its name only, so its gifts are not lost.
+func processDonation(
+	sprdsht *spreadsheet.Spreadsheet,
+	donorList *DonorList,
+	resolver *identity.Resolver,
+	row int) error {
+	var nameDonor, gift, err = ReadGift(sprdsht, row)
+	if err != nil {
+		return err
+	}
+	var key = resolver.KeyOf(nameDonor)
+	if !donorList.Contains(key) {
+		l.Warn("payee is not in the donor address list",
+			"payee", nameDonor, l.File(sprdsht.FileName()), l.Row(row+1))
+		var donor = NewDonorWithDonation(key)
+		donorList.Add(&donor)
+	}
+	donorList.Get(key).AddGift(gift)
+	return err
 }
 
 // ----------------------------------------------------------------------------
@@ -274,16 +245,18 @@ func (donorList *DonorList) Keys() []string {
 	return keys
 }
 
-// GetByEmail returns the donor with the specified email.
+// GetByEmail returns the donor with the specified email.  Emails are
+// compared ignoring case and surrounding spaces.
 // If no donor has the specified email, an error is returned.
 func (donorList *DonorList) GetByEmail(email string) (*Donor, error) {
 	var donor *Donor = nil
 	var err error = errors.New("unable to find donor with this email: " + email)
 	var keys = donorList.Keys()
+	var target = strings.TrimSpace(email)
 
 	for _, key := range keys {
 		donor = donorList.Get(key)
-		if donor.Email() == email {
+		if target != "" && strings.EqualFold(strings.TrimSpace(donor.email), target) {
 			err = nil
 			break
 		}
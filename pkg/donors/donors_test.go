@@ -16,10 +16,17 @@ package donors
 // ----------------------------------------------------------------------------
 
 import (
+	ac "acorn_go/pkg/accounting"
+	a "acorn_go/pkg/address"
+	r "acorn_go/pkg/redact"
 	"acorn_go/pkg/spreadsheet"
 	"os"
 	"strconv"
+	"strings"
 	"testing"
+
+	dec "github.com/shopspring/decimal"
+	d "github.com/waysys/waydate/pkg/date"
 )
 
 // ----------------------------------------------------------------------------
@@ -83,3 +90,361 @@ func Test_NewDonorList(t *testing.T) {
 
 	t.Run("Test_New", testFunction)
 }
+
+// Test_RedactedDonor checks that the contact information of a donor is
+// replaced by a pseudonym when redaction is enabled.
+func Test_RedactedDonor(t *testing.T) {
+	var address = a.New("1 Oak St", "", "Cary", "NC", "27513")
+	var donor = New("Apple, Julietta", "Julietta Apple", address, "ja@mail.com", 1, false)
+
+	r.Enable("secret")
+	defer r.Disable()
+
+	var pseudonym = r.Name(r.Donor, "Apple, Julietta")
+	if donor.Name() != pseudonym {
+		t.Error("donor name is not the pseudonym: " + donor.Name())
+	}
+	if donor.Email() == "ja@mail.com" {
+		t.Error("donor email is not redacted")
+	}
+	if donor.Street() == "1 Oak St" || donor.Address().Street == "1 Oak St" {
+		t.Error("donor street is not redacted")
+	}
+	if donor.Zip() != "275XX" {
+		t.Error("donor zip is not coarsened: " + donor.Zip())
+	}
+	if donor.City() != "Cary" {
+		t.Error("donor city should not be redacted: " + donor.City())
+	}
+	if donor.Key() != "Apple, Julietta" {
+		t.Error("donor key should not be redacted: " + donor.Key())
+	}
+}
+
+// Test_GiftLedger checks that the gift ledger is kept in date order and that
+// the donation totals are computed from it.
+func Test_GiftLedger(t *testing.T) {
+	var donor = NewDonorWithDonation("Apple, Julietta")
+	var date = func(value string) d.Date {
+		var result, err = d.NewFromString(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+	donor.AddGift(NewGift(date("03/15/2025"), dec.NewFromInt(500), "Payment", "donations.xlsx", 12))
+	donor.AddGift(NewGift(date("10/01/2024"), dec.NewFromInt(100), "Payment", "donations.xlsx", 4))
+	donor.AddGift(NewGift(date("12/31/2025"), dec.NewFromInt(250), "Payment", "donations.xlsx", 30))
+	donor.AddGift(NewGift(date("12/31/2025"), dec.NewFromInt(500), "Payment", "donations.xlsx", 31))
+
+	if donor.GiftCount() != 4 {
+		t.Error("incorrect gift count: " + strconv.Itoa(donor.GiftCount()))
+	}
+	var first, found = donor.FirstGift()
+	if !found || first.Row() != 4 {
+		t.Error("incorrect first gift: " + strconv.Itoa(first.Row()))
+	}
+	var last, _ = donor.LastGift()
+	if last.Row() != 31 || last.File() != "donations.xlsx" || last.Type() != "Payment" {
+		t.Error("incorrect last gift: " + strconv.Itoa(last.Row()))
+	}
+	var largest, _ = donor.LargestGift()
+	if largest.Row() != 12 {
+		t.Error("largest gift is not the earliest of the largest gifts: " + strconv.Itoa(largest.Row()))
+	}
+	if len(donor.GiftsBetween(date("01/01/2025"), date("12/31/2025"))) != 3 {
+		t.Error("incorrect number of gifts in 2025")
+	}
+	if !donor.TotalBetween(date("10/01/2024"), date("03/15/2025")).Equal(dec.NewFromInt(600)) {
+		t.Error("incorrect total between dates: " + donor.TotalBetween(date("10/01/2024"), date("03/15/2025")).String())
+	}
+	if !donor.Donation(ac.FY2025).Equal(dec.NewFromInt(600)) || !donor.Donation(ac.FY2026).Equal(dec.NewFromInt(750)) {
+		t.Error("fiscal year totals do not match the ledger")
+	}
+	if !donor.CalDonation(ac.Y2025).Equal(dec.NewFromInt(1250)) {
+		t.Error("calendar year total does not match the ledger: " + donor.CalDonation(ac.Y2025).String())
+	}
+	var empty = NewDonorWithDonation("Baker, Sam")
+	if _, found = empty.FirstGift(); found {
+		t.Error("donor without gifts has a first gift")
+	}
+}
+
+// Test_Reversal checks that refunds and chargebacks net against the gifts
+// they reverse and that merging ledgers does not net them again.
+func Test_Reversal(t *testing.T) {
+	var donor = NewDonorWithDonation("Apple, Julietta")
+	var date = func(value string) d.Date {
+		var result, err = d.NewFromString(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+	donor.AddGift(NewGift(date("08/01/2025"), dec.NewFromInt(300), "Payment", "donations.xlsx", 2))
+	donor.AddGift(NewGift(date("08/20/2025"), dec.NewFromInt(200), "Payment", "donations.xlsx", 3))
+	donor.AddGift(NewGift(date("09/05/2025"), dec.NewFromInt(300), "Payment", "donations.xlsx", 4))
+	donor.AddGift(NewGift(date("09/15/2025"), dec.NewFromInt(-200), "Refund", "donations.xlsx", 5))
+	donor.AddGift(NewGift(date("10/01/2025"), dec.NewFromInt(-50), "Refund", "donations.xlsx", 6))
+	donor.AddGift(NewGift(date("10/02/2025"), dec.NewFromInt(-500), "Chargeback", "donations.xlsx", 7))
+
+	var reversals = donor.Reversals()
+	if len(reversals) != 3 || donor.GiftCount() != 4 {
+		t.Fatal("incorrect reversals and gifts: " + strconv.Itoa(len(reversals)) + ", " + strconv.Itoa(donor.GiftCount()))
+	}
+	var original, found = reversals[0].Reverses()
+	if !found || original.Row() != 3 || !original.Amount().IsZero() || !original.Reversed().Equal(dec.NewFromInt(200)) {
+		t.Error("refund is not netted against the gift of the same amount: " + strconv.Itoa(original.Row()))
+	}
+	original, found = reversals[1].Reverses()
+	if !found || original.Row() != 4 || !original.Amount().Equal(dec.NewFromInt(250)) {
+		t.Error("partial refund is not netted against the most recent gift: " + strconv.Itoa(original.Row()))
+	}
+	if _, found = reversals[2].Reverses(); found || !reversals[2].IsReversal() {
+		t.Error("chargeback larger than any gift is netted against a gift")
+	}
+	if !donor.Donation(ac.FY2025).Equal(dec.NewFromInt(300)) || !donor.Donation(ac.FY2026).Equal(dec.NewFromInt(-250)) {
+		t.Error("fiscal year totals are not net of reversals: " + donor.Donation(ac.FY2025).String() + ", " +
+			donor.Donation(ac.FY2026).String())
+	}
+	var merged = NewDonorWithDonation("Apple")
+	merged.Merge(&donor)
+	if len(merged.Reversals()) != 3 || merged.GiftCount() != 4 || !merged.TotalDonation().Equal(donor.TotalDonation()) {
+		t.Error("merged ledger does not match: " + merged.TotalDonation().String())
+	}
+}
+
+// Test_Through checks that the gifts and reversals after the date are left
+// out and that a later refund is not netted against an earlier gift.
+func Test_Through(t *testing.T) {
+	var donor = NewDonorWithDonation("Apple, Julietta")
+	var date = func(value string) d.Date {
+		var result, err = d.NewFromString(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+	donor.AddGift(NewGift(date("09/10/2025"), dec.NewFromInt(300), "Payment", "donations.xlsx", 2))
+	donor.AddGift(NewGift(date("09/20/2025"), dec.NewFromInt(-100), "Refund", "donations.xlsx", 3))
+	donor.AddGift(NewGift(date("10/05/2025"), dec.NewFromInt(200), "Payment", "donations.xlsx", 4))
+	donor.AddGift(NewGift(date("10/20/2025"), dec.NewFromInt(-300), "Refund", "donations.xlsx", 5))
+
+	var tests = []struct {
+		date      string
+		gifts     int
+		reversals int
+		donation  int64
+	}{
+		{"09/15/2025", 1, 0, 300},
+		{"09/30/2025", 1, 1, 200},
+		{"10/31/2025", 3, 2, 100},
+	}
+	for _, tt := range tests {
+		var through = donor.Through(date(tt.date))
+		if through.GiftCount() != tt.gifts || len(through.Reversals()) != tt.reversals ||
+			!through.Donation(ac.FY2026).Equal(dec.NewFromInt(tt.donation)) {
+			t.Errorf("%s: got %d gifts, %d reversals, %s", tt.date, through.GiftCount(), len(through.Reversals()),
+				through.Donation(ac.FY2026).String())
+		}
+	}
+	if !donor.Donation(ac.FY2026).Equal(dec.NewFromInt(100)) || len(donor.Reversals()) != 2 {
+		t.Error("donor was changed by Through: " + donor.Donation(ac.FY2026).String())
+	}
+}
+
+// Test_InKind checks that in-kind gifts are kept apart from the cash totals
+// and counted in recognition, lifetime giving, and a merge.
+func Test_InKind(t *testing.T) {
+	var donor = NewDonorWithDonation("Apple, Julietta")
+	var date = func(value string) d.Date {
+		var result, err = d.NewFromString(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+	var inKind = func(value string, description string, amount int64) InKindGift {
+		var gift, err = NewInKindGift(date(value), description, dec.NewFromInt(amount), "Appraisal", "inkind.xlsx", 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return gift
+	}
+	donor.AddGift(NewGift(date("10/01/2025"), dec.NewFromInt(500), "Payment", "donations.xlsx", 2))
+	donor.AddInKind(inKind("12/15/2025", "100 shares of common stock", 4000))
+	donor.AddInKind(inKind("04/10/2025", "catering for the spring celebration", 750))
+
+	if !donor.Donation(ac.FY2026).Equal(dec.NewFromInt(500)) || !donor.InKindDonation(ac.FY2026).Equal(dec.NewFromInt(4000)) ||
+		!donor.Recognition(ac.FY2026).Equal(dec.NewFromInt(4500)) {
+		t.Error("in-kind gifts are not kept apart from cash: " + donor.Recognition(ac.FY2026).String())
+	}
+	var gifts = donor.InKindGifts()
+	if len(gifts) != 2 || gifts[0].Description() != "catering for the spring celebration" {
+		t.Error("in-kind gifts are not in date order")
+	}
+	if len(donor.CalInKindGifts(ac.Y2025)) != 2 || !donor.LifetimeGiving().Equal(dec.NewFromInt(5250)) {
+		t.Error("incorrect calendar year gifts or lifetime giving: " + donor.LifetimeGiving().String())
+	}
+	var acknowledgment = gifts[1].Acknowledgment()
+	if !strings.Contains(acknowledgment, "100 shares of common stock") || strings.Contains(acknowledgment, "4000") {
+		t.Error("acknowledgment must describe the gift without its value: " + acknowledgment)
+	}
+	var household = NewDonorWithDonation("Apple household")
+	household.Merge(&donor)
+	if !household.LifetimeInKind().Equal(dec.NewFromInt(4750)) {
+		t.Error("merge did not add the in-kind gifts")
+	}
+	if _, err := NewInKindGift(date("12/15/2025"), "stock", dec.Zero, "Appraisal", "", 0); err == nil {
+		t.Error("in-kind gift without a value was accepted")
+	}
+	if _, err := NewInKindGift(date("12/15/2025"), "stock", dec.NewFromInt(10), " ", "", 0); err == nil {
+		t.Error("in-kind gift without a valuation method was accepted")
+	}
+}
+
+// Test_SoftCredit checks that a matching gift keeps its hard credit with the
+// employer and moves to the donor with soft credit in the soft credit list.
+func Test_SoftCredit(t *testing.T) {
+	var date = func(value string) d.Date {
+		var result, err = d.NewFromString(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+	var employer = NewDonorWithDonation("Acme Foundation")
+	var donor = NewDonorWithDonation("Apple, Julietta")
+	employer.AddGift(NewGift(date("11/15/2025"), dec.NewFromInt(250), "Payment", "donations.xlsx", 5))
+	employer.AddGift(NewGift(date("11/15/2025"), dec.NewFromInt(1000), "Payment", "donations.xlsx", 6))
+	donor.AddGift(NewGift(date("10/01/2025"), dec.NewFromInt(250), "Payment", "donations.xlsx", 2))
+
+	var credit, err = NewSoftCredit(date("11/15/2025"), dec.NewFromInt(250), "Acme Foundation",
+		"Apple, Julietta", MatchingGift, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = employer.ApplySoftCredit(credit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = employer.ApplySoftCredit(credit); err == nil {
+		t.Error("a gift was soft credited twice")
+	}
+	var credited = employer.CreditedGifts()
+	if len(credited) != 1 || credited[0].Row() != 5 || credited[0].CreditedTo() != "Apple, Julietta" {
+		t.Error("incorrect credited gifts")
+	}
+
+	var donorList = DonorList{employer.Key(): &employer, donor.Key(): &donor}
+	var soft = BySoftCredit(donorList)
+	if !soft[donor.Key()].Donation(ac.FY2026).Equal(dec.NewFromInt(500)) ||
+		!soft[employer.Key()].Donation(ac.FY2026).Equal(dec.NewFromInt(1000)) {
+		t.Error("soft credit was not moved to the donor")
+	}
+	if !donor.Donation(ac.FY2026).Equal(dec.NewFromInt(250)) ||
+		!employer.Donation(ac.FY2026).Equal(dec.NewFromInt(1250)) {
+		t.Error("hard credit was changed by the soft credit list")
+	}
+	if creditType, err := ParseCreditType(" donor advised fund "); err != nil || creditType != DonorAdvisedFund {
+		t.Errorf("ParseCreditType: got %v, %v", creditType, err)
+	}
+	if _, err := NewSoftCredit(date("11/15/2025"), dec.NewFromInt(10), "Acme", "Acme", MatchingGift, 2); err == nil {
+		t.Error("soft credit to the payee was accepted")
+	}
+}
+
+// Test_YearOverYear checks the comparison of a donor's giving with the
+// prior fiscal year.
+func Test_YearOverYear(t *testing.T) {
+	var donor = NewDonorWithDonation("Apple, Julietta")
+	var date = func(value string) d.Date {
+		var result, err = d.NewFromString(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+	donor.AddGift(NewGift(date("10/01/2020"), dec.NewFromInt(50), "Payment", "", 0))
+	donor.AddGift(NewGift(date("10/01/2022"), dec.NewFromInt(100), "Payment", "", 0))
+	donor.AddGift(NewGift(date("10/01/2023"), dec.NewFromInt(300), "Payment", "", 0))
+	donor.AddGift(NewGift(date("10/01/2024"), dec.NewFromInt(300), "Payment", "", 0))
+	donor.AddGift(NewGift(date("10/01/2025"), dec.NewFromInt(120), "Payment", "", 0))
+
+	var expected = [ac.NumFiscalYears]Change{ChangeRecaptured, ChangeUpgraded, ChangeSame, ChangeDowngraded}
+	for _, fy := range ac.FYIndicators {
+		var change = donor.YearOverYear(fy).Change()
+		if change != expected[fy] {
+			t.Error(fy.String() + ": expected " + expected[fy].String() + ", got " + change.String())
+		}
+	}
+	var yoy = donor.YearOverYear(ac.FY2026)
+	if !yoy.Difference().Equal(dec.NewFromInt(-180)) || !yoy.Prior().Equal(dec.NewFromInt(300)) {
+		t.Error("incorrect difference: " + yoy.Difference().String())
+	}
+	var newDonor = NewDonorWithDonation("Baker, Sam")
+	newDonor.AddGift(NewGift(date("10/01/2024"), dec.NewFromInt(100), "Payment", "", 0))
+	if newDonor.YearOverYear(ac.FY2025).Change() != ChangeNew ||
+		newDonor.YearOverYear(ac.FY2026).Change() != ChangeLapsed ||
+		newDonor.YearOverYear(ac.FY2024).Change() != ChangeNone {
+		t.Error("incorrect changes for a new donor")
+	}
+}
+
+// Test_HouseholdRollup checks that the payees of a household are combined
+// into a single donor and other payees are left alone.
+func Test_HouseholdRollup(t *testing.T) {
+	var date = func(value string) d.Date {
+		var result, err = d.NewFromString(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result
+	}
+	var home = a.Address{Street: "12 Oak St", City: "Apex", State: "NC", Zip: "27502"}
+	var donorList = make(DonorList)
+	var husband = New("Apple, John", "John Apple", home, "--", 2, true)
+	var wife = New("Apple, Mary", "Mary Apple", home, "mary@example.com", 2, false)
+	var trust = New("Apple Family Trust", "Apple Family Trust", a.BlankAddress(), "--", 1, false)
+	var other = New("Baker, Sam", "Sam Baker", a.BlankAddress(), "--", 1, false)
+	husband.AddGift(NewGift(date("10/01/2024"), dec.NewFromInt(600), "Payment", "", 0))
+	wife.AddGift(NewGift(date("11/01/2024"), dec.NewFromInt(500), "Payment", "", 0))
+	trust.AddGift(NewGift(date("12/01/2025"), dec.NewFromInt(2000), "Payment", "", 0))
+	for _, donor := range []*Donor{&husband, &wife, &trust, &other} {
+		donorList.Add(donor)
+	}
+
+	var households = NewHouseholdList()
+	var err = households.Add("Apple", "Apple, John", "", a.Address{})
+	if err == nil {
+		err = households.Add("Apple", "Apple, Mary", "John and Mary Apple", a.Address{})
+	}
+	if err == nil {
+		err = households.Add("Apple", "Apple Family Trust", "John and Mary Apple", a.Address{})
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if households.Add("Baker", "Apple, Mary", "", a.Address{}) == nil {
+		t.Error("payee was added to two households")
+	}
+	if households.Add("Apple", "Carter, Ann", "The Apples", a.Address{}) == nil {
+		t.Error("household was given two mailing names")
+	}
+
+	var rollup = households.Rollup(donorList)
+	if rollup.Count() != 2 || !rollup.Contains("Baker, Sam") {
+		t.Fatal("incorrect household donors: " + strconv.Itoa(rollup.Count()))
+	}
+	var household = rollup.Get("Apple")
+	if household.Name() != "John and Mary Apple" || household.Street() != home.Street ||
+		household.Email() != "mary@example.com" || household.NumberInHousehold() != 2 || household.Deceased() {
+		t.Error("incorrect household details: " + household.Name() + ", " + household.Street() + ", " + household.Email())
+	}
+	if household.GiftCount() != 3 || !household.Donation(ac.FY2025).Equal(dec.NewFromInt(1100)) {
+		t.Error("incorrect household giving: " + household.Donation(ac.FY2025).String())
+	}
+	if husband.GiftCount() != 1 {
+		t.Error("rollup changed the ledger of a payee")
+	}
+}
@@ -0,0 +1,216 @@
+// ----------------------------------------------------------------------------
+//
+// Gift
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package donors
+
+// This file contains the gift, a single donation in a donor's ledger.  A
+// gift with a negative amount is a reversal: a refund, a chargeback, a
+// returned check, or another negative adjustment.  A reversal nets against
+// the gift it reverses, so the amount of a gift is the amount kept.  A gift
+// made by an employer or a donor-advised fund may give soft credit to the
+// donor who prompted it.  A gift made through the online giving platform
+// has a processing fee, so less than the gift is deposited.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"acorn_go/pkg/campaigns"
+	"acorn_go/pkg/spreadsheet"
+
+	dec "github.com/shopspring/decimal"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Gift is a single donation with the spreadsheet row it came from.  For a
+// gift, reversed is the amount of the reversals netted against it.  For a
+// reversal, reverses is the gift it was netted against, if any.  creditTo is
+// the key of the donor with soft credit for the gift, if any.  fee is the
+// processing fee deducted before the gift was deposited.
+type Gift struct {
+	date       d.Date
+	amount     dec.Decimal
+	transType  string
+	file       string
+	row        int
+	campaign   string
+	reversed   dec.Decimal
+	reverses   *Gift
+	creditTo   string
+	creditType CreditType
+	fee        dec.Decimal
+}
+
+// ----------------------------------------------------------------------------
+// Factory Functions
+// ----------------------------------------------------------------------------
+
+// ReadGift returns the donor name and the gift in a row of the donation
+// spreadsheet.  The gift is attributed to a campaign by the active campaign
+// configuration.
+func ReadGift(sprdsht *spreadsheet.Spreadsheet, row int) (string, Gift, error) {
+	var gift Gift
+	var nameDonor string
+	var transType string
+	var date d.Date
+	var amount dec.Decimal
+	var config campaigns.Config
+	var err error
+
+	config, err = campaigns.Active()
+	if err == nil {
+		nameDonor, err = sprdsht.Cell(row, columnNameDonor)
+	}
+	if err == nil {
+		transType, err = sprdsht.Cell(row, columnTransactionType)
+	}
+	if err == nil {
+		date, err = sprdsht.CellDate(row, columnDate)
+	}
+	if err == nil {
+		amount, err = sprdsht.CellDecimal(row, columnPayment)
+	}
+	if err == nil {
+		gift = NewGift(date, amount, transType, sprdsht.FileName(), row+1)
+		gift.campaign, err = config.Attribute(sprdsht, row, date)
+	}
+	return nameDonor, gift, err
+}
+
+// NewGift creates a gift.  The file and row identify the source of the gift
+// in the donation spreadsheet.  The row is the row number shown by Excel.
+func NewGift(date d.Date, amount dec.Decimal, transType string, file string, row int) Gift {
+	var gift = Gift{
+		date:      date,
+		amount:    amount,
+		transType: transType,
+		file:      file,
+		row:       row,
+		reversed:  dec.Zero,
+		fee:       dec.Zero,
+	}
+	return gift
+}
+
+// ----------------------------------------------------------------------------
+// Properties
+// ----------------------------------------------------------------------------
+
+// Date returns the date of the gift.
+func (gift Gift) Date() d.Date {
+	return gift.date
+}
+
+// Amount returns the amount of the gift kept after its reversals.  For a
+// reversal, it is the negative amount reversed.
+func (gift Gift) Amount() dec.Decimal {
+	return gift.amount.Sub(gift.reversed)
+}
+
+// Received returns the amount of the gift before its reversals.
+func (gift Gift) Received() dec.Decimal {
+	return gift.amount
+}
+
+// Fee returns the processing fee deducted from the gift before it was
+// deposited.
+func (gift Gift) Fee() dec.Decimal {
+	return gift.fee
+}
+
+// SetFee records the processing fee deducted from the gift.
+func (gift *Gift) SetFee(fee dec.Decimal) {
+	gift.fee = fee
+}
+
+// Net returns the amount of the gift kept after its reversals and the
+// processing fee.  Donor totals use Amount, while finance totals use Net.
+func (gift Gift) Net() dec.Decimal {
+	return gift.Amount().Sub(gift.fee)
+}
+
+// Reversed returns the amount of the reversals netted against the gift.
+func (gift Gift) Reversed() dec.Decimal {
+	return gift.reversed
+}
+
+// IsReversal returns true if the gift is a refund, chargeback, or other
+// negative adjustment.
+func (gift Gift) IsReversal() bool {
+	return gift.amount.LessThan(dec.Zero)
+}
+
+// Reverses returns the gift a reversal was netted against, as it was after
+// the reversal.  The second value is false if the reversal matched no gift.
+func (gift Gift) Reverses() (Gift, bool) {
+	var original Gift
+	var found = gift.reverses != nil
+	if found {
+		original = *gift.reverses
+	}
+	return original, found
+}
+
+// Type returns the Quickbooks transaction type of the gift.
+func (gift Gift) Type() string {
+	return gift.transType
+}
+
+// File returns the name of the spreadsheet the gift was read from.
+func (gift Gift) File() string {
+	return gift.file
+}
+
+// Campaign returns the name of the campaign the gift is attributed to, or
+// campaigns.NoCampaign.
+func (gift Gift) Campaign() string {
+	var campaign = gift.campaign
+	if campaign == "" {
+		campaign = campaigns.NoCampaign
+	}
+	return campaign
+}
+
+// SetCampaign attributes the gift to a campaign.
+func (gift *Gift) SetCampaign(campaign string) {
+	gift.campaign = campaign
+}
+
+// Row returns the row of the spreadsheet the gift was read from.
+func (gift Gift) Row() int {
+	return gift.row
+}
+
+// CreditedTo returns the key of the donor with soft credit for the gift, or
+// an empty string.
+func (gift Gift) CreditedTo() string {
+	return gift.creditTo
+}
+
+// CreditType returns the reason for the soft credit for the gift.  It is
+// meaningful only if the gift gives soft credit.
+func (gift Gift) CreditType() CreditType {
+	return gift.creditType
+}
+
+// IsSoftCredited returns true if the gift gives soft credit to a donor.
+func (gift Gift) IsSoftCredited() bool {
+	return gift.creditTo != ""
+}
+
+// InRange returns true if the gift was made on or between the dates.
+func (gift Gift) InRange(from d.Date, thru d.Date) bool {
+	return !gift.date.Before(from) && !gift.date.After(thru)
+}
@@ -0,0 +1,285 @@
+// ----------------------------------------------------------------------------
+//
+// Households
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package donors
+
+// Gifts are recorded under the Quickbooks payee, so spouses or a family
+// trust giving separately look like different donors.  The household table
+// maps payees to a household with a shared mailing name and address.  Each
+// row of the table names a household and one of its payees.  The mailing
+// name and address may be given on any row of the household and must agree
+// where given more than once.  If a household has no mailing address, the
+// address of its first payee with one is used.
+//
+// A rollup replaces the payees of each household with a single donor whose
+// ledger holds the gifts of all the payees.  Payees that are not in a
+// household are left as they are.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	a "acorn_go/pkg/address"
+	"acorn_go/pkg/spreadsheet"
+	"errors"
+	"sort"
+
+	"github.com/waysys/assert/assert"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Household is a group of payees who share a mailing name and address.
+type Household struct {
+	key     string
+	name    string
+	address a.Address
+	members []string
+}
+
+// HouseholdList maps households by their keys and payees by the keys of
+// their households.
+type HouseholdList struct {
+	households map[string]*Household
+	payees     map[string]string
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Household column names.  The payee and address columns have the same
+// headings as the donor spreadsheet.
+const (
+	columnHousehold   = "Household"
+	columnMailingName = "Mailing Name"
+)
+
+// ----------------------------------------------------------------------------
+// Factory Functions
+// ----------------------------------------------------------------------------
+
+// NewHouseholdList returns an empty household list.  Every payee is its own
+// household.
+func NewHouseholdList() HouseholdList {
+	return HouseholdList{
+		households: make(map[string]*Household),
+		payees:     make(map[string]string),
+	}
+}
+
+// NewHouseholdListFromSheet creates a household list from the rows of the
+// household spreadsheet.
+func NewHouseholdListFromSheet(sprdsht *spreadsheet.Spreadsheet) (HouseholdList, error) {
+	var households = NewHouseholdList()
+	var numRows = sprdsht.Size()
+	var err error
+	//
+	// Loop through all the rows in the spreadsheet
+	//
+	for row := 1; row < numRows; row++ {
+		err = processHousehold(&households, sprdsht, row)
+		if err != nil {
+			return households, sprdsht.WrapRow(err, "processing household", row)
+		}
+	}
+	return households, err
+}
+
+// ReadHouseholds creates a household list from the household spreadsheet.
+func ReadHouseholds(fileName string, tab string) (HouseholdList, error) {
+	var sprdsht, err = spreadsheet.ProcessData(fileName, tab)
+	if err != nil {
+		return NewHouseholdList(), err
+	}
+	return NewHouseholdListFromSheet(&sprdsht)
+}
+
+// processHousehold adds the payee in a row to its household.
+func processHousehold(households *HouseholdList, sprdsht *spreadsheet.Spreadsheet, row int) error {
+	var key string
+	var payee string
+	var name string
+	var address a.Address
+	var err error
+	//
+	// Extract values from spreadsheet
+	//
+	key, err = sprdsht.Cell(row, columnHousehold)
+	if err == nil {
+		payee, err = sprdsht.Cell(row, columnKey)
+	}
+	if err == nil {
+		name, err = sprdsht.Cell(row, columnMailingName)
+	}
+	if err == nil {
+		address, err = processAddress(sprdsht, row)
+	}
+	//
+	// Add the payee
+	//
+	if err == nil {
+		err = households.Add(key, payee, name, address)
+	}
+	return err
+}
+
+// ----------------------------------------------------------------------------
+// Household Methods
+// ----------------------------------------------------------------------------
+
+// Key returns the name used to identify the household.
+func (household *Household) Key() string {
+	return household.key
+}
+
+// Name returns the mailing name of the household.  It is the key if no
+// mailing name was given.
+func (household *Household) Name() string {
+	var name = household.name
+	if name == "" {
+		name = household.key
+	}
+	return name
+}
+
+// Address returns the mailing address of the household.  The street is
+// empty if no address was given.
+func (household *Household) Address() a.Address {
+	return household.address
+}
+
+// Members returns the payees of the household in alphabetical order.
+func (household *Household) Members() []string {
+	var members = append([]string{}, household.members...)
+	sort.Strings(members)
+	return members
+}
+
+// ----------------------------------------------------------------------------
+// Household List Methods
+// ----------------------------------------------------------------------------
+
+// Add adds a payee to a household, creating the household if necessary.
+// The name and address may be empty.  An error is returned if the payee is
+// already in a household or the name or address differs from the one
+// already given for the household.
+func (households *HouseholdList) Add(key string, payee string, name string, address a.Address) error {
+	if key == "" || payee == "" {
+		return errors.New("household and payee must not be empty")
+	}
+	if other, found := households.payees[payee]; found {
+		return errors.New("payee is already in household " + other + ": " + payee)
+	}
+	var household, found = households.households[key]
+	if !found {
+		household = &Household{key: key}
+		households.households[key] = household
+	}
+	if name != "" {
+		if household.name != "" && household.name != name {
+			return errors.New("household has two mailing names: " + key)
+		}
+		household.name = name
+	}
+	if address.Street != "" {
+		if household.address.Street != "" && household.address != address {
+			return errors.New("household has two mailing addresses: " + key)
+		}
+		household.address = address
+	}
+	household.members = append(household.members, payee)
+	households.payees[payee] = key
+	return nil
+}
+
+// Contains returns true if the household list has a household with the key.
+func (households *HouseholdList) Contains(key string) bool {
+	var _, found = households.households[key]
+	return found
+}
+
+// Get returns the household with the key.  The household must be in the
+// list.
+func (households *HouseholdList) Get(key string) *Household {
+	assert.Assert(households.Contains(key), "household is not in household list: "+key)
+	return households.households[key]
+}
+
+// Count returns the number of households in the list.
+func (households *HouseholdList) Count() int {
+	return len(households.households)
+}
+
+// Keys returns the alphabetically sorted keys of the households.
+func (households *HouseholdList) Keys() []string {
+	var keys = make([]string, 0, len(households.households))
+	for key := range households.households {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// HouseholdOf returns the key of the payee's household, or the payee if the
+// payee is not in a household.
+func (households *HouseholdList) HouseholdOf(payee string) string {
+	var key, found = households.payees[payee]
+	if !found {
+		key = payee
+	}
+	return key
+}
+
+// Rollup returns a donor list with a single donor for each household.  The
+// household donor has the mailing name and address of the household, the
+// email of the first payee with one, the largest number in household of
+// its payees, and the gifts of all its payees.  It is deceased only if all
+// its payees are.  The donors in the list are not changed.
+func (households *HouseholdList) Rollup(donorList DonorList) DonorList {
+	var result = make(DonorList)
+	for _, payee := range donorList.Keys() {
+		var donor = donorList.Get(payee)
+		var key = households.HouseholdOf(payee)
+		if key == payee && !households.Contains(key) {
+			result[payee] = donor
+			continue
+		}
+		if !result.Contains(key) {
+			result.Add(households.newDonor(key, donor))
+		}
+		result.Get(key).join(donor)
+	}
+	return result
+}
+
+// newDonor returns a donor for the household with the email of its first
+// payee and no gifts.
+func (households *HouseholdList) newDonor(key string, first *Donor) *Donor {
+	var household = households.Get(key)
+	var donor = New(key, household.Name(), household.address, first.email, first.numberHousehold, true)
+	return &donor
+}
+
+// join adds the gifts and details of a payee to a household donor.
+func (donor *Donor) join(payee *Donor) {
+	if donor.address.Street == "" {
+		donor.address = payee.address
+	}
+	if (!donor.HasEmail() || donor.email == "") && payee.HasEmail() && payee.email != "" {
+		donor.email = payee.email
+	}
+	donor.numberHousehold = max(donor.numberHousehold, payee.numberHousehold)
+	donor.deceased = donor.deceased && payee.deceased
+	donor.Merge(payee)
+}
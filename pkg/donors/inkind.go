@@ -0,0 +1,255 @@
+// ----------------------------------------------------------------------------
+//
+// In-Kind Gifts
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package donors
+
+// An in-kind gift is a gift of something other than cash: stock, donated
+// goods, or services for an event.  In-kind gifts are kept in their own
+// spreadsheet with a row for each gift and the columns Date, "Donor full
+// name", Description, Fair Market Value, and Valuation Method.  They are
+// held apart from the donor's cash ledger, so Donation and the other cash
+// totals are not changed by them.  Recognition adds them to the cash.
+//
+// The acknowledgment of an in-kind gift describes the gift but does not
+// state its value, since the donor is responsible for valuing a non-cash
+// contribution for tax purposes.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	ac "acorn_go/pkg/accounting"
+	"acorn_go/pkg/spreadsheet"
+	"errors"
+	"slices"
+	"strings"
+
+	dec "github.com/shopspring/decimal"
+	"github.com/waysys/assert/assert"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// InKindGift is a non-cash gift with its fair market value and the method
+// used to value it.
+type InKindGift struct {
+	date        d.Date
+	description string
+	value       dec.Decimal
+	method      string
+	file        string
+	row         int
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// In-kind gift column names.  The date and donor columns have the same
+// headings as the donation and donor spreadsheets.
+const (
+	columnDescription = "Description"
+	columnFairValue   = "Fair Market Value"
+	columnValuation   = "Valuation Method"
+)
+
+// Wording of the acknowledgment of an in-kind gift
+const (
+	organizationName   = "Acorn Scholarship Fund"
+	acknowledgmentText = "No goods or services were provided in exchange for this contribution.  " +
+		"This acknowledgment describes the gift but does not state its value, which the donor is " +
+		"responsible for determining."
+)
+
+// ----------------------------------------------------------------------------
+// Factory Functions
+// ----------------------------------------------------------------------------
+
+// NewInKindGift creates an in-kind gift.  The file and row identify the
+// source of the gift in the in-kind spreadsheet.  The row is the row number
+// shown by Excel.
+func NewInKindGift(
+	date d.Date,
+	description string,
+	value dec.Decimal,
+	method string,
+	file string,
+	row int) (InKindGift, error) {
+
+	var gift = InKindGift{
+		date:        date,
+		description: strings.TrimSpace(description),
+		value:       value,
+		method:      strings.TrimSpace(method),
+		file:        file,
+		row:         row,
+	}
+	var err error
+	switch {
+	case gift.description == "":
+		err = errors.New("in-kind gift must have a description")
+	case !value.GreaterThan(dec.Zero):
+		err = errors.New("fair market value must be greater than zero")
+	case gift.method == "":
+		err = errors.New("in-kind gift must have a valuation method")
+	}
+	return gift, err
+}
+
+// ReadInKindGift returns the donor name and the in-kind gift in a row of the
+// in-kind spreadsheet.
+func ReadInKindGift(sprdsht *spreadsheet.Spreadsheet, row int) (string, InKindGift, error) {
+	var gift InKindGift
+	var nameDonor string
+	var date d.Date
+	var description string
+	var value dec.Decimal
+	var method string
+	var err error
+
+	nameDonor, err = sprdsht.Cell(row, columnKey)
+	if err == nil && nameDonor == "" {
+		err = errors.New("donor name must not be empty")
+	}
+	if err == nil {
+		date, err = sprdsht.CellDate(row, columnDate)
+	}
+	if err == nil {
+		description, err = sprdsht.Cell(row, columnDescription)
+	}
+	if err == nil {
+		value, err = sprdsht.CellDecimal(row, columnFairValue)
+	}
+	if err == nil {
+		method, err = sprdsht.Cell(row, columnValuation)
+	}
+	if err == nil {
+		gift, err = NewInKindGift(date, description, value, method, sprdsht.FileName(), row+1)
+	}
+	return nameDonor, gift, err
+}
+
+// ----------------------------------------------------------------------------
+// Properties
+// ----------------------------------------------------------------------------
+
+// Date returns the date the gift was received.
+func (gift InKindGift) Date() d.Date {
+	return gift.date
+}
+
+// Description returns the description of the gift.
+func (gift InKindGift) Description() string {
+	return gift.description
+}
+
+// Value returns the fair market value of the gift.
+func (gift InKindGift) Value() dec.Decimal {
+	return gift.value
+}
+
+// Method returns how the fair market value was determined, such as an
+// appraisal or the closing price of a stock.
+func (gift InKindGift) Method() string {
+	return gift.method
+}
+
+// File returns the name of the spreadsheet the gift was read from.
+func (gift InKindGift) File() string {
+	return gift.file
+}
+
+// Row returns the row of the gift in the in-kind spreadsheet.
+func (gift InKindGift) Row() int {
+	return gift.row
+}
+
+// Acknowledgment returns the wording that acknowledges the gift in a
+// year-end letter.
+func (gift InKindGift) Acknowledgment() string {
+	return organizationName + " gratefully acknowledges the gift of " + gift.description +
+		" received on " + gift.date.String() + ".  " + acknowledgmentText
+}
+
+// ----------------------------------------------------------------------------
+// Donor Methods
+// ----------------------------------------------------------------------------
+
+// AddInKind adds an in-kind gift to the donor.  The in-kind gifts are kept
+// in date order.
+func (donor *Donor) AddInKind(gift InKindGift) {
+	var index = len(donor.inKind)
+	for index > 0 && donor.inKind[index-1].date.After(gift.date) {
+		index--
+	}
+	donor.inKind = slices.Insert(donor.inKind, index, gift)
+}
+
+// InKindGifts returns a copy of the in-kind gifts in date order.
+func (donor Donor) InKindGifts() []InKindGift {
+	return slices.Clone(donor.inKind)
+}
+
+// InKindDonation returns the value of the in-kind gifts in the fiscal year.
+func (donor Donor) InKindDonation(fy ac.FYIndicator) dec.Decimal {
+	assert.Assert(ac.IsFYIndicator(fy), "Invalid FYIndicator: "+fy.String())
+	var amount = ZERO
+	for _, gift := range donor.inKind {
+		if ac.FiscalYearIndicator(gift.date) == fy {
+			amount = amount.Add(gift.value)
+		}
+	}
+	return amount
+}
+
+// CalInKindGifts returns the in-kind gifts in the calendar year.
+func (donor Donor) CalInKindGifts(year ac.YearIndicator) []InKindGift {
+	assert.Assert(ac.IsYearIndicator(year), "Invalid year indicator: "+year.String())
+	var gifts = []InKindGift{}
+	for _, gift := range donor.inKind {
+		if ac.YIndicator(gift.date) == year {
+			gifts = append(gifts, gift)
+		}
+	}
+	return gifts
+}
+
+// Recognition returns the cash and in-kind giving in the fiscal year.
+func (donor Donor) Recognition(fy ac.FYIndicator) dec.Decimal {
+	return donor.Donation(fy).Add(donor.InKindDonation(fy))
+}
+
+// LifetimeCash returns the total of every cash gift in the ledger,
+// including those before the fiscal years of the analysis.
+func (donor Donor) LifetimeCash() dec.Decimal {
+	var amount = ZERO
+	for _, gift := range donor.gifts {
+		amount = amount.Add(gift.Amount())
+	}
+	return amount
+}
+
+// LifetimeInKind returns the value of every in-kind gift.
+func (donor Donor) LifetimeInKind() dec.Decimal {
+	var amount = ZERO
+	for _, gift := range donor.inKind {
+		amount = amount.Add(gift.value)
+	}
+	return amount
+}
+
+// LifetimeGiving returns the total of every cash and in-kind gift.
+func (donor Donor) LifetimeGiving() dec.Decimal {
+	return donor.LifetimeCash().Add(donor.LifetimeInKind())
+}
@@ -0,0 +1,272 @@
+// ----------------------------------------------------------------------------
+//
+// Soft Credits
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package donors
+
+// When an employer matches a donor's gift, or a donor gives through a
+// donor-advised fund, Quickbooks records the employer or the fund as the
+// payee.  The payee keeps the hard credit for the gift: the gift stays in
+// its ledger and counts in its totals.  The donor who prompted the gift
+// receives soft credit, the recognition for it.
+//
+// Soft credits are kept in their own spreadsheet with a row for each gift
+// and the columns Date, Payee, and Payment, as in the donation spreadsheet,
+// and "Donor full name" and Credit Type.  Each row identifies a gift in the
+// payee's ledger by its date and amount and names the donor who receives
+// soft credit for it.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"acorn_go/pkg/spreadsheet"
+	"errors"
+	"slices"
+	"strings"
+
+	dec "github.com/shopspring/decimal"
+	"github.com/waysys/assert/assert"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// CreditType is the reason a donor receives soft credit for a gift.
+type CreditType int
+
+// SoftCredit links a gift made by a payee to the donor who receives soft
+// credit for it.
+type SoftCredit struct {
+	date       d.Date
+	amount     dec.Decimal
+	payee      string
+	donor      string
+	creditType CreditType
+	row        int
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const NumCreditTypes = 2
+
+const (
+	MatchingGift     = CreditType(0)
+	DonorAdvisedFund = CreditType(1)
+)
+
+var CreditTypes = [NumCreditTypes]CreditType{
+	MatchingGift,
+	DonorAdvisedFund,
+}
+
+var creditTypeNames = [NumCreditTypes]string{
+	"Matching Gift",
+	"Donor Advised Fund",
+}
+
+// Soft credit column names.  The date, payee, and payment columns have the
+// same headings as the donation spreadsheet.
+const (
+	columnCreditType = "Credit Type"
+)
+
+// ----------------------------------------------------------------------------
+// Factory Functions
+// ----------------------------------------------------------------------------
+
+// NewSoftCredit returns the soft credit to the donor for the payee's gift of
+// the amount on the date.  The row is the row number shown by Excel.
+func NewSoftCredit(
+	date d.Date,
+	amount dec.Decimal,
+	payee string,
+	donor string,
+	creditType CreditType,
+	row int) (SoftCredit, error) {
+
+	var credit = SoftCredit{
+		date:       date,
+		amount:     amount,
+		payee:      strings.TrimSpace(payee),
+		donor:      strings.TrimSpace(donor),
+		creditType: creditType,
+		row:        row,
+	}
+	var err error
+	switch {
+	case credit.payee == "" || credit.donor == "":
+		err = errors.New("payee and donor must not be empty")
+	case credit.payee == credit.donor:
+		err = errors.New("donor must not be the payee: " + credit.donor)
+	case !amount.GreaterThan(dec.Zero):
+		err = errors.New("soft credit amount must be greater than zero")
+	}
+	return credit, err
+}
+
+// ReadSoftCredit returns the soft credit in a row of the soft credit
+// spreadsheet.
+func ReadSoftCredit(sprdsht *spreadsheet.Spreadsheet, row int) (SoftCredit, error) {
+	var date d.Date
+	var amount dec.Decimal
+	var payee, donor, typeName string
+	var creditType CreditType
+	var err error
+
+	date, err = sprdsht.CellDate(row, columnDate)
+	if err == nil {
+		amount, err = sprdsht.CellDecimal(row, columnPayment)
+	}
+	if err == nil {
+		payee, err = sprdsht.Cell(row, columnNameDonor)
+	}
+	if err == nil {
+		donor, err = sprdsht.Cell(row, columnKey)
+	}
+	if err == nil {
+		typeName, err = sprdsht.Cell(row, columnCreditType)
+	}
+	if err == nil {
+		creditType, err = ParseCreditType(typeName)
+	}
+	if err != nil {
+		return SoftCredit{}, err
+	}
+	return NewSoftCredit(date, amount, payee, donor, creditType, row+1)
+}
+
+// ParseCreditType returns the credit type with the name, ignoring case.
+func ParseCreditType(name string) (CreditType, error) {
+	for _, creditType := range CreditTypes {
+		if strings.EqualFold(strings.TrimSpace(name), creditType.String()) {
+			return creditType, nil
+		}
+	}
+	return MatchingGift, errors.New("credit type must be Matching Gift or Donor Advised Fund: " + name)
+}
+
+// ----------------------------------------------------------------------------
+// Credit Type Methods
+// ----------------------------------------------------------------------------
+
+// String returns the name of the credit type.
+func (creditType CreditType) String() string {
+	assert.Assert(MatchingGift <= creditType && creditType <= DonorAdvisedFund, "invalid credit type")
+	return creditTypeNames[creditType]
+}
+
+// ----------------------------------------------------------------------------
+// Soft Credit Methods
+// ----------------------------------------------------------------------------
+
+// Date returns the date of the payee's gift.
+func (credit SoftCredit) Date() d.Date {
+	return credit.date
+}
+
+// Amount returns the amount of the payee's gift.
+func (credit SoftCredit) Amount() dec.Decimal {
+	return credit.amount
+}
+
+// Payee returns the name of the employer or fund that made the gift.
+func (credit SoftCredit) Payee() string {
+	return credit.payee
+}
+
+// Donor returns the key of the donor who receives soft credit.
+func (credit SoftCredit) Donor() string {
+	return credit.donor
+}
+
+// CreditType returns the reason for the soft credit.
+func (credit SoftCredit) CreditType() CreditType {
+	return credit.creditType
+}
+
+// Row returns the row of the soft credit in the soft credit spreadsheet.
+func (credit SoftCredit) Row() int {
+	return credit.row
+}
+
+// ----------------------------------------------------------------------------
+// Donor Methods
+// ----------------------------------------------------------------------------
+
+// ApplySoftCredit gives the donor of the soft credit recognition for the
+// gift in the ledger made on its date for its amount.  The gift must not
+// already give soft credit to a donor.
+func (donor *Donor) ApplySoftCredit(credit SoftCredit) error {
+	var index = slices.IndexFunc(donor.gifts, func(gift Gift) bool {
+		return !gift.date.Before(credit.date) && !gift.date.After(credit.date) &&
+			gift.amount.Equal(credit.amount) && gift.creditTo == ""
+	})
+	if index < 0 {
+		return errors.New("no gift by " + donor.key + " of " + credit.amount.StringFixed(2) +
+			" on " + credit.date.String() + " to give soft credit")
+	}
+	donor.gifts[index].creditTo = credit.donor
+	donor.gifts[index].creditType = credit.creditType
+	return nil
+}
+
+// CreditedGifts returns the gifts in the ledger that give soft credit to
+// another donor in date order.
+func (donor Donor) CreditedGifts() []Gift {
+	var gifts = []Gift{}
+	for _, gift := range donor.gifts {
+		if gift.IsSoftCredited() {
+			gifts = append(gifts, gift)
+		}
+	}
+	return gifts
+}
+
+// ----------------------------------------------------------------------------
+// Functions
+// ----------------------------------------------------------------------------
+
+// BySoftCredit returns a donor list in which each gift that gives soft
+// credit is moved from the ledger of its payee to the ledger of the donor
+// with the soft credit.  A donor with soft credit who is not in the list is
+// added.  The donors in the list passed are not changed.
+func BySoftCredit(donorList DonorList) DonorList {
+	var result = make(DonorList)
+	var credited = []Gift{}
+	for key, donor := range donorList {
+		var copied = *donor
+		copied.gifts = []Gift{}
+		for _, gift := range donor.gifts {
+			if gift.IsSoftCredited() {
+				credited = append(credited, gift)
+			} else {
+				copied.gifts = append(copied.gifts, gift)
+			}
+		}
+		copied.reversals = slices.Clone(donor.reversals)
+		copied.inKind = slices.Clone(donor.inKind)
+		result[key] = &copied
+	}
+	for _, gift := range credited {
+		var donor, found = result[gift.creditTo]
+		if !found {
+			var created = NewDonorWithDonation(gift.creditTo)
+			donor = &created
+			result[gift.creditTo] = donor
+		}
+		donor.insert(gift)
+	}
+	return result
+}
@@ -0,0 +1,173 @@
+// ----------------------------------------------------------------------------
+//
+// Year Over Year
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package donors
+
+// This file compares a donor's giving in a fiscal year with the fiscal year
+// before it and places the donor in a category of change used by the
+// fundraising effectiveness gain and loss analysis.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	ac "acorn_go/pkg/accounting"
+
+	dec "github.com/shopspring/decimal"
+	"github.com/waysys/assert/assert"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Change is the category of change in a donor's giving from one fiscal year
+// to the next.
+type Change int
+
+// YearOverYear holds a donor's giving in a fiscal year and the fiscal year
+// before it.
+type YearOverYear struct {
+	fy         ac.FYIndicator
+	prior      dec.Decimal
+	current    dec.Decimal
+	gaveBefore bool
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const NumChanges = 7
+
+const (
+	ChangeNone       = Change(0) // did not give in either fiscal year
+	ChangeNew        = Change(1) // first gift in the fiscal year
+	ChangeRecaptured = Change(2) // gave in the fiscal year after a gap
+	ChangeUpgraded   = Change(3) // gave more than in the prior fiscal year
+	ChangeSame       = Change(4) // gave the same as in the prior fiscal year
+	ChangeDowngraded = Change(5) // gave less than in the prior fiscal year
+	ChangeLapsed     = Change(6) // gave in the prior fiscal year but not this one
+)
+
+var Changes = [NumChanges]Change{
+	ChangeNone,
+	ChangeNew,
+	ChangeRecaptured,
+	ChangeUpgraded,
+	ChangeSame,
+	ChangeDowngraded,
+	ChangeLapsed,
+}
+
+var changeNames = [NumChanges]string{
+	"No Gift",
+	"New",
+	"Recaptured",
+	"Upgraded",
+	"Same",
+	"Downgraded",
+	"Lapsed",
+}
+
+// ----------------------------------------------------------------------------
+// Functions
+// ----------------------------------------------------------------------------
+
+// IsChange returns true if the change is a valid value.
+func IsChange(change Change) bool {
+	return ChangeNone <= change && change < NumChanges
+}
+
+// ----------------------------------------------------------------------------
+// Methods
+// ----------------------------------------------------------------------------
+
+// YearOverYear compares the donor's giving in the fiscal year with the
+// fiscal year before it.  The prior fiscal year may be before the fiscal
+// years of the analysis.
+func (donor Donor) YearOverYear(fy ac.FYIndicator) YearOverYear {
+	assert.Assert(ac.IsFYIndicator(fy), "Invalid FYIndicator: "+fy.String())
+	var number = fy.Number()
+	var yoy = YearOverYear{
+		fy:      fy,
+		prior:   ZERO,
+		current: ZERO,
+	}
+	var earlier = make(map[int]dec.Decimal)
+	for _, gift := range donor.gifts {
+		var giftNumber = ac.FiscalYearNumber(gift.date)
+		switch {
+		case giftNumber == number:
+			yoy.current = yoy.current.Add(gift.Amount())
+		case giftNumber == number-1:
+			yoy.prior = yoy.prior.Add(gift.Amount())
+		case giftNumber < number-1:
+			earlier[giftNumber] = earlier[giftNumber].Add(gift.Amount())
+		}
+	}
+	for _, total := range earlier {
+		if total.GreaterThan(ZERO) {
+			yoy.gaveBefore = true
+		}
+	}
+	return yoy
+}
+
+// String returns the name of the change.
+func (change Change) String() string {
+	assert.Assert(IsChange(change), "Invalid change")
+	return changeNames[change]
+}
+
+// FY returns the fiscal year of the comparison.
+func (yoy YearOverYear) FY() ac.FYIndicator {
+	return yoy.fy
+}
+
+// Prior returns the donor's giving in the prior fiscal year.
+func (yoy YearOverYear) Prior() dec.Decimal {
+	return yoy.prior
+}
+
+// Current returns the donor's giving in the fiscal year.
+func (yoy YearOverYear) Current() dec.Decimal {
+	return yoy.current
+}
+
+// Difference returns the current giving less the prior giving.
+func (yoy YearOverYear) Difference() dec.Decimal {
+	return yoy.current.Sub(yoy.prior)
+}
+
+// Change returns the category of change in the donor's giving.
+func (yoy YearOverYear) Change() Change {
+	var gavePrior = yoy.prior.GreaterThan(ZERO)
+	var gaveCurrent = yoy.current.GreaterThan(ZERO)
+	var change Change
+	switch {
+	case gaveCurrent && gavePrior && yoy.current.GreaterThan(yoy.prior):
+		change = ChangeUpgraded
+	case gaveCurrent && gavePrior && yoy.current.Equal(yoy.prior):
+		change = ChangeSame
+	case gaveCurrent && gavePrior:
+		change = ChangeDowngraded
+	case gaveCurrent && yoy.gaveBefore:
+		change = ChangeRecaptured
+	case gaveCurrent:
+		change = ChangeNew
+	case gavePrior:
+		change = ChangeLapsed
+	default:
+		change = ChangeNone
+	}
+	return change
+}
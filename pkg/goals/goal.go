@@ -0,0 +1,374 @@
+// ----------------------------------------------------------------------------
+//
+// Fundraising Goals
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+// The goals package compares the fundraising goals set by the board with the
+// actual results of a fiscal year.
+package goals
+
+// The goals are kept in a spreadsheet with a row for each goal and the
+// columns Fiscal Year, Measure, and Goal, and the optional columns Month
+// and Campaign.  The fiscal year is written as FY2026 or 2026.  The measure
+// is one of:
+// -- Donations: the dollars given
+// -- Donors: the number of donors
+// -- New Donors: the number of donors whose first gift was made in the
+//    fiscal year
+// -- Major Gifts: the dollars given by donors whose giving in the fiscal
+//    year reaches the minimum of the lowest major donor tier
+//
+// A goal with a month, written as October, Oct, or 10, is the donations
+// goal for that month of the fiscal year.  A goal with a campaign is the
+// goal for the donations, donors, or new donors of the campaign.  A goal
+// may not have both a month and a campaign.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	a "acorn_go/pkg/accounting"
+	"acorn_go/pkg/spreadsheet"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	dec "github.com/shopspring/decimal"
+	"github.com/waysys/assert/assert"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Measure is a result of the fiscal year that the board sets a goal for.
+type Measure int
+
+// Goal is the target for a measure in a fiscal year, or in a month or
+// campaign of the fiscal year.
+type Goal struct {
+	fy       a.FYIndicator
+	measure  Measure
+	month    int
+	campaign string
+	amount   dec.Decimal
+	row      int
+}
+
+// Goals holds the goals in the order of the goals spreadsheet.
+type Goals struct {
+	goals []Goal
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const NumMeasures = 4
+
+const (
+	Donations  = Measure(0)
+	Donors     = Measure(1)
+	NewDonors  = Measure(2)
+	MajorGifts = Measure(3)
+)
+
+var Measures = [NumMeasures]Measure{
+	Donations,
+	Donors,
+	NewDonors,
+	MajorGifts,
+}
+
+var measureNames = [NumMeasures]string{
+	"Donations",
+	"Donors",
+	"New Donors",
+	"Major Gifts",
+}
+
+// Goals column names
+const (
+	columnFiscalYear = "Fiscal Year"
+	columnMeasure    = "Measure"
+	columnGoal       = "Goal"
+	columnMonth      = "Month"
+	columnCampaign   = "Campaign"
+)
+
+// ----------------------------------------------------------------------------
+// Factory Functions
+// ----------------------------------------------------------------------------
+
+// NewGoal creates a goal.  The month is the number of the calendar month,
+// or zero for a goal for the whole fiscal year.  The campaign is empty for
+// a goal for all campaigns.
+func NewGoal(
+	fy a.FYIndicator,
+	measure Measure,
+	month int,
+	campaign string,
+	amount dec.Decimal) (Goal, error) {
+
+	var goal = Goal{
+		fy:       fy,
+		measure:  measure,
+		month:    month,
+		campaign: strings.TrimSpace(campaign),
+		amount:   amount,
+	}
+	var err error
+	switch {
+	case !a.IsFYIndicator(fy):
+		err = errors.New("fiscal year is not a fiscal year of the analysis")
+	case !amount.GreaterThan(dec.Zero):
+		err = errors.New("goal must be greater than zero")
+	case month < 0 || month > 12:
+		err = errors.New("invalid month: " + strconv.Itoa(month))
+	case month != 0 && goal.campaign != "":
+		err = errors.New("goal must not have both a month and a campaign")
+	case month != 0 && measure != Donations:
+		err = errors.New("only a Donations goal may have a month")
+	case goal.campaign != "" && measure == MajorGifts:
+		err = errors.New("a Major Gifts goal must not have a campaign")
+	}
+	return goal, err
+}
+
+// NewGoals returns the goals in the rows of a goals spreadsheet.  A measure
+// may have only one goal for a fiscal year, month, or campaign.
+func NewGoals(sprdsht *spreadsheet.Spreadsheet) (Goals, error) {
+	var goals = Goals{goals: []Goal{}}
+	var numRows = sprdsht.Size()
+	for row := 1; row < numRows; row++ {
+		var goal, err = readGoal(sprdsht, row)
+		if err == nil && slices.ContainsFunc(goals.goals, func(other Goal) bool {
+			return other.fy == goal.fy && other.measure == goal.measure &&
+				other.month == goal.month && other.campaign == goal.campaign
+		}) {
+			err = errors.New("goal is listed more than once: " + goal.Label())
+		}
+		if err != nil {
+			return goals, sprdsht.WrapRow(err, "processing goal", row)
+		}
+		goals.goals = append(goals.goals, goal)
+	}
+	return goals, nil
+}
+
+// ReadGoals reads the goals from a goals spreadsheet.
+func ReadGoals(fileName string, tab string) (Goals, error) {
+	var sprdsht, err = spreadsheet.ProcessData(fileName, tab)
+	if err != nil {
+		return Goals{goals: []Goal{}}, err
+	}
+	return NewGoals(&sprdsht)
+}
+
+// readGoal returns the goal in a row of the goals spreadsheet.
+func readGoal(sprdsht *spreadsheet.Spreadsheet, row int) (Goal, error) {
+	var fyText, measureName, monthText, campaign string
+	var fy a.FYIndicator
+	var measure Measure
+	var month int
+	var amount dec.Decimal
+	var err error
+
+	fyText, err = sprdsht.Cell(row, columnFiscalYear)
+	if err == nil {
+		fy, err = ParseFiscalYear(fyText)
+	}
+	if err == nil {
+		measureName, err = sprdsht.Cell(row, columnMeasure)
+	}
+	if err == nil {
+		measure, err = ParseMeasure(measureName)
+	}
+	if err == nil {
+		amount, err = sprdsht.CellDecimal(row, columnGoal)
+	}
+	if err == nil && sprdsht.HasColumn(columnMonth) {
+		monthText, err = sprdsht.Cell(row, columnMonth)
+	}
+	if err == nil && monthText != "" {
+		month, err = ParseMonth(monthText)
+	}
+	if err == nil && sprdsht.HasColumn(columnCampaign) {
+		campaign, err = sprdsht.Cell(row, columnCampaign)
+	}
+	if err != nil {
+		return Goal{}, err
+	}
+	var goal Goal
+	goal, err = NewGoal(fy, measure, month, campaign, amount)
+	goal.row = row + 1
+	return goal, err
+}
+
+// ParseMeasure returns the measure with the name, ignoring case.
+func ParseMeasure(name string) (Measure, error) {
+	for _, measure := range Measures {
+		if strings.EqualFold(strings.TrimSpace(name), measure.String()) {
+			return measure, nil
+		}
+	}
+	return Donations, errors.New("measure must be Donations, Donors, New Donors, or Major Gifts: " + name)
+}
+
+// ParseFiscalYear returns the fiscal year written as FY2026 or 2026.
+func ParseFiscalYear(value string) (a.FYIndicator, error) {
+	var text = strings.TrimSpace(value)
+	if len(text) > 2 && strings.EqualFold(text[:2], "FY") {
+		text = text[2:]
+	}
+	var number, err = strconv.Atoi(text)
+	if err == nil {
+		for _, fy := range a.FYIndicators {
+			if fy.Number() == number {
+				return fy, nil
+			}
+		}
+	}
+	return a.OutOfRange, errors.New("fiscal year is not a fiscal year of the analysis: " + value)
+}
+
+// ParseMonth returns the number of the month written as October, Oct, or
+// 10, ignoring case.
+func ParseMonth(value string) (int, error) {
+	var text = strings.TrimSpace(value)
+	var number, err = strconv.Atoi(text)
+	if err == nil && number >= 1 && number <= 12 {
+		return number, nil
+	}
+	for month := time.January; month <= time.December; month++ {
+		var name = month.String()
+		if strings.EqualFold(text, name) || strings.EqualFold(text, name[:3]) {
+			return int(month), nil
+		}
+	}
+	return 0, errors.New("invalid month: " + value)
+}
+
+// ----------------------------------------------------------------------------
+// Goals Methods
+// ----------------------------------------------------------------------------
+
+// Count returns the number of goals.
+func (goals *Goals) Count() int {
+	return len(goals.goals)
+}
+
+// ForYear returns the goals of the fiscal year in the order of the goals
+// spreadsheet.
+func (goals *Goals) ForYear(fy a.FYIndicator) []Goal {
+	var result = []Goal{}
+	for _, goal := range goals.goals {
+		if goal.fy == fy {
+			result = append(result, goal)
+		}
+	}
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Measure Methods
+// ----------------------------------------------------------------------------
+
+// String returns the name of the measure.
+func (measure Measure) String() string {
+	assert.Assert(Donations <= measure && measure <= MajorGifts, "invalid measure")
+	return measureNames[measure]
+}
+
+// IsDollars returns true if the measure is an amount of money rather than a
+// number of donors.
+func (measure Measure) IsDollars() bool {
+	return measure == Donations || measure == MajorGifts
+}
+
+// ----------------------------------------------------------------------------
+// Goal Methods
+// ----------------------------------------------------------------------------
+
+// FiscalYear returns the fiscal year of the goal.
+func (goal Goal) FiscalYear() a.FYIndicator {
+	return goal.fy
+}
+
+// Measure returns the measure the goal is for.
+func (goal Goal) Measure() Measure {
+	return goal.measure
+}
+
+// Month returns the number of the calendar month of the goal, or zero for a
+// goal for the whole fiscal year.
+func (goal Goal) Month() int {
+	return goal.month
+}
+
+// IsMonthly returns true if the goal is for a month.
+func (goal Goal) IsMonthly() bool {
+	return goal.month != 0
+}
+
+// YearMonth returns the year and month of a monthly goal.
+func (goal Goal) YearMonth() d.YearMonth {
+	assert.Assert(goal.IsMonthly(), "goal is not for a month: "+goal.Label())
+	return fiscalMonth(goal.fy.Number(), goal.month)
+}
+
+// Campaign returns the campaign of the goal, or an empty string for a goal
+// for all campaigns.
+func (goal Goal) Campaign() string {
+	return goal.campaign
+}
+
+// Amount returns the target of the goal: dollars for Donations and Major
+// Gifts, and a number of donors for Donors and New Donors.
+func (goal Goal) Amount() dec.Decimal {
+	return goal.amount
+}
+
+// Row returns the row of the goal in the goals spreadsheet, as shown by
+// Excel.
+func (goal Goal) Row() int {
+	return goal.row
+}
+
+// Label returns a description of the goal, such as "FY2026 Donations
+// October" or "FY2026 Donors Year-End Appeal".
+func (goal Goal) Label() string {
+	var label = goal.fy.String() + " " + goal.measure.String()
+	if goal.IsMonthly() {
+		label += " " + time.Month(goal.month).String()
+	}
+	if goal.campaign != "" {
+		label += " " + goal.campaign
+	}
+	return label
+}
+
+// ----------------------------------------------------------------------------
+// Support Functions
+// ----------------------------------------------------------------------------
+
+// fiscalMonth returns the year and month of the calendar month in the
+// fiscal year that ends in the calendar year.
+func fiscalMonth(fiscalYear int, month int) d.YearMonth {
+	var year = fiscalYear
+	if month >= int(a.FiscalYear2023Begin.Month()) {
+		year--
+	}
+	var result, err = d.NewYearMonth(year, month)
+	assert.Assert(err == nil, "invalid month: "+strconv.Itoa(month))
+	return result
+}
@@ -0,0 +1,219 @@
+// ----------------------------------------------------------------------------
+//
+// Fundraising goal tests
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package goals
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	a "acorn_go/pkg/accounting"
+	"acorn_go/pkg/campaigns"
+	dna "acorn_go/pkg/donations"
+	ds "acorn_go/pkg/donationseries"
+	dn "acorn_go/pkg/donors"
+	md "acorn_go/pkg/majordonor"
+	"testing"
+
+	dec "github.com/shopspring/decimal"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Test Support
+// ----------------------------------------------------------------------------
+
+// date returns the date for the tests.
+func date(t *testing.T, value string) d.Date {
+	t.Helper()
+	var result, err = d.NewFromString(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+// newGoal returns a goal for the tests.
+func newGoal(t *testing.T, measure Measure, month int, campaign string, amount int64) Goal {
+	var goal, err = NewGoal(a.FY2026, measure, month, campaign, dec.NewFromInt(amount))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return goal
+}
+
+// gift returns a gift of the amount on the date to the campaign.
+func gift(t *testing.T, value string, amount int64, campaign string) dn.Gift {
+	t.Helper()
+	var result = dn.NewGift(date(t, value), dec.NewFromInt(amount), "Payment", "", 0)
+	result.SetCampaign(campaign)
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Tests
+// ----------------------------------------------------------------------------
+
+// TestNewGoal checks the parsing of the goal columns and the goals that are
+// not accepted.
+func TestNewGoal(t *testing.T) {
+	if fy, err := ParseFiscalYear(" fy2026 "); err != nil || fy != a.FY2026 {
+		t.Errorf("ParseFiscalYear: got %v, %v", fy, err)
+	}
+	if fy, err := ParseFiscalYear("2025"); err != nil || fy != a.FY2025 {
+		t.Errorf("ParseFiscalYear: got %v, %v", fy, err)
+	}
+	if _, err := ParseFiscalYear("FY2019"); err == nil {
+		t.Error("fiscal year FY2019 was accepted")
+	}
+	if measure, err := ParseMeasure("new donors"); err != nil || measure != NewDonors {
+		t.Errorf("ParseMeasure: got %v, %v", measure, err)
+	}
+	for _, text := range []string{"October", "oct", "10"} {
+		if month, err := ParseMonth(text); err != nil || month != 10 {
+			t.Errorf("ParseMonth %q: got %d, %v", text, month, err)
+		}
+	}
+	if _, err := ParseMonth("13"); err == nil {
+		t.Error("month 13 was accepted")
+	}
+
+	var goal = newGoal(t, Donations, 1, "", 1000)
+	if goal.YearMonth().Year != 2026 || goal.Label() != "FY2026 Donations January" {
+		t.Errorf("got %s in %d", goal.Label(), goal.YearMonth().Year)
+	}
+	if goal = newGoal(t, Donations, 10, "", 1000); goal.YearMonth().Year != 2025 {
+		t.Errorf("got %s in %d", goal.Label(), goal.YearMonth().Year)
+	}
+	var invalid = []struct {
+		fy       a.FYIndicator
+		measure  Measure
+		month    int
+		campaign string
+		amount   int64
+	}{
+		{a.OutOfRange, Donations, 0, "", 100},
+		{a.FY2026, Donations, 0, "", 0},
+		{a.FY2026, Donations, 13, "", 100},
+		{a.FY2026, Donations, 10, campaigns.YearEndAppeal, 100},
+		{a.FY2026, Donors, 10, "", 100},
+		{a.FY2026, MajorGifts, 0, campaigns.YearEndAppeal, 100},
+	}
+	for index, tt := range invalid {
+		if _, err := NewGoal(tt.fy, tt.measure, tt.month, tt.campaign, dec.NewFromInt(tt.amount)); err == nil {
+			t.Errorf("invalid goal %d was accepted", index)
+		}
+	}
+}
+
+// TestAnalysis checks the actual, percent of goal, pace, and projection of
+// goals for the fiscal year, a month, and a campaign.
+func TestAnalysis(t *testing.T) {
+	var goals = Goals{goals: []Goal{
+		newGoal(t, Donations, 0, "", 5000),
+		newGoal(t, Donors, 0, "", 4),
+		newGoal(t, NewDonors, 0, "", 2),
+		newGoal(t, MajorGifts, 0, "", 3000),
+		newGoal(t, Donations, 10, "", 1000),
+		newGoal(t, Donations, 1, "", 1000),
+		newGoal(t, Donations, 0, campaigns.YearEndAppeal, 2000),
+		newGoal(t, Donors, 0, campaigns.SpringEvent, 10),
+	}}
+	var donationList = make(dna.DonationList)
+	for _, key := range []string{"Ann", "Bob"} {
+		var donor = dn.NewDonorWithDonation(key)
+		donationList[key] = &donor
+	}
+	donationList["Ann"].AddGift(gift(t, "10/01/2024", 500, campaigns.NoCampaign))
+	donationList["Ann"].AddGift(gift(t, "03/01/2025", 500, campaigns.NoCampaign))
+	donationList["Ann"].AddGift(gift(t, "10/15/2025", 800, campaigns.NoCampaign))
+	donationList["Bob"].AddGift(gift(t, "11/01/2025", 1200, campaigns.YearEndAppeal))
+	donationList["Bob"].AddGift(gift(t, "02/01/2026", 100, campaigns.NoCampaign))
+	var series = make(ds.DonationSeries)
+	for _, month := range []struct {
+		year   int
+		month  int
+		amount float64
+	}{
+		{2024, 10, 500},
+		{2025, 10, 800},
+		{2025, 11, 1200},
+	} {
+		var yearMonth, _ = d.NewYearMonth(month.year, month.month)
+		var info = ds.NewDonationInfo()
+		info.AddAmount(month.amount)
+		series[yearMonth] = &info
+	}
+	var analysis, err = NewAnalysis(&goals, donationList, series, md.DefaultTiers, date(t, "12/31/2025"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if analysis.FiscalYear() != a.FY2026 || len(analysis.PriorYears()) != 3 ||
+		analysis.SamePoint(a.FY2025).String() != "12/31/2024" {
+		t.Fatalf("got %s with %d prior years", analysis.FiscalYear().String(), len(analysis.PriorYears()))
+	}
+
+	var tests = []struct {
+		actual    float64
+		percent   float64
+		pace      float64
+		projected float64
+	}{
+		{2000, 40, 300, 4000},
+		{2, 50, 100, 2},
+		{1, 50, 0, 1},
+		{1200, 40, 0, 1200},
+		{800, 80, 60, 800},
+		{0, 0, 0, 0},
+		{1200, 60, 0, 1200},
+		{0, 0, 0, 0},
+	}
+	for index, goal := range analysis.Goals() {
+		var tt = tests[index]
+		if analysis.Actual(goal) != tt.actual || analysis.PercentOfGoal(goal) != tt.percent ||
+			analysis.Pace(goal, a.FY2025) != tt.pace || analysis.Projected(goal) != tt.projected {
+			t.Errorf("%s: got actual %v, %v%%, pace %v%%, projected %v", goal.Label(), analysis.Actual(goal),
+				analysis.PercentOfGoal(goal), analysis.Pace(goal, a.FY2025), analysis.Projected(goal))
+		}
+	}
+	if _, err = NewAnalysis(&goals, donationList, series, md.DefaultTiers, date(t, "12/31/2019")); err == nil {
+		t.Error("report date before the analysis was accepted")
+	}
+	//
+	// A lower tier counts Ann's gifts as major gifts
+	//
+	var tiers = []md.Tier{{Name: "Friend", Minimum: dec.NewFromInt(500)}}
+	analysis, err = NewAnalysis(&goals, donationList, series, tiers, date(t, "12/31/2025"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual := analysis.Actual(analysis.Goals()[3]); actual != 2000 {
+		t.Errorf("Major Gifts with a $500 tier: got actual %v", actual)
+	}
+}
+
+// TestNewDonors checks that a donor who gives again after two years without
+// giving is not a new donor.
+func TestNewDonors(t *testing.T) {
+	var donationList = make(dna.DonationList)
+	for _, key := range []string{"Ann", "Cy"} {
+		var donor = dn.NewDonorWithDonation(key)
+		donationList[key] = &donor
+	}
+	donationList["Ann"].AddGift(gift(t, "10/15/2025", 100, campaigns.NoCampaign))
+	donationList["Cy"].AddGift(gift(t, "10/01/2022", 100, campaigns.NoCampaign))
+	donationList["Cy"].AddGift(gift(t, "11/01/2025", 100, campaigns.NoCampaign))
+	var snapshot = NewSnapshot(donationList, date(t, "12/31/2025"), []string{}, md.DefaultTiers)
+	if snapshot.Value(NewDonors, "", a.FY2026) != 1 || snapshot.Value(NewDonors, "", a.FY2023) != 1 {
+		t.Errorf("got %v new donors in FY2026 and %v in FY2023, want 1 and 1",
+			snapshot.Value(NewDonors, "", a.FY2026), snapshot.Value(NewDonors, "", a.FY2023))
+	}
+}
@@ -0,0 +1,314 @@
+// ----------------------------------------------------------------------------
+//
+// Budget versus Actual
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package goals
+
+// The progress toward a goal is the actual result of the fiscal year from
+// its first day through the report date.  The pace compares the progress
+// with the results of each prior fiscal year through the same point, the
+// same day of the year.  The projection divides the progress by the share
+// of the final result that the prior years had reached by that point, on
+// average, so a year that is ahead of the prior years' pace is projected
+// to finish above their results.
+//
+// The fiscal year results come from the donation and donor count analyses
+// of the gifts made through the report date.  The monthly results come
+// from the donation series, which has the gifts of whole months, so the
+// month of the report date includes the gifts made later in the month.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	a "acorn_go/pkg/accounting"
+	dna "acorn_go/pkg/donations"
+	ds "acorn_go/pkg/donationseries"
+	md "acorn_go/pkg/majordonor"
+	"errors"
+	"math"
+	"slices"
+
+	dec "github.com/shopspring/decimal"
+	"github.com/waysys/assert/assert"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Snapshot holds the results of every fiscal year from the gifts made
+// through a date.
+type Snapshot struct {
+	through    d.Date
+	donations  dna.DonationAnalysis
+	donorCount dna.DonorCountAnalysis
+	campaigns  dna.CampaignAnalysis
+	newDonors  [a.NumFiscalYears]int
+	majorGifts [a.NumFiscalYears]float64
+}
+
+// Analysis compares the goals of a fiscal year with its results through
+// the report date and with the prior fiscal years.
+type Analysis struct {
+	fy          a.FYIndicator
+	asOf        d.Date
+	goals       []Goal
+	current     Snapshot
+	final       Snapshot
+	priorToDate []Snapshot
+	series      ds.DonationSeries
+}
+
+// ----------------------------------------------------------------------------
+// Factory Functions
+// ----------------------------------------------------------------------------
+
+// NewSnapshot computes the results of the gifts in the donation list made
+// on or before the date.  The campaigns are included in the campaign
+// results even if they have no gifts.  The new donors of a fiscal year are
+// the donors whose first gift ever was made in it, as for a campaign.  The
+// major gifts are the donations of donors whose giving in a fiscal year
+// reaches the lowest of the tiers.
+func NewSnapshot(donationList dna.DonationList, through d.Date, campaigns []string, tiers []md.Tier) Snapshot {
+	var list = donationList.Through(through)
+	var snapshot = Snapshot{
+		through:    through,
+		donations:  dna.ComputeDonations(list),
+		donorCount: dna.ComputeDonorCount(list),
+		campaigns:  dna.ComputeCampaigns(list, campaigns),
+	}
+	for _, donor := range list {
+		for _, gift := range donor.Gifts() {
+			if gift.Amount().GreaterThan(dec.Zero) {
+				if fy := a.FiscalYearIndicator(gift.Date()); a.IsFYIndicator(fy) {
+					snapshot.newDonors[fy]++
+				}
+				break
+			}
+		}
+		for _, fy := range a.FYIndicators {
+			var amount = donor.Donation(fy)
+			if md.TierOf(tiers, amount) != md.NotMajor {
+				snapshot.majorGifts[fy] += amount.InexactFloat64()
+			}
+		}
+	}
+	return snapshot
+}
+
+// NewAnalysis compares the goals of the fiscal year of the report date with
+// the results of the gifts in the donation list and the donation series.
+// The major donor tiers set the threshold of the major gifts.
+func NewAnalysis(
+	goals *Goals,
+	donationList dna.DonationList,
+	series ds.DonationSeries,
+	tiers []md.Tier,
+	asOf d.Date) (Analysis, error) {
+
+	var fy = a.FiscalYearIndicator(asOf)
+	if !a.IsFYIndicator(fy) {
+		return Analysis{}, errors.New("report date is not in a fiscal year of the analysis: " + asOf.String())
+	}
+	var analysis = Analysis{
+		fy:          fy,
+		asOf:        asOf,
+		goals:       goals.ForYear(fy),
+		priorToDate: []Snapshot{},
+		series:      series,
+	}
+	var campaigns = []string{}
+	for _, goal := range analysis.goals {
+		if goal.campaign != "" {
+			campaigns = append(campaigns, goal.campaign)
+		}
+	}
+	//
+	// Compute the results through the report date, the final results of
+	// the prior years, and their results through the same point
+	//
+	analysis.current = NewSnapshot(donationList, asOf, campaigns, tiers)
+	analysis.final = NewSnapshot(donationList, fy.End(), campaigns, tiers)
+	var samePoint = asOf
+	for prior := fy.Prior(); prior != a.OutOfRange; prior = prior.Prior() {
+		samePoint = a.SameDayPriorYear(samePoint)
+		analysis.priorToDate = append(analysis.priorToDate, NewSnapshot(donationList, samePoint, campaigns, tiers))
+	}
+	return analysis, nil
+}
+
+// ----------------------------------------------------------------------------
+// Snapshot Methods
+// ----------------------------------------------------------------------------
+
+// Through returns the date of the last gift included in the results.
+func (snapshot *Snapshot) Through() d.Date {
+	return snapshot.through
+}
+
+// Value returns the result of the measure in the fiscal year.  If the
+// campaign is not empty, it is the result of the campaign.
+func (snapshot *Snapshot) Value(measure Measure, campaign string, fy a.FYIndicator) float64 {
+	assert.Assert(a.IsFYIndicator(fy), "Invalid fiscal year indicator: "+fy.String())
+	if campaign != "" {
+		switch measure {
+		case Donations:
+			return snapshot.campaigns.Amount(campaign, fy).InexactFloat64()
+		case Donors:
+			return float64(snapshot.campaigns.Donors(campaign, fy))
+		case NewDonors:
+			return float64(snapshot.campaigns.NewDonors(campaign, fy))
+		}
+	}
+	switch measure {
+	case Donations:
+		return snapshot.donations.DonationFiscalYear(fy)
+	case Donors:
+		return float64(snapshot.donorCount.DonorCountFiscalYear(fy))
+	case NewDonors:
+		return float64(snapshot.newDonors[fy])
+	default:
+		return snapshot.majorGifts[fy]
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Analysis Methods
+// ----------------------------------------------------------------------------
+
+// FiscalYear returns the fiscal year of the report date.
+func (analysis *Analysis) FiscalYear() a.FYIndicator {
+	return analysis.fy
+}
+
+// AsOf returns the report date.
+func (analysis *Analysis) AsOf() d.Date {
+	return analysis.asOf
+}
+
+// Goals returns the goals of the fiscal year in the order of the goals
+// spreadsheet.
+func (analysis *Analysis) Goals() []Goal {
+	return slices.Clone(analysis.goals)
+}
+
+// PriorYears returns the prior fiscal years, most recent first.
+func (analysis *Analysis) PriorYears() []a.FYIndicator {
+	var years = []a.FYIndicator{}
+	for prior := analysis.fy.Prior(); prior != a.OutOfRange; prior = prior.Prior() {
+		years = append(years, prior)
+	}
+	return years
+}
+
+// SamePoint returns the same day as the report date in the prior fiscal
+// year.
+func (analysis *Analysis) SamePoint(prior a.FYIndicator) d.Date {
+	return analysis.priorToDate[analysis.priorIndex(prior)].through
+}
+
+// Actual returns the progress toward the goal.  The actual of a monthly
+// goal is the donations of the month, or zero for a month after the month
+// of the report date.
+func (analysis *Analysis) Actual(goal Goal) float64 {
+	if goal.IsMonthly() {
+		var yearMonth = goal.YearMonth()
+		if analysis.isAfterReport(yearMonth) {
+			return 0.0
+		}
+		return analysis.series.GetAmount(yearMonth)
+	}
+	return analysis.current.Value(goal.measure, goal.campaign, analysis.fy)
+}
+
+// PercentOfGoal returns the actual as a percent of the goal.
+func (analysis *Analysis) PercentOfGoal(goal Goal) float64 {
+	return a.Percent(analysis.Actual(goal), goal.amount.InexactFloat64())
+}
+
+// Remaining returns the amount still needed to reach the goal, or zero if
+// the goal has been reached.
+func (analysis *Analysis) Remaining(goal Goal) float64 {
+	return math.Max(goal.amount.InexactFloat64()-analysis.Actual(goal), 0.0)
+}
+
+// PriorToDate returns the result of the measure of the goal in the prior
+// fiscal year through the same point.  For a monthly goal, it is the
+// donations of the same month of the prior year.
+func (analysis *Analysis) PriorToDate(goal Goal, prior a.FYIndicator) float64 {
+	if goal.IsMonthly() {
+		return analysis.series.GetAmount(fiscalMonth(prior.Number(), goal.month))
+	}
+	var snapshot = analysis.priorToDate[analysis.priorIndex(prior)]
+	return snapshot.Value(goal.measure, goal.campaign, prior)
+}
+
+// PriorFinal returns the result of the measure of the goal for the whole
+// prior fiscal year.  For a monthly goal, it is the donations of the same
+// month of the prior year.
+func (analysis *Analysis) PriorFinal(goal Goal, prior a.FYIndicator) float64 {
+	if goal.IsMonthly() {
+		return analysis.series.GetAmount(fiscalMonth(prior.Number(), goal.month))
+	}
+	assert.Assert(prior < analysis.fy, "not a prior fiscal year: "+prior.String())
+	return analysis.final.Value(goal.measure, goal.campaign, prior)
+}
+
+// Pace returns the percent change of the actual from the result of the
+// prior fiscal year through the same point.
+func (analysis *Analysis) Pace(goal Goal, prior a.FYIndicator) float64 {
+	return a.PercentChange(analysis.PriorToDate(goal, prior), analysis.Actual(goal))
+}
+
+// Projected returns the result of the fiscal year projected from the actual
+// at the pace of the prior fiscal years.  The projection is the actual if
+// no prior year has a result or the goal is for a month.
+func (analysis *Analysis) Projected(goal Goal) float64 {
+	var actual = analysis.Actual(goal)
+	if goal.IsMonthly() {
+		return actual
+	}
+	var share = 0.0
+	var count = 0
+	for _, prior := range analysis.PriorYears() {
+		var final = analysis.PriorFinal(goal, prior)
+		if final > 0.0 {
+			share += analysis.PriorToDate(goal, prior) / final
+			count++
+		}
+	}
+	if count == 0 || share == 0.0 {
+		return actual
+	}
+	return actual * float64(count) / share
+}
+
+// ProjectedPercent returns the projection as a percent of the goal.
+func (analysis *Analysis) ProjectedPercent(goal Goal) float64 {
+	return a.Percent(analysis.Projected(goal), goal.amount.InexactFloat64())
+}
+
+// isAfterReport returns true if the month is after the month of the report
+// date.
+func (analysis *Analysis) isAfterReport(yearMonth d.YearMonth) bool {
+	var year, month = int(analysis.asOf.Year()), int(analysis.asOf.Month())
+	return yearMonth.Year > year || (yearMonth.Year == year && yearMonth.Month > month)
+}
+
+// priorIndex returns the index of the prior fiscal year in the results
+// through the same point.
+func (analysis *Analysis) priorIndex(prior a.FYIndicator) int {
+	var index = int(analysis.fy) - int(prior) - 1
+	assert.Assert(a.IsFYIndicator(prior) && 0 <= index && index < len(analysis.priorToDate),
+		"not a prior fiscal year: "+prior.String())
+	return index
+}
@@ -0,0 +1,84 @@
+// ----------------------------------------------------------------------------
+//
+// Grant tests
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package grants
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	a "acorn_go/pkg/accounting"
+	q "acorn_go/pkg/quickbooks"
+	r "acorn_go/pkg/redact"
+	"testing"
+
+	dec "github.com/shopspring/decimal"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Test Support
+// ----------------------------------------------------------------------------
+
+// date returns the date for the tests.
+func date(t *testing.T, value string) d.Date {
+	t.Helper()
+	var result, err = d.NewFromString(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Tests
+// ----------------------------------------------------------------------------
+
+// Test_RecipientSumRedacted checks that the recipient summaries are kept
+// under the recipients' names when redaction is enabled.
+func Test_RecipientSumRedacted(t *testing.T) {
+	r.Enable("secret")
+	defer r.Disable()
+
+	var ann = q.NewRecipient("Apple, Ann")
+	var bob = q.NewRecipient("Baker, Bob")
+	var school = q.NewVendor("State University")
+	var grantList = NewGrantList()
+	for _, transaction := range []Transaction{
+		NewTransaction(date(t, "10/01/2025"), Grant, &ann, &school, dec.NewFromInt(2000), "7040"),
+		NewTransaction(date(t, "11/01/2025"), GrantPayment, &ann, &school, dec.NewFromInt(1000), "7040"),
+		NewTransaction(date(t, "12/01/2025"), GrantPayment, &ann, &school, dec.NewFromInt(1000), "7040"),
+		NewTransaction(date(t, "01/15/2026"), Refund, &ann, &school, dec.NewFromInt(250), "7040"),
+		NewTransaction(date(t, "11/15/2025"), GrantPayment, &bob, &school, dec.NewFromInt(500), "7040"),
+	} {
+		grantList.Add(&transaction)
+	}
+
+	var list, err = AssembleRecipientSumList(&grantList)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names = list.Names()
+	if len(names) != 2 || names[0] != "Apple, Ann" || names[1] != "Baker, Bob" {
+		t.Fatalf("got recipients %v", names)
+	}
+	var sum *RecipientSum
+	sum, err = list.Get("Apple, Ann")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sum.PaymentTotal(a.FY2026).Equal(dec.NewFromInt(2000)) || !sum.GrantTotal(a.FY2026).Equal(dec.NewFromInt(2000)) {
+		t.Errorf("got payments %s and grants %s", sum.PaymentTotal(a.FY2026).String(), sum.GrantTotal(a.FY2026).String())
+	}
+	if pseudonym := r.Name(r.Recipient, sum.RecipientName()); pseudonym == "Apple, Ann" {
+		t.Error("recipient name is not redacted for output: " + pseudonym)
+	}
+}
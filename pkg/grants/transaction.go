@@ -18,6 +18,7 @@ package grants
 import (
 	a "acorn_go/pkg/accounting"
 	q "acorn_go/pkg/quickbooks"
+	r "acorn_go/pkg/redact"
 	"strconv"
 
 	dec "github.com/shopspring/decimal"
@@ -91,9 +92,14 @@ func (trans *Transaction) Recipient() string {
 }
 
 // EducationalInstitution returns a string with the name of the
-// educational institution
+// educational institution.  For individual grants, the vendor is the
+// recipient, so the name is redacted like the recipient's name.
 func (trans *Transaction) EducationalInstitution() string {
-	return trans.edInst.Name()
+	var name = trans.edInst.Name()
+	if trans.Account() == q.AccountIndividualGrant {
+		name = r.Name(r.Recipient, q.ConvertName(name))
+	}
+	return name
 }
 
 // Amount returns the amount of the transaction.
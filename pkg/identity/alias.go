@@ -0,0 +1,135 @@
+// ----------------------------------------------------------------------------
+//
+// Alias table
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package identity
+
+// The alias table maps Quickbooks payees to donors in the donor address
+// list.  It is kept in a spreadsheet with a Payee column and a
+// "Donor full name" column, and it grows as matches are approved.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	s "acorn_go/pkg/spreadsheet"
+	sp "acorn_go/pkg/support"
+	"errors"
+	"os"
+	"sort"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// AliasTable maps payees to the keys of donors.
+type AliasTable map[string]string
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Alias column names
+const (
+	columnPayee = "Payee"
+	columnDonor = "Donor full name"
+)
+
+// ----------------------------------------------------------------------------
+// Factory Functions
+// ----------------------------------------------------------------------------
+
+// NewAliasTable creates an alias table from the rows of the alias
+// spreadsheet.
+func NewAliasTable(sprdsht *s.Spreadsheet) (AliasTable, error) {
+	var aliases = make(AliasTable)
+	var numRows = sprdsht.Size()
+	for row := 1; row < numRows; row++ {
+		var payee, err = sprdsht.Cell(row, columnPayee)
+		var key string
+		if err == nil {
+			key, err = sprdsht.Cell(row, columnDonor)
+		}
+		if err == nil {
+			err = aliases.Add(payee, key)
+		}
+		if err != nil {
+			return aliases, sprdsht.WrapRow(err, "processing alias", row)
+		}
+	}
+	return aliases, nil
+}
+
+// ReadAliases reads the alias table from a spreadsheet.  If the file does
+// not exist, the table is empty.
+func ReadAliases(fileName string, tab string) (AliasTable, error) {
+	if _, err := os.Stat(fileName); errors.Is(err, os.ErrNotExist) {
+		return make(AliasTable), nil
+	}
+	var sprdsht, err = s.ProcessData(fileName, tab)
+	if err != nil {
+		return make(AliasTable), err
+	}
+	return NewAliasTable(&sprdsht)
+}
+
+// ----------------------------------------------------------------------------
+// Methods
+// ----------------------------------------------------------------------------
+
+// Add maps a payee to a donor key.  An error is returned if either is
+// empty or the payee is already mapped to another donor.
+func (aliases AliasTable) Add(payee string, key string) error {
+	if payee == "" || key == "" {
+		return errors.New("payee and donor must not be empty")
+	}
+	if other, found := aliases[payee]; found && other != key {
+		return errors.New("payee is already an alias of " + other + ": " + payee)
+	}
+	aliases[payee] = key
+	return nil
+}
+
+// Lookup returns the donor key of a payee.  The second value is false if
+// the payee has no alias.
+func (aliases AliasTable) Lookup(payee string) (string, bool) {
+	var key, found = aliases[payee]
+	return key, found
+}
+
+// Payees returns the alphabetically sorted payees in the table.
+func (aliases AliasTable) Payees() []string {
+	var payees = make([]string, 0, len(aliases))
+	for payee := range aliases {
+		payees = append(payees, payee)
+	}
+	sort.Strings(payees)
+	return payees
+}
+
+// Write saves the alias table in a spreadsheet, replacing the file.
+func (aliases AliasTable) Write(fileName string, tab string) (err error) {
+	var output s.SpreadsheetFile
+	output, err = s.New(fileName, tab)
+	if err != nil {
+		return sp.WrapFile(err, sp.Output, "opening alias file", fileName)
+	}
+	defer func() {
+		err = errors.Join(err, output.Save(), output.Close())
+	}()
+	s.WriteCell(&output, "A", 1, columnPayee)
+	s.WriteCell(&output, "B", 1, columnDonor)
+	for index, payee := range aliases.Payees() {
+		s.WriteCell(&output, "A", index+2, payee)
+		s.WriteCell(&output, "B", index+2, aliases[payee])
+	}
+	return err
+}
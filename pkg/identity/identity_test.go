@@ -0,0 +1,157 @@
+// ----------------------------------------------------------------------------
+//
+// Identity Tests
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package identity
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+// ----------------------------------------------------------------------------
+// Test functions
+// ----------------------------------------------------------------------------
+
+// Test_Normalize checks the normalization of names.
+func Test_Normalize(t *testing.T) {
+	var tests = []struct {
+		name     string
+		expected string
+	}{
+		{"Abernathy, Michael", "michael abernathy"},
+		{"Michael Abernathy", "michael abernathy"},
+		{"Mr. & Mrs. John O'Neil Jr.", "john oneil jr"},
+		{"ONeil, John Jr.", "john oneil jr"},
+		{"Smith, John and Mary", "john mary smith"},
+		{"Mary & John Smith", "mary john smith"},
+		{"Dr. Ann Lee-Hart, PhD", "ann lee hart"},
+		{"Thomas James", "thomas james"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if result := Normalize(tt.name); result != tt.expected {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.name, result, tt.expected)
+		}
+	}
+}
+
+// Test_Similarity checks the similarity scores of names.
+func Test_Similarity(t *testing.T) {
+	var tests = []struct {
+		first  string
+		second string
+		low    int
+		high   int
+	}{
+		{"abernathy michael", "abernathy michael", 100, 100},
+		{"abernathy micheal", "abernathy michael", 85, 100},
+		{"abernathy m", "abernathy michael", 100, 100},
+		{"john smith", "john mary smith", 80, 80},
+		{"abernathy michael", "caldwell susan", 0, 40},
+		{"", "caldwell susan", 0, 0},
+	}
+	for _, tt := range tests {
+		var score = Similarity(tt.first, tt.second)
+		if score < tt.low || score > tt.high {
+			t.Errorf("Similarity(%q, %q) = %d, want %d to %d", tt.first, tt.second, score, tt.low, tt.high)
+		}
+	}
+}
+
+// Test_Resolve checks each way a payee is matched to a donor.
+func Test_Resolve(t *testing.T) {
+	var keys = []string{
+		"Abernathy, Michael",
+		"Caldwell, Susan",
+		"Dunbar, Mark",
+		"Dunbar, Mary",
+		"Lee, Ann",
+		"Lee, Anne",
+		"Smith, John Sr.",
+		"Thomas, James",
+	}
+	var aliases = make(AliasTable)
+	if err := aliases.Add("Caldwell Family Fund", "Caldwell, Susan"); err != nil {
+		t.Fatal(err)
+	}
+	var resolver = NewResolver(keys, aliases)
+	var tests = []struct {
+		payee  string
+		key    string
+		status Status
+	}{
+		{"Abernathy, Michael", "Abernathy, Michael", Exact},
+		{"Caldwell Family Fund", "Caldwell, Susan", Alias},
+		{"Michael Abernathy", "Abernathy, Michael", Normalized},
+		{"Abernathy, Micheal", "Abernathy, Michael", Similar},
+		{"Dunbar, M.", "", Ambiguous},
+		{"Dunbar, Marz", "", Ambiguous},
+		{"Lee, Annie", "Lee, Anne", Similar},
+		{"Fairchild, George", "", Unmatched},
+		{"James Thomas", "Thomas, James", Normalized},
+		{"Mr. John Smith Sr.", "Smith, John Sr.", Normalized},
+	}
+	for _, tt := range tests {
+		var match = resolver.Resolve(tt.payee)
+		if match.Key() != tt.key || match.Status() != tt.status {
+			t.Errorf("%s: got %q %s, want %q %s", tt.payee, match.Key(), match.Status().String(), tt.key, tt.status.String())
+		}
+	}
+	if resolver.KeyOf("Fairchild, George") != "Fairchild, George" || resolver.KeyOf("Michael Abernathy") != "Abernathy, Michael" {
+		t.Error("incorrect keys of payees")
+	}
+	if resolver.KeyOf("Abernathy, Micheal") != "Abernathy, Micheal" || resolver.Resolve("Lee, Annie").Resolved() {
+		t.Error("similar match was resolved without approval")
+	}
+	for _, payee := range []string{"Thomas James", "John Smith Jr."} {
+		if match := resolver.Resolve(payee); match.Resolved() {
+			t.Errorf("%s: different person was resolved to %q", payee, match.Key())
+		}
+	}
+	if candidates := resolver.Resolve("Dunbar, M.").Candidates(); len(candidates) != 2 {
+		t.Errorf("expected two candidates, got %d", len(candidates))
+	}
+}
+
+// Test_Aliases checks that an alias table is written and read back.
+func Test_Aliases(t *testing.T) {
+	var aliases = make(AliasTable)
+	var err = aliases.Add("Caldwell Family Fund", "Caldwell, Susan")
+	if err == nil {
+		err = aliases.Add("Abernathy, Micheal", "Abernathy, Michael")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aliases.Add("Caldwell Family Fund", "Dunbar, Mark") == nil {
+		t.Error("payee was mapped to two donors")
+	}
+	var fileName = filepath.Join(t.TempDir(), "aliases.xlsx")
+	var empty AliasTable
+	empty, err = ReadAliases(fileName, "Sheet1")
+	if err != nil || len(empty) != 0 {
+		t.Error("missing alias file is not an empty table")
+	}
+	if err = aliases.Write(fileName, "Sheet1"); err != nil {
+		t.Fatal(err)
+	}
+	var result AliasTable
+	result, err = ReadAliases(fileName, "Sheet1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result) != 2 || result["Abernathy, Micheal"] != "Abernathy, Michael" {
+		t.Error("alias table was not read back")
+	}
+}
@@ -0,0 +1,171 @@
+// ----------------------------------------------------------------------------
+//
+// Name normalization
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+// The identity package matches the payee names in Quickbooks to the donor
+// names in the donor address list.  A payee matches a donor when the names
+// are the same, when the alias table maps the payee to the donor, when the
+// normalized names are the same, or when the names are similar enough.
+// Payees that match no donor, or more than one, are listed for review, and
+// approved matches are saved in the alias table.
+package identity
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// ignoredWords are the titles, degrees, and conjunctions left out of a
+// normalized name.  Generational suffixes such as Jr. and Sr. are kept
+// because they tell a parent from a child.
+var ignoredWords = map[string]bool{
+	"and": true, "mr": true, "mrs": true, "ms": true, "miss": true, "dr": true,
+	"rev": true, "prof": true, "md": true, "phd": true, "esq": true, "cpa": true,
+}
+
+// suffixWords are the generational suffixes placed at the end of a
+// normalized name.
+var suffixWords = map[string]bool{
+	"jr": true, "sr": true, "ii": true, "iii": true, "iv": true,
+}
+
+// ----------------------------------------------------------------------------
+// Functions
+// ----------------------------------------------------------------------------
+
+// Normalize returns the words of a name in lower case, separated by single
+// spaces.  A name in the form "last, first" is treated as "first last", and
+// otherwise the words keep their order, so "James Thomas" and "Thomas
+// James" stay different.  Apostrophes and periods are removed, other
+// punctuation separates words, "&" is treated as "and", titles, degrees,
+// and "and" are left out, and suffixes such as Jr. are moved to the end.
+// "Mr. & Mrs. John O'Neil Jr." and "ONeil, John Jr." both normalize to
+// "john oneil jr".
+func Normalize(name string) string {
+	var words = strings.Fields(normalizedText(name))
+	var result = []string{}
+	var suffixes = []string{}
+	for _, word := range words {
+		switch {
+		case ignoredWords[word] || slices.Contains(result, word) || slices.Contains(suffixes, word):
+			continue
+		case suffixWords[word]:
+			suffixes = append(suffixes, word)
+		default:
+			result = append(result, word)
+		}
+	}
+	return strings.Join(append(result, suffixes...), " ")
+}
+
+// normalizedText returns the name in lower case with the last name moved
+// to the end and the punctuation replaced.
+func normalizedText(name string) string {
+	var text = strings.ToLower(name)
+	if last, rest, found := strings.Cut(text, ","); found {
+		text = rest + " " + last
+	}
+	var builder strings.Builder
+	for _, char := range text {
+		switch {
+		case char == '\'' || char == '.' || char == '’':
+			continue
+		case char == '&':
+			builder.WriteString(" and ")
+		case unicode.IsLetter(char) || unicode.IsDigit(char):
+			builder.WriteRune(char)
+		default:
+			builder.WriteRune(' ')
+		}
+	}
+	return builder.String()
+}
+
+// Similarity returns a score from 0 to 100 of how alike two normalized
+// names are.  It is the greater of the share of words the names have in
+// common and the share of characters that need no edit.  Words are in
+// common if they are equal, one is the initial of the other, or a word of
+// five or more letters differs from the other by one edit.
+func Similarity(first string, second string) int {
+	if first == "" || second == "" {
+		return 0
+	}
+	if first == second {
+		return 100
+	}
+	var wordScore = 200 * commonWords(strings.Fields(first), strings.Fields(second)) /
+		(len(strings.Fields(first)) + len(strings.Fields(second)))
+	var length = max(len(first), len(second))
+	var editScore = 100 * (length - distance(first, second)) / length
+	return max(wordScore, editScore)
+}
+
+// commonWords returns the number of words of the first name that match a
+// different word of the second name.
+func commonWords(first []string, second []string) int {
+	var used = make([]bool, len(second))
+	var count = 0
+	for _, word := range first {
+		for index, other := range second {
+			if !used[index] && wordsMatch(word, other) {
+				used[index] = true
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+// wordsMatch returns true if the words are equal, one is the initial of
+// the other, or a long word differs by one edit.
+func wordsMatch(word string, other string) bool {
+	switch {
+	case word == other:
+		return true
+	case len(word) == 1 || len(other) == 1:
+		return word[0] == other[0]
+	case len(word) >= 5 && len(other) >= 5:
+		return distance(word, other) <= 1
+	}
+	return false
+}
+
+// distance returns the number of single character insertions, deletions,
+// and substitutions needed to change one string into the other.
+func distance(first string, second string) int {
+	var a = []rune(first)
+	var b = []rune(second)
+	var prior = make([]int, len(b)+1)
+	var current = make([]int, len(b)+1)
+	for j := range prior {
+		prior[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			var cost = 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(prior[j]+1, current[j-1]+1, prior[j-1]+cost)
+		}
+		prior, current = current, prior
+	}
+	return prior[len(b)]
+}
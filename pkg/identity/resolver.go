@@ -0,0 +1,256 @@
+// ----------------------------------------------------------------------------
+//
+// Resolver
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package identity
+
+// The resolver finds the donor for a payee.  It tries, in order, the exact
+// name, the alias table, the normalized name, and the similarity of the
+// normalized names.  A similar donor is proposed only if its score is at
+// least MatchScore and no other donor comes within AmbiguousMargin of it.
+// Different donors can have similar names, so a similar match is not used
+// until it is reviewed and approved into the alias table.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"sort"
+
+	"github.com/waysys/assert/assert"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Status tells how a payee was matched to a donor.
+type Status int
+
+// Candidate is a donor that may be the payee, with its similarity score.
+type Candidate struct {
+	Key   string
+	Score int
+}
+
+// Match is the result of resolving a payee.
+type Match struct {
+	payee      string
+	key        string
+	status     Status
+	candidates []Candidate
+}
+
+// Resolver matches payees to the keys of the donors in the donor address
+// list.
+type Resolver struct {
+	keys       []string
+	normalized map[string][]string
+	aliases    AliasTable
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const NumStatuses = 6
+
+const (
+	Exact      = Status(0) // the payee is the donor's name
+	Alias      = Status(1) // the alias table maps the payee to the donor
+	Normalized = Status(2) // the normalized names are the same
+	Similar    = Status(3) // the names are similar
+	Ambiguous  = Status(4) // more than one donor matches
+	Unmatched  = Status(5) // no donor matches
+)
+
+var Statuses = [NumStatuses]Status{
+	Exact,
+	Alias,
+	Normalized,
+	Similar,
+	Ambiguous,
+	Unmatched,
+}
+
+var statusNames = [NumStatuses]string{
+	"Exact",
+	"Alias",
+	"Normalized",
+	"Similar",
+	"Ambiguous",
+	"Unmatched",
+}
+
+// MatchScore is the lowest similarity score accepted as a match.
+const MatchScore = 85
+
+// AmbiguousMargin is the amount by which the best score must exceed the
+// next best for a similar donor to be accepted.
+const AmbiguousMargin = 5
+
+// CandidateScore is the lowest similarity score of a candidate listed for
+// review.
+const CandidateScore = 60
+
+// MaxCandidates is the largest number of candidates listed for a payee.
+const MaxCandidates = 3
+
+// ----------------------------------------------------------------------------
+// Factory Functions
+// ----------------------------------------------------------------------------
+
+// NewResolver creates a resolver for the donor keys and the alias table.
+func NewResolver(keys []string, aliases AliasTable) Resolver {
+	var resolver = Resolver{
+		keys:       append([]string{}, keys...),
+		normalized: make(map[string][]string),
+		aliases:    aliases,
+	}
+	sort.Strings(resolver.keys)
+	for _, key := range resolver.keys {
+		var name = Normalize(key)
+		resolver.normalized[name] = append(resolver.normalized[name], key)
+	}
+	return resolver
+}
+
+// ----------------------------------------------------------------------------
+// Resolver Methods
+// ----------------------------------------------------------------------------
+
+// Resolve returns the match of the payee to a donor.
+func (resolver *Resolver) Resolve(payee string) Match {
+	var match = Match{payee: payee, status: Unmatched, candidates: []Candidate{}}
+	//
+	// Exact name and alias
+	//
+	if resolver.contains(payee) {
+		match.key, match.status = payee, Exact
+		return match
+	}
+	if key, found := resolver.aliases.Lookup(payee); found && resolver.contains(key) {
+		match.key, match.status = key, Alias
+		return match
+	}
+	//
+	// Normalized name
+	//
+	var name = Normalize(payee)
+	var same = resolver.normalized[name]
+	if len(same) == 1 {
+		match.key, match.status = same[0], Normalized
+		return match
+	}
+	if len(same) > 1 {
+		for _, key := range same {
+			match.candidates = append(match.candidates, Candidate{Key: key, Score: 100})
+		}
+		match.status = Ambiguous
+		return match
+	}
+	//
+	// Similar names
+	//
+	for _, key := range resolver.keys {
+		var score = Similarity(name, Normalize(key))
+		if score >= CandidateScore {
+			match.candidates = append(match.candidates, Candidate{Key: key, Score: score})
+		}
+	}
+	sort.SliceStable(match.candidates, func(i int, j int) bool {
+		return match.candidates[i].Score > match.candidates[j].Score
+	})
+	if len(match.candidates) > MaxCandidates {
+		match.candidates = match.candidates[:MaxCandidates]
+	}
+	if len(match.candidates) > 0 && match.candidates[0].Score >= MatchScore {
+		match.status = Ambiguous
+		if len(match.candidates) == 1 || match.candidates[0].Score-match.candidates[1].Score >= AmbiguousMargin {
+			match.key, match.status = match.candidates[0].Key, Similar
+		}
+	}
+	return match
+}
+
+// KeyOf returns the donor key of the payee, or the payee if it is not
+// resolved to a donor.
+func (resolver *Resolver) KeyOf(payee string) string {
+	var match = resolver.Resolve(payee)
+	var key = payee
+	if match.Resolved() {
+		key = match.Key()
+	}
+	return key
+}
+
+// contains returns true if the key is the key of a donor.
+func (resolver *Resolver) contains(key string) bool {
+	var index = sort.SearchStrings(resolver.keys, key)
+	return index < len(resolver.keys) && resolver.keys[index] == key
+}
+
+// ----------------------------------------------------------------------------
+// Match Methods
+// ----------------------------------------------------------------------------
+
+// String returns the name of the status.
+func (status Status) String() string {
+	assert.Assert(Exact <= status && status < NumStatuses, "Invalid match status")
+	return statusNames[status]
+}
+
+// Payee returns the payee that was resolved.
+func (match Match) Payee() string {
+	return match.payee
+}
+
+// Key returns the key of the donor matched, or the donor proposed for a
+// similar match.  It is empty if the payee is ambiguous or unmatched.
+func (match Match) Key() string {
+	return match.key
+}
+
+// Status returns how the payee was matched.
+func (match Match) Status() Status {
+	return match.status
+}
+
+// Resolved returns true if the payee was matched to a single donor by
+// name, alias, or normalized name.  A similar match is not resolved until
+// it is approved into the alias table.
+func (match Match) Resolved() bool {
+	return match.status <= Normalized
+}
+
+// NeedsReview returns true if the match should be reviewed: the payee was
+// matched by similarity, matched more than one donor, or matched none.
+func (match Match) NeedsReview() bool {
+	return match.status >= Similar
+}
+
+// Score returns the similarity score of the donor matched, 100 for a
+// match by name or alias, or 0 for a payee that was not resolved.
+func (match Match) Score() int {
+	var score = 0
+	switch {
+	case match.status == Similar:
+		score = match.candidates[0].Score
+	case match.Resolved():
+		score = 100
+	}
+	return score
+}
+
+// Candidates returns the donors that may be the payee, from the highest
+// score to the lowest.
+func (match Match) Candidates() []Candidate {
+	return append([]Candidate{}, match.candidates...)
+}
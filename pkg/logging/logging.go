@@ -0,0 +1,245 @@
+// ----------------------------------------------------------------------------
+//
+// Logging
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+// The logging package provides the logger shared by the programs.  Messages
+// have a level and contextual fields such as the command, file, and row, and
+// are written as text or JSON.  Warnings are also collected so that a program
+// can summarize them when it finishes.
+package logging
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Format is the output format of the log.
+type Format string
+
+// Warning is a warning logged while a program ran.
+type Warning struct {
+	Message string
+	Attrs   []slog.Attr
+}
+
+// collector is a slog handler that records warnings before passing each
+// record to the handler that writes it.
+type collector struct {
+	handler slog.Handler
+	attrs   []slog.Attr
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const (
+	Text Format = "text"
+	JSON Format = "json"
+)
+
+// Environmental variables that configure the log
+const (
+	FormatVariable = "ACORN_LOG_FORMAT"
+	LevelVariable  = "ACORN_LOG_LEVEL"
+)
+
+// Keys of the contextual fields
+const (
+	KeyCommand = "command"
+	KeyFile    = "file"
+	KeySheet   = "sheet"
+	KeyRow     = "row"
+)
+
+var logger = New(os.Stderr, Text, slog.LevelInfo)
+
+var warnings []Warning
+var mutex sync.Mutex
+
+// ----------------------------------------------------------------------------
+// Factory Functions
+// ----------------------------------------------------------------------------
+
+// New returns a logger that writes messages at or above the level to the
+// writer in the specified format.  Warnings are collected.
+func New(writer io.Writer, format Format, level slog.Level) *slog.Logger {
+	var handler slog.Handler
+	var options = slog.HandlerOptions{Level: level}
+
+	if format == JSON {
+		handler = slog.NewJSONHandler(writer, &options)
+	} else {
+		handler = slog.NewTextHandler(writer, &options)
+	}
+	return slog.New(&collector{handler: handler})
+}
+
+// ----------------------------------------------------------------------------
+// Functions
+// ----------------------------------------------------------------------------
+
+// Init configures the logger for a program.  The format and level are taken
+// from ACORN_LOG_FORMAT (text or json) and ACORN_LOG_LEVEL (debug, info,
+// warn, or error).  Every message includes the name of the command.
+func Init(command string) {
+	var format = Format(strings.ToLower(os.Getenv(FormatVariable)))
+	var level = slog.LevelInfo
+	var value = os.Getenv(LevelVariable)
+	if value != "" {
+		var err = level.UnmarshalText([]byte(value))
+		if err != nil {
+			level = slog.LevelInfo
+		}
+	}
+	logger = New(os.Stderr, format, level).With(KeyCommand, command)
+	slog.SetDefault(logger)
+	ResetWarnings()
+}
+
+// SetLogger replaces the logger.
+func SetLogger(newLogger *slog.Logger) {
+	logger = newLogger
+}
+
+// Logger returns the logger.
+func Logger() *slog.Logger {
+	return logger
+}
+
+// With returns a logger that includes the fields in every message.
+func With(args ...any) *slog.Logger {
+	return logger.With(args...)
+}
+
+// Debug logs a message at the debug level.
+func Debug(message string, args ...any) {
+	logger.Debug(message, args...)
+}
+
+// Info logs a message at the info level.
+func Info(message string, args ...any) {
+	logger.Info(message, args...)
+}
+
+// Warn logs a message at the warning level.  The warning is collected.
+func Warn(message string, args ...any) {
+	logger.Warn(message, args...)
+}
+
+// Error logs a message at the error level.
+func Error(message string, args ...any) {
+	logger.Error(message, args...)
+}
+
+// File returns the field for a file name.
+func File(name string) slog.Attr {
+	return slog.String(KeyFile, name)
+}
+
+// Sheet returns the field for a sheet name.
+func Sheet(name string) slog.Attr {
+	return slog.String(KeySheet, name)
+}
+
+// Row returns the field for a row number.
+func Row(row int) slog.Attr {
+	return slog.Int(KeyRow, row)
+}
+
+// Warnings returns the warnings collected since the logger was initialized.
+func Warnings() []Warning {
+	mutex.Lock()
+	defer mutex.Unlock()
+	var result = make([]Warning, len(warnings))
+	copy(result, warnings)
+	return result
+}
+
+// ResetWarnings discards the collected warnings.
+func ResetWarnings() {
+	mutex.Lock()
+	defer mutex.Unlock()
+	warnings = nil
+}
+
+// Summary returns one line for each distinct warning message with the
+// number of times it occurred, ordered by message.
+func Summary() []string {
+	var counts = make(map[string]int)
+	var messages = []string{}
+	for _, warning := range Warnings() {
+		if counts[warning.Message] == 0 {
+			messages = append(messages, warning.Message)
+		}
+		counts[warning.Message]++
+	}
+	sort.Strings(messages)
+	var lines = []string{}
+	for _, message := range messages {
+		lines = append(lines, message+": "+strconv.Itoa(counts[message]))
+	}
+	return lines
+}
+
+// ----------------------------------------------------------------------------
+// Handler Methods
+// ----------------------------------------------------------------------------
+
+// Enabled reports whether the handler writes records at the level.
+// Warnings are always enabled so they can be collected.
+func (c *collector) Enabled(ctx context.Context, level slog.Level) bool {
+	return level == slog.LevelWarn || c.handler.Enabled(ctx, level)
+}
+
+// Handle records warnings and passes enabled records to the handler.
+func (c *collector) Handle(ctx context.Context, record slog.Record) error {
+	var err error = nil
+	if record.Level == slog.LevelWarn {
+		var attrs = append([]slog.Attr{}, c.attrs...)
+		record.Attrs(func(attr slog.Attr) bool {
+			attrs = append(attrs, attr)
+			return true
+		})
+		mutex.Lock()
+		warnings = append(warnings, Warning{Message: record.Message, Attrs: attrs})
+		mutex.Unlock()
+	}
+	if c.handler.Enabled(ctx, record.Level) {
+		err = c.handler.Handle(ctx, record)
+	}
+	return err
+}
+
+// WithAttrs returns a handler that includes the fields in every record.
+func (c *collector) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &collector{
+		handler: c.handler.WithAttrs(attrs),
+		attrs:   append(append([]slog.Attr{}, c.attrs...), attrs...),
+	}
+}
+
+// WithGroup returns a handler that places later fields in a group.
+func (c *collector) WithGroup(name string) slog.Handler {
+	return &collector{handler: c.handler.WithGroup(name), attrs: c.attrs}
+}
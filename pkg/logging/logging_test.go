@@ -0,0 +1,106 @@
+// ----------------------------------------------------------------------------
+//
+// Logging tests
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package logging
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// ----------------------------------------------------------------------------
+// Test Support
+// ----------------------------------------------------------------------------
+
+// useBuffer directs the log to a buffer for the duration of a test.
+func useBuffer(t *testing.T, format Format, level slog.Level) *bytes.Buffer {
+	var buffer = new(bytes.Buffer)
+	var saved = Logger()
+	SetLogger(New(buffer, format, level))
+	ResetWarnings()
+	t.Cleanup(func() {
+		SetLogger(saved)
+		ResetWarnings()
+	})
+	return buffer
+}
+
+// ----------------------------------------------------------------------------
+// Tests
+// ----------------------------------------------------------------------------
+
+// Test_Text checks that text messages include the contextual fields.
+func Test_Text(t *testing.T) {
+	var buffer = useBuffer(t, Text, slog.LevelInfo)
+	Info("reading", File("donors.xlsx"), Sheet("Sheet1"), Row(12))
+	var line = buffer.String()
+	for _, field := range []string{"msg=reading", "file=donors.xlsx", "sheet=Sheet1", "row=12"} {
+		if !strings.Contains(line, field) {
+			t.Error("text message does not contain " + field + ": " + line)
+		}
+	}
+}
+
+// Test_JSON checks that JSON messages can be decoded.
+func Test_JSON(t *testing.T) {
+	var buffer = useBuffer(t, JSON, slog.LevelInfo)
+	With(KeyCommand, "analyze").Info("reading", Row(3))
+	var record map[string]any
+	var err = json.Unmarshal(buffer.Bytes(), &record)
+	if err != nil {
+		t.Fatal("invalid JSON message: " + err.Error())
+	}
+	if record["command"] != "analyze" || record["msg"] != "reading" || record["row"] != 3.0 {
+		t.Error("unexpected JSON message: " + buffer.String())
+	}
+}
+
+// Test_Level checks that messages below the level are not written but
+// warnings are still collected.
+func Test_Level(t *testing.T) {
+	var buffer = useBuffer(t, Text, slog.LevelError)
+	Debug("debug")
+	Info("info")
+	Warn("warning")
+	if buffer.Len() != 0 {
+		t.Error("messages below the level were written: " + buffer.String())
+	}
+	if len(Warnings()) != 1 {
+		t.Error("warning was not collected")
+	}
+}
+
+// Test_Summary checks the count of each warning message.
+func Test_Summary(t *testing.T) {
+	useBuffer(t, Text, slog.LevelInfo)
+	var logger = With(KeyFile, "bills.xlsx")
+	logger.Warn("bill skipped", Row(2))
+	logger.Warn("bill skipped", Row(5))
+	Warn("donor is not in the donor address list")
+
+	var summary = Summary()
+	if len(summary) != 2 {
+		t.Fatal("incorrect number of summary lines: " + strings.Join(summary, "; "))
+	}
+	if summary[0] != "bill skipped: 2" || summary[1] != "donor is not in the donor address list: 1" {
+		t.Error("incorrect summary: " + strings.Join(summary, "; "))
+	}
+	var warning = Warnings()[0]
+	if len(warning.Attrs) != 2 || warning.Attrs[0].Key != KeyFile {
+		t.Error("warning does not include the logger's fields")
+	}
+}
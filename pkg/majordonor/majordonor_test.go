@@ -0,0 +1,99 @@
+// ----------------------------------------------------------------------------
+//
+// Major Donor Tests
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package majordonor
+
+import (
+	"testing"
+
+	a "acorn_go/pkg/accounting"
+	dna "acorn_go/pkg/donations"
+	dns "acorn_go/pkg/donors"
+
+	dec "github.com/shopspring/decimal"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// donor returns a donor who gave the amounts on the dates.
+func donor(t *testing.T, name string, gifts map[string]int64) *dns.Donor {
+	t.Helper()
+	var result = dns.NewDonorWithDonation(name)
+	for value, amount := range gifts {
+		var date, err = d.NewFromString(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result.AddGift(dns.NewGift(date, dec.NewFromInt(amount), "Payment", "", 0))
+	}
+	return &result
+}
+
+// TestParseTiers checks the parsing and validation of tiers.
+func TestParseTiers(t *testing.T) {
+	tiers, err := ParseTiers("Friend:500, Patron:2000 ,Benefactor:10000")
+	if err != nil || len(tiers) != 3 || tiers[1].Name != "Patron" || tiers[2].Minimum.IntPart() != 10000 {
+		t.Fatalf("tiers were not parsed: %v", err)
+	}
+	for _, value := range []string{"", "Friend", "Friend:x", "Friend:500,Patron:500", ":500", "A:500,A:600", "A:0"} {
+		if _, err = ParseTiers(value); err == nil {
+			t.Errorf("tiers %q were accepted", value)
+		}
+	}
+	if TierOf(DefaultTiers, dec.NewFromInt(999)) != NotMajor || TierOf(DefaultTiers, dec.NewFromInt(2500)) != 1 {
+		t.Error("incorrect tier for an amount")
+	}
+}
+
+// TestComputeMajorDonors checks the tiers, totals, and movement over four
+// fiscal years.
+func TestComputeMajorDonors(t *testing.T) {
+	dl := make(dna.DonationList)
+	dl["A"] = donor(t, "A", map[string]int64{"10/01/2021": 1000, "10/01/2022": 1000, "10/01/2025": 6000})
+	dl["B"] = donor(t, "B", map[string]int64{"10/01/2022": 3000, "10/01/2023": 2000, "10/01/2025": 2500})
+	dl["C"] = donor(t, "C", map[string]int64{"10/01/2022": 500, "10/01/2025": 500})
+
+	majorDonor := ComputeMajorDonors(&dl, DefaultTiers, a.FYIndicators[:])
+	if majorDonor.MajorDonorCount(a.FY2026) != 2 || majorDonor.TierCount(2, a.FY2026) != 1 || majorDonor.TierCount(1, a.FY2026) != 1 {
+		t.Error("incorrect major donor counts in FY2026")
+	}
+	if majorDonor.DonationsMajor(a.FY2026) != 8500 || majorDonor.AvgDonation(a.FY2026) != 4250 {
+		t.Errorf("FY2026 donations %.0f, average %.0f", majorDonor.DonationsMajor(a.FY2026), majorDonor.AvgDonation(a.FY2026))
+	}
+	if majorDonor.PercentDonation(a.FY2026) != 94 {
+		t.Errorf("FY2026 percent of donations %.0f", majorDonor.PercentDonation(a.FY2026))
+	}
+	// The average donation went from 2000 in FY2023 to 2000 in FY2024.
+	if majorDonor.PercentChange(a.FY2024) != 0 || majorDonor.PercentChange(a.FY2023) != 0 {
+		t.Errorf("FY2024 percent change %.0f", majorDonor.PercentChange(a.FY2024))
+	}
+	if majorDonor.Moves(a.FY2023, 0, 0) != 1 || majorDonor.Moves(a.FY2023, NotMajor, 1) != 1 {
+		t.Error("FY2023 movement does not use the gifts before FY2023")
+	}
+	if majorDonor.Moves(a.FY2024, 1, 0) != 1 || majorDonor.Moves(a.FY2024, 0, NotMajor) != 1 {
+		t.Error("incorrect movement into FY2024")
+	}
+	if majorDonor.Moves(a.FY2026, NotMajor, 2) != 1 || majorDonor.Moves(a.FY2026, NotMajor, NotMajor) != 1 {
+		t.Error("incorrect movement into FY2026")
+	}
+}
+
+// TestFiscalYears checks an analysis over some of the fiscal years.
+func TestFiscalYears(t *testing.T) {
+	dl := make(dna.DonationList)
+	dl["A"] = donor(t, "A", map[string]int64{"10/01/2024": 1000, "10/01/2025": 1500})
+
+	majorDonor := ComputeMajorDonors(&dl, DefaultTiers, []a.FYIndicator{a.FY2025, a.FY2026})
+	if majorDonor.PercentChange(a.FY2025) != 0 || majorDonor.PercentChange(a.FY2026) != 50 {
+		t.Errorf("percent change %.0f, %.0f", majorDonor.PercentChange(a.FY2025), majorDonor.PercentChange(a.FY2026))
+	}
+	if majorDonor.TierPercentChange(0, a.FY2026) != 50 {
+		t.Errorf("tier percent change %.0f", majorDonor.TierPercentChange(0, a.FY2026))
+	}
+}
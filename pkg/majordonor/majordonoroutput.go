@@ -11,7 +11,9 @@
 
 package majordonor
 
-// This package computes the output values for major donors
+// This package computes the output values for major donors.  Major donors
+// are counted by tier for each of a list of fiscal years, and the donors
+// moving between tiers from the prior fiscal year are counted as well.
 
 // ----------------------------------------------------------------------------
 // Imports
@@ -21,28 +23,51 @@ import (
 	a "acorn_go/pkg/accounting"
 	dn "acorn_go/pkg/donations"
 	"math"
+
+	"github.com/waysys/assert/assert"
 )
 
 // ----------------------------------------------------------------------------
 // Types
 // ----------------------------------------------------------------------------
 
+// MajorDonor holds the count and donations of each tier for each fiscal
+// year.  The fiscal years are identified by their position in the list
+// given to ComputeMajorDonors.
 type MajorDonor struct {
-	donorCount     [3]int
-	donations      [3]float64
-	donationsTotal [3]float64
+	tiers          []Tier
+	fiscalYears    []a.FYIndicator
+	donorCount     [][]int
+	donations      [][]float64
+	donationsTotal []float64
+	moves          [][][]int
 }
 
 // ----------------------------------------------------------------------------
 // Factory Functions
 // ----------------------------------------------------------------------------
 
-// NewMajorDonor creates a MajorDonor structure initialized to zero for each element.
-func NewMajorDonor() MajorDonor {
-	majorDonor := MajorDonor{
-		donorCount:     [3]int{0, 0, 0},
-		donations:      [3]float64{0.0, 0.0, 0.0},
-		donationsTotal: [3]float64{0.0, 0.0, 0.0},
+// NewMajorDonor creates a MajorDonor structure initialized to zero for each
+// tier and fiscal year.
+func NewMajorDonor(tiers []Tier, fiscalYears []a.FYIndicator) MajorDonor {
+	assert.Assert(ValidateTiers(tiers) == nil, "invalid major donor tiers")
+	var majorDonor = MajorDonor{
+		tiers:          tiers,
+		fiscalYears:    fiscalYears,
+		donorCount:     make([][]int, len(tiers)),
+		donations:      make([][]float64, len(tiers)),
+		donationsTotal: make([]float64, len(fiscalYears)),
+		moves:          make([][][]int, len(fiscalYears)),
+	}
+	for tier := range tiers {
+		majorDonor.donorCount[tier] = make([]int, len(fiscalYears))
+		majorDonor.donations[tier] = make([]float64, len(fiscalYears))
+	}
+	for index := range fiscalYears {
+		majorDonor.moves[index] = make([][]int, len(tiers)+1)
+		for from := range majorDonor.moves[index] {
+			majorDonor.moves[index][from] = make([]int, len(tiers)+1)
+		}
 	}
 	return majorDonor
 }
@@ -51,10 +76,11 @@ func NewMajorDonor() MajorDonor {
 // Operational Functions
 // ----------------------------------------------------------------------------
 
-// ComputeMajorDonors computes the values for the MajorDonor structure
-func ComputeMajorDonors(donationList *dn.DonationList) MajorDonor {
-	var majorDonor = NewMajorDonor()
-	var donations [3]float64
+// ComputeMajorDonors computes the values for the MajorDonor structure.  The
+// tier of a donor in the fiscal year before the first one in the list is
+// taken from the donor's gift ledger.
+func ComputeMajorDonors(donationList *dn.DonationList, tiers []Tier, fiscalYears []a.FYIndicator) MajorDonor {
+	var majorDonor = NewMajorDonor(tiers, fiscalYears)
 	//
 	// Loop through donors with annual donation information.
 	//
@@ -62,13 +88,17 @@ func ComputeMajorDonors(donationList *dn.DonationList) MajorDonor {
 		//
 		// Loop through fiscal years
 		//
-		for _, fy := range a.FYIndicators {
-			donations[fy] = donor.Donation(fy).InexactFloat64()
-			if donor.IsMajorDonor(fy) {
-				majorDonor.donorCount[fy]++
-				majorDonor.donations[fy] += donations[fy]
+		for index, fy := range fiscalYears {
+			var yoy = donor.YearOverYear(fy)
+			var donation = yoy.Current().InexactFloat64()
+			var tier = TierOf(tiers, yoy.Current())
+			if tier != NotMajor {
+				majorDonor.donorCount[tier][index]++
+				majorDonor.donations[tier][index] += donation
 			}
-			majorDonor.donationsTotal[fy] += donations[fy]
+			majorDonor.donationsTotal[index] += donation
+			var priorTier = TierOf(tiers, yoy.Prior())
+			majorDonor.moves[index][priorTier+1][tier+1]++
 		}
 	}
 	return majorDonor
@@ -78,59 +108,132 @@ func ComputeMajorDonors(donationList *dn.DonationList) MajorDonor {
 // Methods
 // ----------------------------------------------------------------------------
 
-// MajorDonors returns the number of major donors for the specified fiscal year
+// Tiers returns the tiers of the analysis from lowest to highest.
+func (majorDonor MajorDonor) Tiers() []Tier {
+	return majorDonor.tiers
+}
+
+// FiscalYears returns the fiscal years of the analysis.
+func (majorDonor MajorDonor) FiscalYears() []a.FYIndicator {
+	return majorDonor.fiscalYears
+}
+
+// index returns the position of the fiscal year in the analysis.
+func (majorDonor MajorDonor) index(fy a.FYIndicator) int {
+	for index, candidate := range majorDonor.fiscalYears {
+		if candidate == fy {
+			return index
+		}
+	}
+	assert.Assert(false, "fiscal year is not in the major donor analysis: "+fy.String())
+	return 0
+}
+
+// TierCount returns the number of donors in the tier for the specified
+// fiscal year.
+func (majorDonor MajorDonor) TierCount(tier int, fy a.FYIndicator) int {
+	return majorDonor.donorCount[tier][majorDonor.index(fy)]
+}
+
+// TierDonations returns the amount of donations by donors in the tier.
+func (majorDonor MajorDonor) TierDonations(tier int, fy a.FYIndicator) float64 {
+	return math.Round(majorDonor.donations[tier][majorDonor.index(fy)])
+}
+
+// TierAvgDonation returns the average donation by donors in the tier.
+func (majorDonor MajorDonor) TierAvgDonation(tier int, fy a.FYIndicator) float64 {
+	var index = majorDonor.index(fy)
+	return average(majorDonor.donations[tier][index], majorDonor.donorCount[tier][index])
+}
+
+// TierPercentDonation returns the donations by donors in the tier as a
+// percent of the total donations for the specified fiscal year.
+func (majorDonor MajorDonor) TierPercentDonation(tier int, fy a.FYIndicator) float64 {
+	var index = majorDonor.index(fy)
+	return a.Percent(majorDonor.donations[tier][index], majorDonor.donationsTotal[index])
+}
+
+// TierPercentChange returns the percent change in the average donation of
+// the tier from the prior fiscal year in the analysis.  It is zero for the
+// first fiscal year.
+func (majorDonor MajorDonor) TierPercentChange(tier int, fy a.FYIndicator) float64 {
+	var index = majorDonor.index(fy)
+	var change = 0.00
+	if index > 0 {
+		var prior = majorDonor.fiscalYears[index-1]
+		change = a.PercentChange(majorDonor.TierAvgDonation(tier, prior), majorDonor.TierAvgDonation(tier, fy))
+	}
+	return change
+}
+
+// MajorDonorCount returns the number of major donors in all tiers for the
+// specified fiscal year.
 func (majorDonor MajorDonor) MajorDonorCount(fy a.FYIndicator) int {
-	return majorDonor.donorCount[fy]
+	var count = 0
+	for tier := range majorDonor.tiers {
+		count += majorDonor.donorCount[tier][majorDonor.index(fy)]
+	}
+	return count
+}
+
+// donationsMajor returns the unrounded donations by major donors in all
+// tiers.
+func (majorDonor MajorDonor) donationsMajor(fy a.FYIndicator) float64 {
+	var donations = 0.00
+	for tier := range majorDonor.tiers {
+		donations += majorDonor.donations[tier][majorDonor.index(fy)]
+	}
+	return donations
 }
 
 // DonationsMajor returns the amount of donations by major donors.
 func (majorDonor MajorDonor) DonationsMajor(fy a.FYIndicator) float64 {
-	return math.Round(majorDonor.donations[fy])
+	return math.Round(majorDonor.donationsMajor(fy))
 }
 
 // AverageDonation returns the average donation by major donors for the
 // specified fiscal year
 func (majorDonor MajorDonor) AvgDonation(fy a.FYIndicator) float64 {
-	var count = float64(majorDonor.MajorDonorCount(fy))
-	var donation = majorDonor.donations[fy]
-	var avg = 0.00
-	if count > 0.00 {
-		avg = math.Round(donation / count)
-	}
-	return avg
+	return average(majorDonor.donationsMajor(fy), majorDonor.MajorDonorCount(fy))
 }
 
 // PercentDonation returns the donations by major donors as a percent
 // of the total donations for the specified fiscal year.
 func (majorDonor MajorDonor) PercentDonation(fy a.FYIndicator) float64 {
-	var donation = majorDonor.donations[fy]
-	var donationsTotal = majorDonor.donationsTotal[fy]
-	var percent = 0.00
-	if donationsTotal > 0.00 {
-		percent = math.Round(100.0 * donation / donationsTotal)
-	}
-	return percent
+	return a.Percent(majorDonor.donationsMajor(fy), majorDonor.donationsTotal[majorDonor.index(fy)])
 }
 
 // PercentChange returns the percent change in average donations from
-// the current fiscal year compared to the prior fiscal year.
+// the prior fiscal year in the analysis to the specified fiscal year.  It
+// is zero for the first fiscal year.
 func (majorDonor MajorDonor) PercentChange(fy a.FYIndicator) float64 {
-	var percentChange = 0.00
-	switch fy {
-	case a.FY2024:
-		percentChange = 0.00
-	case a.FY2025:
-		var avgDonationFY2023 = majorDonor.AvgDonation(a.FY2025)
-		var avgDonationFY2024 = majorDonor.AvgDonation(a.FY2024)
-		if avgDonationFY2023 > 0.00 {
-			percentChange = 100.00 * (avgDonationFY2024 - avgDonationFY2023) / avgDonationFY2023
-		}
-	case a.FY2026:
-		var avgDonationFY2024 = majorDonor.AvgDonation(a.FY2026)
-		var avgDonationFY2025 = majorDonor.AvgDonation(a.FY2025)
-		if avgDonationFY2024 > 0.00 {
-			percentChange = 100 * (avgDonationFY2025 - avgDonationFY2024) / avgDonationFY2024
-		}
+	var index = majorDonor.index(fy)
+	var change = 0.00
+	if index > 0 {
+		var prior = majorDonor.fiscalYears[index-1]
+		change = a.PercentChange(majorDonor.AvgDonation(prior), majorDonor.AvgDonation(fy))
 	}
-	return math.Round(percentChange)
+	return change
+}
+
+// Moves returns the number of donors who were in the from tier in the
+// fiscal year before the specified one and are in the to tier in the
+// specified fiscal year.  Either tier may be NotMajor.
+func (majorDonor MajorDonor) Moves(fy a.FYIndicator, from int, to int) int {
+	assert.Assert(from >= NotMajor && from < len(majorDonor.tiers), "invalid from tier")
+	assert.Assert(to >= NotMajor && to < len(majorDonor.tiers), "invalid to tier")
+	return majorDonor.moves[majorDonor.index(fy)][from+1][to+1]
+}
+
+// ----------------------------------------------------------------------------
+// Support Functions
+// ----------------------------------------------------------------------------
+
+// average returns the rounded average of the donations.
+func average(donations float64, count int) float64 {
+	var avg = 0.00
+	if count > 0 {
+		avg = math.Round(donations / float64(count))
+	}
+	return avg
 }
@@ -0,0 +1,143 @@
+// ----------------------------------------------------------------------------
+//
+// Major Donor Tiers
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package majordonor
+
+// This file defines the tiers of the major donor program.  A donor is in
+// the highest tier whose minimum is no more than the donor's total giving in
+// a fiscal year.  The default tiers can be replaced with
+// ACORN_MAJOR_DONOR_TIERS, a comma separated list of names and minimums such
+// as "Friend:1000,Patron:2500,Benefactor:5000".
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	dns "acorn_go/pkg/donors"
+	sp "acorn_go/pkg/support"
+	"errors"
+	"os"
+	"strings"
+
+	dec "github.com/shopspring/decimal"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Tier is a level of the major donor program.
+type Tier struct {
+	Name    string
+	Minimum dec.Decimal
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// TiersVariable is the environmental variable with the tiers.
+const TiersVariable = "ACORN_MAJOR_DONOR_TIERS"
+
+// NotMajor is the tier index of a donor who gave less than the minimum of
+// the lowest tier.
+const NotMajor = -1
+
+// DefaultTiers are the tiers used when ACORN_MAJOR_DONOR_TIERS is not
+// defined.  The lowest tier starts at the major donor limit.
+var DefaultTiers = []Tier{
+	{Name: "Friend", Minimum: dns.MajorDonorLimit},
+	{Name: "Patron", Minimum: dec.NewFromInt(2500)},
+	{Name: "Benefactor", Minimum: dec.NewFromInt(5000)},
+}
+
+// ----------------------------------------------------------------------------
+// Functions
+// ----------------------------------------------------------------------------
+
+// ValidateTiers returns an error if there are no tiers, a tier has no name,
+// two tiers have the same name, or the minimums are not greater than zero
+// and increasing.
+func ValidateTiers(tiers []Tier) error {
+	if len(tiers) == 0 {
+		return errors.New("at least one major donor tier is required")
+	}
+	var names = make(map[string]bool)
+	var minimum = dec.Zero
+	for _, tier := range tiers {
+		if tier.Name == "" {
+			return errors.New("major donor tier must have a name")
+		}
+		if names[tier.Name] {
+			return errors.New("major donor tier is defined twice: " + tier.Name)
+		}
+		if !tier.Minimum.GreaterThan(minimum) {
+			return errors.New("major donor tier minimums must be greater than zero and increasing: " + tier.Name)
+		}
+		names[tier.Name] = true
+		minimum = tier.Minimum
+	}
+	return nil
+}
+
+// ParseTiers returns the tiers in a comma separated list of names and
+// minimums.
+func ParseTiers(value string) ([]Tier, error) {
+	var tiers = []Tier{}
+	for _, field := range strings.Split(value, ",") {
+		var name, amount, found = strings.Cut(field, ":")
+		if !found {
+			return []Tier{}, errors.New("major donor tier must be a name and minimum: " + field)
+		}
+		var minimum, err = dec.NewFromString(strings.TrimSpace(amount))
+		if err != nil {
+			return []Tier{}, errors.New("major donor tier minimum is not an amount: " + field)
+		}
+		tiers = append(tiers, Tier{Name: strings.TrimSpace(name), Minimum: minimum})
+	}
+	return tiers, ValidateTiers(tiers)
+}
+
+// ConfiguredTiers returns the tiers from ACORN_MAJOR_DONOR_TIERS or the
+// default tiers if it is not defined.
+func ConfiguredTiers() ([]Tier, error) {
+	var value = strings.TrimSpace(os.Getenv(TiersVariable))
+	if value == "" {
+		return DefaultTiers, nil
+	}
+	var tiers, err = ParseTiers(value)
+	if err != nil {
+		err = sp.Wrap(err, sp.Usage, "reading "+TiersVariable)
+	}
+	return tiers, err
+}
+
+// TierOf returns the index of the tier for the amount, or NotMajor if the
+// amount is less than the minimum of the lowest tier.
+func TierOf(tiers []Tier, amount dec.Decimal) int {
+	var index = NotMajor
+	for tier := range tiers {
+		if amount.GreaterThanOrEqual(tiers[tier].Minimum) {
+			index = tier
+		}
+	}
+	return index
+}
+
+// TierName returns the name of the tier with the index, or "Not a Major
+// Donor" for NotMajor.
+func TierName(tiers []Tier, index int) string {
+	var name = "Not a Major Donor"
+	if index != NotMajor {
+		name = tiers[index].Name
+	}
+	return name
+}
@@ -0,0 +1,174 @@
+// ----------------------------------------------------------------------------
+//
+// Expected Matching Gifts
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package matching
+
+// Each gift of a donor in a matching gift program, made on or after the
+// start date, is expected to be matched by the employer at the ratio of
+// the program.  A matching gift is a gift by the employer that gives soft
+// credit to the donor as a matching gift.  The matching gifts received are
+// applied to the donor's gifts oldest first, and a matching gift is applied
+// only to gifts made on or before it.  The amount of a matching gift beyond
+// what is expected is not applied.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	a "acorn_go/pkg/accounting"
+	dna "acorn_go/pkg/donations"
+	dn "acorn_go/pkg/donors"
+
+	dec "github.com/shopspring/decimal"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Match is the matching gift expected for a donor's gift and the amount of
+// it received.
+type Match struct {
+	program  Program
+	date     d.Date
+	gift     dec.Decimal
+	expected dec.Decimal
+	received dec.Decimal
+}
+
+// ----------------------------------------------------------------------------
+// Table Methods
+// ----------------------------------------------------------------------------
+
+// Expected returns the match for each gift of the donors in the programs
+// made on or before the date, net of the reversals made by then, in the
+// order of the programs and the dates of the gifts.  The donation list must keep the hard credit with the payees,
+// so the gifts of the employers are in their own ledgers.
+func (table *Table) Expected(donationList dna.DonationList, asOf d.Date) []Match {
+	var result = []Match{}
+	for _, program := range table.programs {
+		if !donationList.Contains(program.key) {
+			continue
+		}
+		//
+		// Compute the match expected for each gift
+		//
+		var matches = []Match{}
+		for _, gift := range donationList.Get(program.key).Through(asOf).Gifts() {
+			if gift.Date().Before(program.start) || gift.IsSoftCredited() || !gift.Amount().GreaterThan(dec.Zero) {
+				continue
+			}
+			matches = append(matches, Match{
+				program:  program,
+				date:     gift.Date(),
+				gift:     gift.Amount(),
+				expected: gift.Amount().Mul(program.ratio).Round(2),
+				received: dec.Zero,
+			})
+		}
+		//
+		// Apply the matching gifts received from the employer
+		//
+		if donationList.Contains(program.payee) {
+			for _, gift := range donationList.Get(program.payee).Through(asOf).CreditedGifts() {
+				if program.isMatch(gift) {
+					applyMatch(matches, gift)
+				}
+			}
+		}
+		result = append(result, matches...)
+	}
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Match Methods
+// ----------------------------------------------------------------------------
+
+// Donor returns the key of the donor whose gift is matched.
+func (match Match) Donor() string {
+	return match.program.key
+}
+
+// Employer returns the name of the employer expected to match the gift.
+func (match Match) Employer() string {
+	return match.program.employer
+}
+
+// Date returns the date of the donor's gift.
+func (match Match) Date() d.Date {
+	return match.date
+}
+
+// Gift returns the amount of the donor's gift.
+func (match Match) Gift() dec.Decimal {
+	return match.gift
+}
+
+// Ratio returns the amount matched for each dollar given.
+func (match Match) Ratio() dec.Decimal {
+	return match.program.ratio
+}
+
+// Expected returns the amount of the matching gift expected.
+func (match Match) Expected() dec.Decimal {
+	return match.expected
+}
+
+// Received returns the amount of the matching gift received.
+func (match Match) Received() dec.Decimal {
+	return match.received
+}
+
+// Outstanding returns the amount of the matching gift not yet received.
+func (match Match) Outstanding() dec.Decimal {
+	return match.expected.Sub(match.received)
+}
+
+// IsReceived returns true if the matching gift has been received in full.
+func (match Match) IsReceived() bool {
+	return !match.Outstanding().GreaterThan(dec.Zero)
+}
+
+// DaysOutstanding returns the number of days from the donor's gift to the
+// date.
+func (match Match) DaysOutstanding(asOf d.Date) int {
+	return max(a.DaysBetween(match.date, asOf), 0)
+}
+
+// ----------------------------------------------------------------------------
+// Support Functions
+// ----------------------------------------------------------------------------
+
+// isMatch returns true if the employer's gift is a matching gift for the
+// donor of the program.
+func (program *Program) isMatch(gift dn.Gift) bool {
+	var donor = gift.CreditedTo()
+	return gift.CreditType() == dn.MatchingGift && (donor == program.key || donor == program.donor)
+}
+
+// applyMatch applies a matching gift to the outstanding matches of gifts
+// made on or before it, oldest first.
+func applyMatch(matches []Match, gift dn.Gift) {
+	var remaining = gift.Amount()
+	for index := range matches {
+		var match = &matches[index]
+		if !remaining.GreaterThan(dec.Zero) || match.date.After(gift.Date()) {
+			break
+		}
+		var payment = dec.Min(remaining, match.Outstanding())
+		if payment.GreaterThan(dec.Zero) {
+			match.received = match.received.Add(payment)
+			remaining = remaining.Sub(payment)
+		}
+	}
+}
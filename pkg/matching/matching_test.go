@@ -0,0 +1,119 @@
+// ----------------------------------------------------------------------------
+//
+// Matching gift tests
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package matching
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	dna "acorn_go/pkg/donations"
+	dn "acorn_go/pkg/donors"
+	"testing"
+
+	dec "github.com/shopspring/decimal"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Test Support
+// ----------------------------------------------------------------------------
+
+// date returns the date for the tests.
+func date(t *testing.T, value string) d.Date {
+	t.Helper()
+	var result, err = d.NewFromString(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Tests
+// ----------------------------------------------------------------------------
+
+// TestExpected checks that the matching gifts received are applied to the
+// donor's gifts oldest first and that the rest are expected.
+func TestExpected(t *testing.T) {
+	var program, err = NewProgram("Ann", "Acme", dec.NewFromInt(2), date(t, "01/01/2025"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var table = Table{programs: []Program{program}}
+	var donationList = make(dna.DonationList)
+	for _, key := range []string{"Ann", "Acme"} {
+		var donor = dn.NewDonorWithDonation(key)
+		donationList[key] = &donor
+	}
+	var add = func(key string, value string, amount int64) {
+		var err = donationList.AddGift(key, dn.NewGift(date(t, value), dec.NewFromInt(amount), "Payment", "", 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	add("Ann", "12/01/2024", 100)
+	add("Ann", "02/01/2025", 100)
+	add("Ann", "03/01/2025", 50)
+	add("Ann", "05/01/2025", 75)
+	add("Acme", "04/15/2025", 250)
+	add("Acme", "06/01/2025", 1000)
+	for _, credit := range []struct {
+		date   string
+		amount int64
+	}{
+		{"04/15/2025", 250},
+		{"06/01/2025", 1000},
+	} {
+		var soft, err = dn.NewSoftCredit(date(t, credit.date), dec.NewFromInt(credit.amount), "Acme", "Ann",
+			dn.MatchingGift, 2)
+		if err == nil {
+			err = donationList.Get("Acme").ApplySoftCredit(soft)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	//
+	// The second matching gift and the refunds are after the date of the
+	// report
+	//
+	for key, amount := range map[string]int64{"Ann": -75, "Acme": -250} {
+		err = donationList.AddGift(key, dn.NewGift(date(t, "07/01/2025"), dec.NewFromInt(amount), "Refund", "", 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	var matches = table.Expected(donationList, date(t, "05/31/2025"))
+	var tests = []struct {
+		date        string
+		expected    int64
+		outstanding int64
+	}{
+		{"02/01/2025", 200, 0},
+		{"03/01/2025", 100, 50},
+		{"05/01/2025", 150, 150},
+	}
+	if len(matches) != len(tests) {
+		t.Fatalf("got %d matches, want %d", len(matches), len(tests))
+	}
+	for index, tt := range tests {
+		var match = matches[index]
+		if match.Date().String() != date(t, tt.date).String() || match.Expected().IntPart() != tt.expected ||
+			match.Outstanding().IntPart() != tt.outstanding {
+			t.Errorf("match %d: got %s expected %s outstanding %s", index, match.Date().String(),
+				match.Expected().String(), match.Outstanding().String())
+		}
+	}
+	if _, err := NewProgram("Ann", "Acme", dec.Zero, date(t, "01/01/2025")); err == nil {
+		t.Error("program without a ratio was accepted")
+	}
+}
@@ -0,0 +1,216 @@
+// ----------------------------------------------------------------------------
+//
+// Matching Gift Programs
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+// The matching package finds the employer matching gifts that are expected
+// for the gifts of donors but have not been received.
+package matching
+
+// The matching gift programs are kept in a spreadsheet with a row for each
+// donor whose employer matches gifts and the columns "Donor full name",
+// Employer, Match Ratio, and Start Date.  The employer is the Quickbooks
+// payee of its matching gifts.  A ratio of 1 matches a gift dollar for
+// dollar.  Gifts made before the start date are not matched.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"acorn_go/pkg/identity"
+	"acorn_go/pkg/spreadsheet"
+	"errors"
+	"slices"
+	"strings"
+
+	dec "github.com/shopspring/decimal"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Program is an employer's promise to match the gifts of a donor.
+type Program struct {
+	donor    string
+	key      string
+	employer string
+	payee    string
+	ratio    dec.Decimal
+	start    d.Date
+	row      int
+}
+
+// Table holds the matching gift programs in the order of the spreadsheet.
+type Table struct {
+	programs []Program
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Matching gift program column names
+const (
+	columnDonor    = "Donor full name"
+	columnEmployer = "Employer"
+	columnRatio    = "Match Ratio"
+	columnStart    = "Start Date"
+)
+
+// ----------------------------------------------------------------------------
+// Factory Functions
+// ----------------------------------------------------------------------------
+
+// NewProgram returns the program of the employer that matches the gifts of
+// the donor made on or after the start date at the ratio.
+func NewProgram(donor string, employer string, ratio dec.Decimal, start d.Date) (Program, error) {
+	var program = Program{
+		donor:    strings.TrimSpace(donor),
+		key:      strings.TrimSpace(donor),
+		employer: strings.TrimSpace(employer),
+		payee:    strings.TrimSpace(employer),
+		ratio:    ratio,
+		start:    start,
+	}
+	var err error
+	switch {
+	case program.donor == "" || program.employer == "":
+		err = errors.New("donor and employer must not be empty")
+	case !ratio.GreaterThan(dec.Zero):
+		err = errors.New("match ratio must be greater than zero")
+	}
+	return program, err
+}
+
+// NewTable returns the programs in the rows of a matching gift spreadsheet.
+// A donor may be listed only once for each employer.
+func NewTable(sprdsht *spreadsheet.Spreadsheet) (Table, error) {
+	var table = Table{programs: []Program{}}
+	var numRows = sprdsht.Size()
+	for row := 1; row < numRows; row++ {
+		var program, err = readProgram(sprdsht, row)
+		if err == nil && slices.ContainsFunc(table.programs, func(other Program) bool {
+			return other.donor == program.donor && other.employer == program.employer
+		}) {
+			err = errors.New("donor is listed more than once for the employer: " + program.donor)
+		}
+		if err != nil {
+			return table, sprdsht.WrapRow(err, "processing matching gift program", row)
+		}
+		table.programs = append(table.programs, program)
+	}
+	return table, nil
+}
+
+// ReadTable reads the programs from a matching gift spreadsheet.
+func ReadTable(fileName string, tab string) (Table, error) {
+	var sprdsht, err = spreadsheet.ProcessData(fileName, tab)
+	if err != nil {
+		return Table{programs: []Program{}}, err
+	}
+	return NewTable(&sprdsht)
+}
+
+// readProgram returns the program in a row of the matching gift spreadsheet.
+func readProgram(sprdsht *spreadsheet.Spreadsheet, row int) (Program, error) {
+	var donor, employer string
+	var ratio dec.Decimal
+	var start d.Date
+	var err error
+
+	donor, err = sprdsht.Cell(row, columnDonor)
+	if err == nil {
+		employer, err = sprdsht.Cell(row, columnEmployer)
+	}
+	if err == nil {
+		ratio, err = sprdsht.CellDecimal(row, columnRatio)
+	}
+	if err == nil {
+		start, err = sprdsht.CellDate(row, columnStart)
+	}
+	if err != nil {
+		return Program{}, err
+	}
+	var program Program
+	program, err = NewProgram(donor, employer, ratio, start)
+	program.row = row + 1
+	return program, err
+}
+
+// ----------------------------------------------------------------------------
+// Program Methods
+// ----------------------------------------------------------------------------
+
+// Donor returns the name of the donor as given in the spreadsheet.
+func (program *Program) Donor() string {
+	return program.donor
+}
+
+// Key returns the key of the donor in the donation list.
+func (program *Program) Key() string {
+	return program.key
+}
+
+// Employer returns the name of the employer as given in the spreadsheet.
+func (program *Program) Employer() string {
+	return program.employer
+}
+
+// Payee returns the key of the employer in the donation list.
+func (program *Program) Payee() string {
+	return program.payee
+}
+
+// Ratio returns the amount matched for each dollar given.
+func (program *Program) Ratio() dec.Decimal {
+	return program.ratio
+}
+
+// Start returns the date of the first gift matched.
+func (program *Program) Start() d.Date {
+	return program.start
+}
+
+// Row returns the row of the program in the spreadsheet, as shown by Excel.
+func (program *Program) Row() int {
+	return program.row
+}
+
+// ----------------------------------------------------------------------------
+// Table Methods
+// ----------------------------------------------------------------------------
+
+// Count returns the number of programs.
+func (table *Table) Count() int {
+	return len(table.programs)
+}
+
+// Programs returns the programs in the order of the spreadsheet.
+func (table *Table) Programs() []Program {
+	return slices.Clone(table.programs)
+}
+
+// Resolve matches the donor and the employer of each program to their keys
+// in the donation list and returns the donor names that matched no donor.
+// An employer is usually not in the donor list, so it keeps its name if it
+// is not matched.
+func (table *Table) Resolve(resolver *identity.Resolver) []string {
+	var unmatched = []string{}
+	for index := range table.programs {
+		var program = &table.programs[index]
+		if !resolver.Resolve(program.donor).Resolved() && !slices.Contains(unmatched, program.donor) {
+			unmatched = append(unmatched, program.donor)
+		}
+		program.key = resolver.KeyOf(program.donor)
+		program.payee = resolver.KeyOf(program.employer)
+	}
+	return unmatched
+}
@@ -0,0 +1,386 @@
+// ----------------------------------------------------------------------------
+//
+// Deposit Reconciliation
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package online
+
+// The processor pays out the net of the gifts to the bank in batches, and
+// each payout is recorded in Quickbooks as a deposit from the processor.
+// A payout is reconciled with the first deposit from the processor for its
+// net amount made within the deposit window after the payout date.  A
+// payout with no such deposit is reconciled with a deposit of another
+// amount within the window, if there is one, so the difference can be
+// investigated.  Deposits left over have no payout.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	a "acorn_go/pkg/accounting"
+	"acorn_go/pkg/spreadsheet"
+	"slices"
+	"strconv"
+	"strings"
+
+	dec "github.com/shopspring/decimal"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Payout is the total of the gifts paid out to the bank on a date.
+type Payout struct {
+	date  d.Date
+	count int
+	gross dec.Decimal
+	fee   dec.Decimal
+	net   dec.Decimal
+}
+
+// Deposit is a deposit from the processor in the Quickbooks donation
+// spreadsheet.
+type Deposit struct {
+	date   d.Date
+	amount dec.Decimal
+	row    int
+}
+
+// Reconciliation pairs a payout with the deposit that received it.  Either
+// may be missing.
+type Reconciliation struct {
+	payout     Payout
+	hasPayout  bool
+	deposit    Deposit
+	hasDeposit bool
+}
+
+// Totals is the giving through the platform in a fiscal year.
+type Totals struct {
+	fiscalYear  int
+	count       int
+	gross       dec.Decimal
+	fee         dec.Decimal
+	net         dec.Decimal
+	covered     int
+	coveredFees dec.Decimal
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Number of days after the payout date that the deposit may be made
+const DepositWindow = 5
+
+// Status of a reconciliation
+const (
+	StatusMatched       = "Matched"
+	StatusAmountDiffers = "Amount differs"
+	StatusNoDeposit     = "No deposit"
+	StatusNoPayout      = "No payout"
+)
+
+// Donation spreadsheet column names and the transaction type of a deposit
+const (
+	columnPayee   = "Payee"
+	columnType    = "Type"
+	columnPayment = "Payment"
+	typeDeposit   = "Deposit"
+)
+
+// ----------------------------------------------------------------------------
+// Functions
+// ----------------------------------------------------------------------------
+
+// ReadDeposits returns the deposits from the payee in the rows of the
+// Quickbooks donation spreadsheet in date order.  Case is ignored in the
+// payee and the type.
+func ReadDeposits(sprdsht *spreadsheet.Spreadsheet, payee string) ([]Deposit, error) {
+	var deposits = []Deposit{}
+	var numRows = sprdsht.Size()
+	for row := 1; row < numRows; row++ {
+		var name, transType string
+		var deposit = Deposit{row: row + 1}
+		var err error
+
+		name, err = sprdsht.Cell(row, columnPayee)
+		if err == nil {
+			transType, err = sprdsht.Cell(row, columnType)
+		}
+		if err == nil && (!strings.EqualFold(name, payee) || !strings.EqualFold(transType, typeDeposit)) {
+			continue
+		}
+		if err == nil {
+			deposit.date, err = sprdsht.CellDate(row, columnDate)
+		}
+		if err == nil {
+			deposit.amount, err = sprdsht.CellDecimal(row, columnPayment)
+		}
+		if err != nil {
+			return deposits, sprdsht.WrapRow(err, "processing deposit", row)
+		}
+		deposits = append(deposits, deposit)
+	}
+	slices.SortStableFunc(deposits, func(x Deposit, y Deposit) int {
+		return a.CompareDates(x.date, y.date)
+	})
+	return deposits, nil
+}
+
+// Reconcile pairs the payouts with the deposits.  The reconciliations of
+// the payouts are in the order of the payouts, followed by the deposits
+// that have no payout.
+func Reconcile(payouts []Payout, deposits []Deposit) []Reconciliation {
+	var result = []Reconciliation{}
+	var used = make([]bool, len(deposits))
+	//
+	// Find the deposit for each payout
+	//
+	var find = func(payout Payout, sameAmount bool) int {
+		var last = a.AddDays(payout.date, DepositWindow)
+		for index, deposit := range deposits {
+			if used[index] || deposit.date.Before(payout.date) || deposit.date.After(last) {
+				continue
+			}
+			if !sameAmount || deposit.amount.Equal(payout.net) {
+				return index
+			}
+		}
+		return -1
+	}
+	var matched = make([]int, len(payouts))
+	for index, payout := range payouts {
+		matched[index] = find(payout, true)
+		if matched[index] >= 0 {
+			used[matched[index]] = true
+		}
+	}
+	for index, payout := range payouts {
+		if matched[index] < 0 {
+			matched[index] = find(payout, false)
+			if matched[index] >= 0 {
+				used[matched[index]] = true
+			}
+		}
+		var reconciliation = Reconciliation{payout: payout, hasPayout: true}
+		if matched[index] >= 0 {
+			reconciliation.deposit = deposits[matched[index]]
+			reconciliation.hasDeposit = true
+		}
+		result = append(result, reconciliation)
+	}
+	//
+	// List the deposits without a payout
+	//
+	for index, deposit := range deposits {
+		if !used[index] {
+			result = append(result, Reconciliation{deposit: deposit, hasDeposit: true})
+		}
+	}
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Export Methods
+// ----------------------------------------------------------------------------
+
+// Payouts returns the total of the transactions paid out on each date in
+// date order.  Transactions not yet paid out are left out.
+func (export *Export) Payouts() []Payout {
+	var result = []Payout{}
+	for _, transaction := range export.transactions {
+		if !transaction.paidOut {
+			continue
+		}
+		var index = slices.IndexFunc(result, func(payout Payout) bool {
+			return a.CompareDates(payout.date, transaction.payout) == 0
+		})
+		if index < 0 {
+			result = append(result, Payout{date: transaction.payout, gross: dec.Zero, fee: dec.Zero, net: dec.Zero})
+			index = len(result) - 1
+		}
+		var payout = &result[index]
+		payout.count++
+		payout.gross = payout.gross.Add(transaction.gross)
+		payout.fee = payout.fee.Add(transaction.fee)
+		payout.net = payout.net.Add(transaction.net)
+	}
+	slices.SortFunc(result, func(x Payout, y Payout) int {
+		return a.CompareDates(x.date, y.date)
+	})
+	return result
+}
+
+// Totals returns the gross, fees, and net of the transactions in each
+// fiscal year in order of the fiscal years.
+func (export *Export) Totals() []Totals {
+	var result = []Totals{}
+	for _, transaction := range export.transactions {
+		var fiscalYear = a.FiscalYearNumber(transaction.date)
+		var index = slices.IndexFunc(result, func(totals Totals) bool {
+			return totals.fiscalYear == fiscalYear
+		})
+		if index < 0 {
+			result = append(result, Totals{
+				fiscalYear:  fiscalYear,
+				gross:       dec.Zero,
+				fee:         dec.Zero,
+				net:         dec.Zero,
+				coveredFees: dec.Zero,
+			})
+			index = len(result) - 1
+		}
+		var totals = &result[index]
+		totals.count++
+		totals.gross = totals.gross.Add(transaction.gross)
+		totals.fee = totals.fee.Add(transaction.fee)
+		totals.net = totals.net.Add(transaction.net)
+		if transaction.feeCovered {
+			totals.covered++
+			totals.coveredFees = totals.coveredFees.Add(transaction.fee)
+		}
+	}
+	slices.SortFunc(result, func(x Totals, y Totals) int {
+		return x.fiscalYear - y.fiscalYear
+	})
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Payout Methods
+// ----------------------------------------------------------------------------
+
+// Date returns the date of the payout.
+func (payout Payout) Date() d.Date {
+	return payout.date
+}
+
+// Count returns the number of transactions in the payout.
+func (payout Payout) Count() int {
+	return payout.count
+}
+
+// Gross returns the total given in the transactions of the payout.
+func (payout Payout) Gross() dec.Decimal {
+	return payout.gross
+}
+
+// Fee returns the processing fees of the payout.
+func (payout Payout) Fee() dec.Decimal {
+	return payout.fee
+}
+
+// Net returns the amount paid out.
+func (payout Payout) Net() dec.Decimal {
+	return payout.net
+}
+
+// ----------------------------------------------------------------------------
+// Deposit Methods
+// ----------------------------------------------------------------------------
+
+// Date returns the date of the deposit.
+func (deposit Deposit) Date() d.Date {
+	return deposit.date
+}
+
+// Amount returns the amount deposited.
+func (deposit Deposit) Amount() dec.Decimal {
+	return deposit.amount
+}
+
+// Row returns the row of the deposit in the donation spreadsheet, as shown
+// by Excel.
+func (deposit Deposit) Row() int {
+	return deposit.row
+}
+
+// ----------------------------------------------------------------------------
+// Reconciliation Methods
+// ----------------------------------------------------------------------------
+
+// Payout returns the payout.  The second value is false for a deposit with
+// no payout.
+func (reconciliation Reconciliation) Payout() (Payout, bool) {
+	return reconciliation.payout, reconciliation.hasPayout
+}
+
+// Deposit returns the deposit.  The second value is false for a payout with
+// no deposit.
+func (reconciliation Reconciliation) Deposit() (Deposit, bool) {
+	return reconciliation.deposit, reconciliation.hasDeposit
+}
+
+// Difference returns the amount deposited less the amount paid out.
+func (reconciliation Reconciliation) Difference() dec.Decimal {
+	return reconciliation.deposit.amount.Sub(reconciliation.payout.net)
+}
+
+// Status returns Matched, Amount differs, No deposit, or No payout.
+func (reconciliation Reconciliation) Status() string {
+	switch {
+	case !reconciliation.hasDeposit:
+		return StatusNoDeposit
+	case !reconciliation.hasPayout:
+		return StatusNoPayout
+	case reconciliation.Difference().IsZero():
+		return StatusMatched
+	default:
+		return StatusAmountDiffers
+	}
+}
+
+// ----------------------------------------------------------------------------
+// Totals Methods
+// ----------------------------------------------------------------------------
+
+// FiscalYear returns the number of the fiscal year, the calendar year in
+// which it ends.
+func (totals Totals) FiscalYear() int {
+	return totals.fiscalYear
+}
+
+// Label returns the name of the fiscal year, such as FY2026.
+func (totals Totals) Label() string {
+	return "FY" + strconv.Itoa(totals.fiscalYear)
+}
+
+// Count returns the number of transactions.
+func (totals Totals) Count() int {
+	return totals.count
+}
+
+// Gross returns the total given, which is used in donor totals.
+func (totals Totals) Gross() dec.Decimal {
+	return totals.gross
+}
+
+// Fee returns the total of the processing fees.
+func (totals Totals) Fee() dec.Decimal {
+	return totals.fee
+}
+
+// Net returns the total paid out, which is used in finance totals.
+func (totals Totals) Net() dec.Decimal {
+	return totals.net
+}
+
+// Covered returns the number of transactions in which the donor covered the
+// fee.
+func (totals Totals) Covered() int {
+	return totals.covered
+}
+
+// CoveredFees returns the fees covered by donors.
+func (totals Totals) CoveredFees() dec.Decimal {
+	return totals.coveredFees
+}
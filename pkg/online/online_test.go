@@ -0,0 +1,150 @@
+// ----------------------------------------------------------------------------
+//
+// Online giving tests
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package online
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	a "acorn_go/pkg/accounting"
+	"acorn_go/pkg/address"
+	dna "acorn_go/pkg/donations"
+	dn "acorn_go/pkg/donors"
+	"acorn_go/pkg/identity"
+	"os"
+	"path/filepath"
+	"testing"
+
+	dec "github.com/shopspring/decimal"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Test Support
+// ----------------------------------------------------------------------------
+
+// exportText is a platform export that begins with a byte order mark.  It
+// has a gift matched by email, a gift matched by name, a gift not matched,
+// and a gift not yet paid out whose email differs in case.
+const exportText = "\ufeffDate,Transaction ID,Name,Email,Gross,Fee,Net,Fee Covered,Payout Date\n" +
+	"10/01/2025,T1,Ann Apple,ann@example.com,$100.00,$3.20,$96.80,Yes,10/03/2025\n" +
+	"10/02/2025,T2,\"Baker, Bob\",,50.00,1.75,48.25,No,10/03/2025\n" +
+	"10/05/2025,T3,Carl Cook,carl@example.com,25.00,1.03,23.97,No,10/07/2025\n" +
+	"10/09/2025,T4,Ann Apple,Ann@Example.com,\"1,000.00\",29.30,970.70,No,\n"
+
+// date returns the date for the tests.
+func date(t *testing.T, value string) d.Date {
+	t.Helper()
+	var result, err = d.NewFromString(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+// readExport writes the export text to a file and reads it.
+func readExport(t *testing.T) Export {
+	var fileName = filepath.Join(t.TempDir(), "online.csv")
+	var err = os.WriteFile(fileName, []byte(exportText), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var export Export
+	export, err = ReadExport(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return export
+}
+
+// ----------------------------------------------------------------------------
+// Tests
+// ----------------------------------------------------------------------------
+
+// TestImport checks that the transactions are matched by email and by name
+// and become gifts at their gross amounts.
+func TestImport(t *testing.T) {
+	var export = readExport(t)
+	if export.Count() != 4 {
+		t.Fatalf("got %d transactions, want 4", export.Count())
+	}
+	var donorList = make(dn.DonorList)
+	var apple = dn.New("Apple, Ann", "Ann Apple", address.BlankAddress(), "ann@example.com", 1, false)
+	var baker = dn.New("Baker, Bob", "Bob Baker", address.BlankAddress(), "--", 1, false)
+	donorList.Add(&apple)
+	donorList.Add(&baker)
+	var resolver = identity.NewResolver(donorList.Keys(), make(identity.AliasTable))
+
+	var unmatched = export.Resolve(&donorList, &resolver)
+	if len(unmatched) != 1 || unmatched[0].ID() != "T3" {
+		t.Errorf("got %d unmatched transactions, want T3", len(unmatched))
+	}
+	var tests = []struct {
+		key       string
+		matchedBy string
+	}{
+		{"Apple, Ann", MatchedByEmail},
+		{"Baker, Bob", MatchedByName},
+		{"Carl Cook", NotMatched},
+		{"Apple, Ann", MatchedByEmail},
+	}
+	for index, tt := range tests {
+		var transaction = export.Transactions()[index]
+		if transaction.Key() != tt.key || transaction.MatchedBy() != tt.matchedBy {
+			t.Errorf("%s: got %s by %s", transaction.ID(), transaction.Key(), transaction.MatchedBy())
+		}
+	}
+
+	var donationList = make(dna.DonationList)
+	export.AddTo(donationList)
+	var donor = donationList.Get("Apple, Ann")
+	if !donor.Donation(a.FY2026).Equal(dec.NewFromInt(1100)) ||
+		!donor.NetDonation(a.FY2026).Equal(dec.RequireFromString("1067.5")) {
+		t.Errorf("got gross %s, net %s", donor.Donation(a.FY2026).String(), donor.NetDonation(a.FY2026).String())
+	}
+	var totals = export.Totals()
+	if len(totals) != 1 || totals[0].Covered() != 1 || !totals[0].Fee().Equal(dec.RequireFromString("35.28")) {
+		t.Error("incorrect fiscal year totals")
+	}
+}
+
+// TestReconcile checks that the payouts are paired with the deposits of the
+// same amount within the window and that the rest are reported.
+func TestReconcile(t *testing.T) {
+	var export = readExport(t)
+	var payouts = export.Payouts()
+	if len(payouts) != 2 || payouts[0].Count() != 2 || !payouts[0].Net().Equal(dec.RequireFromString("145.05")) {
+		t.Fatalf("incorrect payouts")
+	}
+	var deposits = []Deposit{
+		{date: date(t, "10/04/2025"), amount: dec.RequireFromString("145.05"), row: 2},
+		{date: date(t, "10/08/2025"), amount: dec.RequireFromString("23.00"), row: 3},
+		{date: date(t, "11/01/2025"), amount: dec.RequireFromString("500.00"), row: 4},
+	}
+	var reconciliations = Reconcile(payouts, deposits)
+	var expected = []string{StatusMatched, StatusAmountDiffers, StatusNoPayout}
+	if len(reconciliations) != len(expected) {
+		t.Fatalf("got %d reconciliations, want %d", len(reconciliations), len(expected))
+	}
+	for index, status := range expected {
+		if reconciliations[index].Status() != status {
+			t.Errorf("reconciliation %d: got %s, want %s", index, reconciliations[index].Status(), status)
+		}
+	}
+	if !reconciliations[1].Difference().Equal(dec.RequireFromString("-0.97")) {
+		t.Error("incorrect difference: " + reconciliations[1].Difference().String())
+	}
+	if _, err := NewTransaction(date(t, "10/01/2025"), "T9", "Ann", "", dec.NewFromInt(10),
+		dec.NewFromInt(1), dec.NewFromInt(10), false); err == nil {
+		t.Error("transaction with an incorrect net was accepted")
+	}
+}
@@ -0,0 +1,360 @@
+// ----------------------------------------------------------------------------
+//
+// Online Giving Transactions
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+// The online package imports the gifts made through the online giving
+// platform and reconciles the platform's payouts with the deposits in
+// Quickbooks.
+package online
+
+// The payment processor exports a CSV file with a row for each gift and the
+// columns Date, Transaction ID, Name, Email, Gross, Fee, Net, Fee Covered,
+// and Payout Date.  The gross is the amount the donor gave, the fee is the
+// processing fee, and the net is the amount paid out to the bank.  Fee
+// Covered is Yes if the donor added the fee to the gift.  Payout Date is
+// empty until the gift is paid out.  A refund has a negative gross.
+//
+// The gifts become donations at their gross amount, so donor totals use the
+// gross while finance totals use the net.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	dna "acorn_go/pkg/donations"
+	dn "acorn_go/pkg/donors"
+	"acorn_go/pkg/identity"
+	"acorn_go/pkg/spreadsheet"
+	"errors"
+	"slices"
+	"strings"
+
+	dec "github.com/shopspring/decimal"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Transaction is a gift made through the online giving platform.
+type Transaction struct {
+	date       d.Date
+	id         string
+	name       string
+	email      string
+	gross      dec.Decimal
+	fee        dec.Decimal
+	net        dec.Decimal
+	feeCovered bool
+	payout     d.Date
+	paidOut    bool
+	key        string
+	matchedBy  string
+	file       string
+	row        int
+}
+
+// Export holds the transactions of a platform export in the order of the
+// file.
+type Export struct {
+	transactions []Transaction
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Platform export column names
+const (
+	columnDate       = "Date"
+	columnID         = "Transaction ID"
+	columnName       = "Name"
+	columnEmail      = "Email"
+	columnGross      = "Gross"
+	columnFee        = "Fee"
+	columnNet        = "Net"
+	columnFeeCovered = "Fee Covered"
+	columnPayout     = "Payout Date"
+)
+
+// How a transaction was matched to a donor
+const (
+	MatchedByEmail = "Email"
+	MatchedByName  = "Name"
+	NotMatched     = "Not matched"
+)
+
+// Transaction type of the gifts added to the donation list
+const transType = "Online"
+
+// ----------------------------------------------------------------------------
+// Factory Functions
+// ----------------------------------------------------------------------------
+
+// NewTransaction returns a transaction of the gross amount less the fee.
+// The net must be the gross less the fee.
+func NewTransaction(
+	date d.Date,
+	id string,
+	name string,
+	email string,
+	gross dec.Decimal,
+	fee dec.Decimal,
+	net dec.Decimal,
+	feeCovered bool) (Transaction, error) {
+
+	var transaction = Transaction{
+		date:       date,
+		id:         strings.TrimSpace(id),
+		name:       strings.TrimSpace(name),
+		email:      strings.TrimSpace(email),
+		gross:      gross,
+		fee:        fee,
+		net:        net,
+		feeCovered: feeCovered,
+		key:        strings.TrimSpace(name),
+		matchedBy:  NotMatched,
+	}
+	var err error
+	switch {
+	case transaction.id == "":
+		err = errors.New("transaction ID must not be empty")
+	case transaction.name == "" && transaction.email == "":
+		err = errors.New("transaction must have a name or an email")
+	case gross.IsZero():
+		err = errors.New("gross amount must not be zero")
+	case !gross.Sub(fee).Equal(net):
+		err = errors.New("net must be the gross less the fee: " + id)
+	}
+	if transaction.key == "" {
+		transaction.key = transaction.email
+	}
+	return transaction, err
+}
+
+// NewExport returns the transactions in the rows of a platform export.  Each
+// transaction must have a different ID.
+func NewExport(sprdsht *spreadsheet.Spreadsheet) (Export, error) {
+	var export = Export{transactions: []Transaction{}}
+	var numRows = sprdsht.Size()
+	for row := 1; row < numRows; row++ {
+		var transaction, err = readTransaction(sprdsht, row)
+		if err == nil && slices.ContainsFunc(export.transactions, func(other Transaction) bool {
+			return other.id == transaction.id
+		}) {
+			err = errors.New("transaction is listed more than once: " + transaction.id)
+		}
+		if err != nil {
+			return export, sprdsht.WrapRow(err, "processing online transaction", row)
+		}
+		export.transactions = append(export.transactions, transaction)
+	}
+	return export, nil
+}
+
+// ReadExport reads the transactions from a platform CSV export.
+func ReadExport(fileName string) (Export, error) {
+	var sprdsht, err = spreadsheet.ProcessCSV(fileName)
+	if err != nil {
+		return Export{transactions: []Transaction{}}, err
+	}
+	return NewExport(&sprdsht)
+}
+
+// readTransaction returns the transaction in a row of a platform export.
+func readTransaction(sprdsht *spreadsheet.Spreadsheet, row int) (Transaction, error) {
+	var id, name, email, covered, payoutText string
+	var date, payout d.Date
+	var gross, fee, net dec.Decimal
+	var err error
+
+	date, err = sprdsht.CellDate(row, columnDate)
+	if err == nil {
+		id, err = sprdsht.Cell(row, columnID)
+	}
+	if err == nil {
+		name, err = sprdsht.Cell(row, columnName)
+	}
+	if err == nil {
+		email, err = sprdsht.Cell(row, columnEmail)
+	}
+	if err == nil {
+		gross, err = cellAmount(sprdsht, row, columnGross)
+	}
+	if err == nil {
+		fee, err = cellAmount(sprdsht, row, columnFee)
+	}
+	if err == nil {
+		net, err = cellAmount(sprdsht, row, columnNet)
+	}
+	if err == nil {
+		covered, err = sprdsht.Cell(row, columnFeeCovered)
+	}
+	if err == nil {
+		payoutText, err = sprdsht.Cell(row, columnPayout)
+	}
+	if err == nil && payoutText != "" {
+		payout, err = d.NewFromString(payoutText)
+	}
+	if err != nil {
+		return Transaction{}, err
+	}
+	var feeCovered = strings.EqualFold(covered, "Yes") || strings.EqualFold(covered, "True")
+	var transaction Transaction
+	transaction, err = NewTransaction(date, id, name, email, gross, fee, net, feeCovered)
+	transaction.payout = payout
+	transaction.paidOut = payoutText != ""
+	transaction.file = sprdsht.FileName()
+	transaction.row = row + 1
+	return transaction, err
+}
+
+// cellAmount returns the amount in a cell of the export.  The processor
+// may show amounts with a dollar sign.
+func cellAmount(sprdsht *spreadsheet.Spreadsheet, row int, heading string) (dec.Decimal, error) {
+	var value, err = sprdsht.Cell(row, heading)
+	if err != nil {
+		return dec.Zero, err
+	}
+	value = strings.ReplaceAll(strings.ReplaceAll(value, "$", ""), ",", "")
+	if value == "" {
+		return dec.Zero, nil
+	}
+	return dec.NewFromString(value)
+}
+
+// ----------------------------------------------------------------------------
+// Transaction Methods
+// ----------------------------------------------------------------------------
+
+// Date returns the date of the gift.
+func (transaction Transaction) Date() d.Date {
+	return transaction.date
+}
+
+// ID returns the processor's identifier of the transaction.
+func (transaction Transaction) ID() string {
+	return transaction.id
+}
+
+// Name returns the name of the donor as given in the export.
+func (transaction Transaction) Name() string {
+	return transaction.name
+}
+
+// Email returns the email of the donor as given in the export.
+func (transaction Transaction) Email() string {
+	return transaction.email
+}
+
+// Gross returns the amount the donor gave.
+func (transaction Transaction) Gross() dec.Decimal {
+	return transaction.gross
+}
+
+// Fee returns the processing fee.
+func (transaction Transaction) Fee() dec.Decimal {
+	return transaction.fee
+}
+
+// Net returns the amount paid out to the bank.
+func (transaction Transaction) Net() dec.Decimal {
+	return transaction.net
+}
+
+// FeeCovered returns true if the donor added the fee to the gift.
+func (transaction Transaction) FeeCovered() bool {
+	return transaction.feeCovered
+}
+
+// Payout returns the date the gift was paid out.  The second value is false
+// if the gift has not been paid out.
+func (transaction Transaction) Payout() (d.Date, bool) {
+	return transaction.payout, transaction.paidOut
+}
+
+// Key returns the key of the donor in the donation list.
+func (transaction Transaction) Key() string {
+	return transaction.key
+}
+
+// MatchedBy returns how the transaction was matched to a donor: Email,
+// Name, or Not matched.
+func (transaction Transaction) MatchedBy() string {
+	return transaction.matchedBy
+}
+
+// Row returns the row of the transaction in the export, as shown by Excel.
+func (transaction Transaction) Row() int {
+	return transaction.row
+}
+
+// Gift returns the donation for the transaction.  Its amount is the gross,
+// and the fee is recorded with it.
+func (transaction Transaction) Gift() dn.Gift {
+	var gift = dn.NewGift(transaction.date, transaction.gross, transType, transaction.file, transaction.row)
+	gift.SetFee(transaction.fee)
+	return gift
+}
+
+// ----------------------------------------------------------------------------
+// Export Methods
+// ----------------------------------------------------------------------------
+
+// Count returns the number of transactions.
+func (export *Export) Count() int {
+	return len(export.transactions)
+}
+
+// Transactions returns the transactions in the order of the export.
+func (export *Export) Transactions() []Transaction {
+	return slices.Clone(export.transactions)
+}
+
+// Resolve matches each transaction to a donor, first by the email with the
+// donor list and then by the name with the resolver.  It returns the
+// transactions that matched no donor.  A transaction that is not matched
+// keeps its name, or its email if it has no name, as its key.
+func (export *Export) Resolve(donorList *dn.DonorList, resolver *identity.Resolver) []Transaction {
+	var unmatched = []Transaction{}
+	for index := range export.transactions {
+		var transaction = &export.transactions[index]
+		if transaction.email != "" {
+			var donor, err = donorList.GetByEmail(transaction.email)
+			if err == nil {
+				transaction.key = donor.Key()
+				transaction.matchedBy = MatchedByEmail
+				continue
+			}
+		}
+		if transaction.name != "" && resolver.Resolve(transaction.name).Resolved() {
+			transaction.key = resolver.KeyOf(transaction.name)
+			transaction.matchedBy = MatchedByName
+			continue
+		}
+		unmatched = append(unmatched, *transaction)
+	}
+	return unmatched
+}
+
+// AddTo adds the gifts of the transactions to the donors in the donation
+// list at their gross amounts and returns the number added.  A donor who is
+// not in the list is added.
+func (export *Export) AddTo(donationList dna.DonationList) int {
+	for _, transaction := range export.transactions {
+		if !donationList.Contains(transaction.key) {
+			var donor = dn.NewDonorWithDonation(transaction.key)
+			donationList[transaction.key] = &donor
+		}
+		donationList.Get(transaction.key).AddGift(transaction.Gift())
+	}
+	return len(export.transactions)
+}
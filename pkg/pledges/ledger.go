@@ -0,0 +1,283 @@
+// ----------------------------------------------------------------------------
+//
+// Pledge Ledger
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package pledges
+
+// The pledge ledger is kept in a spreadsheet with a row for each pledge and
+// the columns Pledge, "Donor full name", Pledge Amount, Schedule,
+// Installments, Start Date, and Campaign.  The Campaign column may be
+// omitted or left empty.
+//
+// The gifts of the donor are applied to the open installments of the
+// donor's pledges.  Each gift greater than zero, made on or before the
+// report date, pays the earliest unpaid installment of the oldest pledge it
+// may be applied to, and any amount left over pays the next installment.
+// A gift may be applied to a pledge if it is made on or after the start
+// date and is to the campaign of the pledge or to no campaign.  The amount
+// of a gift beyond what the pledges owe is not a pledge payment.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	a "acorn_go/pkg/accounting"
+	dna "acorn_go/pkg/donations"
+	"acorn_go/pkg/identity"
+	"acorn_go/pkg/spreadsheet"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+
+	dec "github.com/shopspring/decimal"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Ledger holds the pledges in the order of the pledge spreadsheet.
+type Ledger struct {
+	pledges []Pledge
+}
+
+// Receipts is the amount expected on the pledges in a fiscal year.
+type Receipts struct {
+	fiscalYear   int
+	installments int
+	amount       dec.Decimal
+	overdue      dec.Decimal
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Pledge ledger column names
+const (
+	columnPledge       = "Pledge"
+	columnDonor        = "Donor full name"
+	columnAmount       = "Pledge Amount"
+	columnSchedule     = "Schedule"
+	columnInstallments = "Installments"
+	columnStart        = "Start Date"
+	columnCampaign     = "Campaign"
+)
+
+// ----------------------------------------------------------------------------
+// Factory Functions
+// ----------------------------------------------------------------------------
+
+// NewLedger returns the pledges in the rows of a pledge spreadsheet.  Each
+// pledge must have a different identifier.
+func NewLedger(sprdsht *spreadsheet.Spreadsheet) (Ledger, error) {
+	var ledger = Ledger{pledges: []Pledge{}}
+	var numRows = sprdsht.Size()
+	for row := 1; row < numRows; row++ {
+		var pledge, err = readPledge(sprdsht, row)
+		if err == nil && slices.ContainsFunc(ledger.pledges, func(other Pledge) bool {
+			return other.id == pledge.id
+		}) {
+			err = errors.New("pledge is listed more than once: " + pledge.id)
+		}
+		if err != nil {
+			return ledger, sprdsht.WrapRow(err, "processing pledge", row)
+		}
+		ledger.pledges = append(ledger.pledges, pledge)
+	}
+	return ledger, nil
+}
+
+// ReadLedger reads the pledges from a pledge spreadsheet.
+func ReadLedger(fileName string, tab string) (Ledger, error) {
+	var sprdsht, err = spreadsheet.ProcessData(fileName, tab)
+	if err != nil {
+		return Ledger{pledges: []Pledge{}}, err
+	}
+	return NewLedger(&sprdsht)
+}
+
+// readPledge returns the pledge in a row of the pledge spreadsheet.
+func readPledge(sprdsht *spreadsheet.Spreadsheet, row int) (Pledge, error) {
+	var id, donor, scheduleName, countText, campaign string
+	var amount dec.Decimal
+	var schedule Schedule
+	var count int
+	var start d.Date
+	var err error
+
+	id, err = sprdsht.Cell(row, columnPledge)
+	if err == nil {
+		donor, err = sprdsht.Cell(row, columnDonor)
+	}
+	if err == nil {
+		amount, err = sprdsht.CellDecimal(row, columnAmount)
+	}
+	if err == nil {
+		scheduleName, err = sprdsht.Cell(row, columnSchedule)
+	}
+	if err == nil {
+		schedule, err = ParseSchedule(scheduleName)
+	}
+	if err == nil {
+		countText, err = sprdsht.Cell(row, columnInstallments)
+	}
+	if err == nil {
+		count, err = strconv.Atoi(strings.TrimSpace(countText))
+	}
+	if err == nil {
+		start, err = sprdsht.CellDate(row, columnStart)
+	}
+	if err == nil && sprdsht.HasColumn(columnCampaign) {
+		campaign, err = sprdsht.Cell(row, columnCampaign)
+	}
+	if err != nil {
+		return Pledge{}, err
+	}
+	var pledge Pledge
+	pledge, err = NewPledge(id, donor, amount, schedule, count, start, strings.TrimSpace(campaign))
+	pledge.row = row + 1
+	return pledge, err
+}
+
+// ----------------------------------------------------------------------------
+// Ledger Methods
+// ----------------------------------------------------------------------------
+
+// Count returns the number of pledges.
+func (ledger *Ledger) Count() int {
+	return len(ledger.pledges)
+}
+
+// Pledges returns the pledges in the order of the pledge spreadsheet.
+func (ledger *Ledger) Pledges() []Pledge {
+	return slices.Clone(ledger.pledges)
+}
+
+// Resolve matches the donor of each pledge to a donor key and returns the
+// names that matched no donor.  A pledge whose donor is not matched keeps
+// the name as its key.
+func (ledger *Ledger) Resolve(resolver *identity.Resolver) []string {
+	var unmatched = []string{}
+	for index := range ledger.pledges {
+		var pledge = &ledger.pledges[index]
+		if !resolver.Resolve(pledge.donor).Resolved() && !slices.Contains(unmatched, pledge.donor) {
+			unmatched = append(unmatched, pledge.donor)
+		}
+		pledge.key = resolver.KeyOf(pledge.donor)
+	}
+	return unmatched
+}
+
+// Apply applies the gifts in the donation list made on or before the date,
+// net of the reversals made by then, to the installments of the pledges.
+// Payments applied before are discarded.
+func (ledger *Ledger) Apply(donationList dna.DonationList, asOf d.Date) {
+	//
+	// Group the pledges of each donor, oldest first
+	//
+	var byDonor = make(map[string][]*Pledge)
+	for index := range ledger.pledges {
+		var pledge = &ledger.pledges[index]
+		for count := range pledge.installments {
+			pledge.installments[count].paid = dec.Zero
+		}
+		byDonor[pledge.key] = append(byDonor[pledge.key], pledge)
+	}
+	//
+	// Apply the gifts of each donor
+	//
+	for key, pledges := range byDonor {
+		if !donationList.Contains(key) {
+			continue
+		}
+		slices.SortStableFunc(pledges, func(x *Pledge, y *Pledge) int {
+			return a.CompareDates(x.start, y.start)
+		})
+		for _, gift := range donationList.Get(key).Through(asOf).Gifts() {
+			var remaining = gift.Amount()
+			if !remaining.GreaterThan(dec.Zero) {
+				continue
+			}
+			for _, pledge := range pledges {
+				if pledge.accepts(gift.Date(), gift.Campaign()) {
+					remaining = pledge.apply(remaining)
+				}
+			}
+		}
+	}
+}
+
+// Expected returns the unpaid amount of the installments in each fiscal
+// year in order of the fiscal years.  Overdue installments are expected in
+// the fiscal year of the date.
+func (ledger *Ledger) Expected(asOf d.Date) []Receipts {
+	var result = []Receipts{}
+	for _, pledge := range ledger.pledges {
+		for _, installment := range pledge.installments {
+			if installment.IsPaid() {
+				continue
+			}
+			var fiscalYear = installment.FiscalYear()
+			if installment.IsOverdue(asOf) {
+				fiscalYear = a.FiscalYearNumber(asOf)
+			}
+			var index = slices.IndexFunc(result, func(receipts Receipts) bool {
+				return receipts.fiscalYear == fiscalYear
+			})
+			if index < 0 {
+				result = append(result, Receipts{fiscalYear: fiscalYear, amount: dec.Zero, overdue: dec.Zero})
+				index = len(result) - 1
+			}
+			var receipts = &result[index]
+			receipts.installments++
+			receipts.amount = receipts.amount.Add(installment.Unpaid())
+			if installment.IsOverdue(asOf) {
+				receipts.overdue = receipts.overdue.Add(installment.Unpaid())
+			}
+		}
+	}
+	slices.SortFunc(result, func(x Receipts, y Receipts) int {
+		return x.fiscalYear - y.fiscalYear
+	})
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Receipts Methods
+// ----------------------------------------------------------------------------
+
+// FiscalYear returns the number of the fiscal year, the calendar year in
+// which it ends.
+func (receipts Receipts) FiscalYear() int {
+	return receipts.fiscalYear
+}
+
+// Label returns the name of the fiscal year, such as FY2027.
+func (receipts Receipts) Label() string {
+	return "FY" + strconv.Itoa(receipts.fiscalYear)
+}
+
+// Installments returns the number of installments expected.
+func (receipts Receipts) Installments() int {
+	return receipts.installments
+}
+
+// Amount returns the unpaid amount expected in the fiscal year.
+func (receipts Receipts) Amount() dec.Decimal {
+	return receipts.amount
+}
+
+// Overdue returns the part of the amount that is overdue.
+func (receipts Receipts) Overdue() dec.Decimal {
+	return receipts.overdue
+}
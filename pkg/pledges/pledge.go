@@ -0,0 +1,332 @@
+// ----------------------------------------------------------------------------
+//
+// Pledge
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package pledges
+
+// A pledge is a promise by a donor to give an amount over time.  The amount
+// is divided into installments due monthly, quarterly, or annually starting
+// on the start date.  Each installment is the amount divided by the number
+// of installments, rounded down to the cent, and the last installment also
+// takes the cents left over.  An installment is overdue when it is not paid
+// in full by its due date.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	a "acorn_go/pkg/accounting"
+	"acorn_go/pkg/campaigns"
+	"errors"
+	"slices"
+	"strings"
+
+	dec "github.com/shopspring/decimal"
+	"github.com/waysys/assert/assert"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Schedule is how often the installments of a pledge are due.
+type Schedule int
+
+// Installment is a payment due on a pledge and the amount paid toward it.
+type Installment struct {
+	due    d.Date
+	amount dec.Decimal
+	paid   dec.Decimal
+}
+
+// Pledge is a donor's promise to give an amount in installments.
+type Pledge struct {
+	id           string
+	donor        string
+	key          string
+	amount       dec.Decimal
+	schedule     Schedule
+	start        d.Date
+	campaign     string
+	installments []Installment
+	row          int
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const NumSchedules = 3
+
+const (
+	Monthly   = Schedule(0)
+	Quarterly = Schedule(1)
+	Annual    = Schedule(2)
+)
+
+var Schedules = [NumSchedules]Schedule{
+	Monthly,
+	Quarterly,
+	Annual,
+}
+
+var scheduleNames = [NumSchedules]string{
+	"Monthly",
+	"Quarterly",
+	"Annual",
+}
+
+// Months from one installment to the next
+var scheduleMonths = [NumSchedules]int{1, 3, 12}
+
+// ----------------------------------------------------------------------------
+// Factory Functions
+// ----------------------------------------------------------------------------
+
+// NewPledge returns a pledge of the amount by the donor in the number of
+// installments on the schedule beginning on the start date.  The campaign
+// may be empty.
+func NewPledge(
+	id string,
+	donor string,
+	amount dec.Decimal,
+	schedule Schedule,
+	count int,
+	start d.Date,
+	campaign string) (Pledge, error) {
+
+	var pledge = Pledge{
+		id:       id,
+		donor:    donor,
+		key:      donor,
+		amount:   amount,
+		schedule: schedule,
+		start:    start,
+		campaign: campaign,
+	}
+	switch {
+	case id == "" || donor == "":
+		return pledge, errors.New("pledge and donor must not be empty")
+	case !amount.GreaterThan(dec.Zero):
+		return pledge, errors.New("pledge amount must be greater than zero")
+	case count < 1:
+		return pledge, errors.New("number of installments must be at least 1")
+	}
+	//
+	// Divide the amount into installments
+	//
+	var each = amount.Div(dec.NewFromInt(int64(count))).RoundDown(2)
+	var remaining = amount
+	for index := 0; index < count; index++ {
+		var installment = Installment{
+			due:    a.AddMonths(start, index*schedule.Months()),
+			amount: each,
+			paid:   dec.Zero,
+		}
+		if index == count-1 {
+			installment.amount = remaining
+		}
+		remaining = remaining.Sub(installment.amount)
+		pledge.installments = append(pledge.installments, installment)
+	}
+	return pledge, nil
+}
+
+// ParseSchedule returns the schedule with the name, ignoring case.
+func ParseSchedule(name string) (Schedule, error) {
+	for _, schedule := range Schedules {
+		if strings.EqualFold(strings.TrimSpace(name), schedule.String()) {
+			return schedule, nil
+		}
+	}
+	return Annual, errors.New("schedule must be Monthly, Quarterly, or Annual: " + name)
+}
+
+// ----------------------------------------------------------------------------
+// Schedule Methods
+// ----------------------------------------------------------------------------
+
+// String returns the name of the schedule.
+func (schedule Schedule) String() string {
+	assert.Assert(Monthly <= schedule && schedule <= Annual, "invalid schedule")
+	return scheduleNames[schedule]
+}
+
+// Months returns the number of months from one installment to the next.
+func (schedule Schedule) Months() int {
+	assert.Assert(Monthly <= schedule && schedule <= Annual, "invalid schedule")
+	return scheduleMonths[schedule]
+}
+
+// ----------------------------------------------------------------------------
+// Installment Methods
+// ----------------------------------------------------------------------------
+
+// Due returns the date the installment is due.
+func (installment Installment) Due() d.Date {
+	return installment.due
+}
+
+// Amount returns the amount of the installment.
+func (installment Installment) Amount() dec.Decimal {
+	return installment.amount
+}
+
+// Paid returns the amount paid toward the installment.
+func (installment Installment) Paid() dec.Decimal {
+	return installment.paid
+}
+
+// Unpaid returns the amount of the installment not yet paid.
+func (installment Installment) Unpaid() dec.Decimal {
+	return installment.amount.Sub(installment.paid)
+}
+
+// IsPaid returns true if the installment is paid in full.
+func (installment Installment) IsPaid() bool {
+	return !installment.Unpaid().GreaterThan(dec.Zero)
+}
+
+// IsOverdue returns true if the installment was due before the date and is
+// not paid in full.
+func (installment Installment) IsOverdue(asOf d.Date) bool {
+	return installment.due.Before(asOf) && !installment.IsPaid()
+}
+
+// DaysOverdue returns the number of days from the due date to the date.
+func (installment Installment) DaysOverdue(asOf d.Date) int {
+	return max(a.DaysBetween(installment.due, asOf), 0)
+}
+
+// FiscalYear returns the number of the fiscal year the installment is due.
+func (installment Installment) FiscalYear() int {
+	return a.FiscalYearNumber(installment.due)
+}
+
+// ----------------------------------------------------------------------------
+// Pledge Methods
+// ----------------------------------------------------------------------------
+
+// ID returns the identifier of the pledge.
+func (pledge *Pledge) ID() string {
+	return pledge.id
+}
+
+// Donor returns the name of the donor as given in the pledge ledger.
+func (pledge *Pledge) Donor() string {
+	return pledge.donor
+}
+
+// Key returns the key of the donor in the donation list.
+func (pledge *Pledge) Key() string {
+	return pledge.key
+}
+
+// Amount returns the amount pledged.
+func (pledge *Pledge) Amount() dec.Decimal {
+	return pledge.amount
+}
+
+// Schedule returns how often the installments are due.
+func (pledge *Pledge) Schedule() Schedule {
+	return pledge.schedule
+}
+
+// Start returns the date the first installment is due.
+func (pledge *Pledge) Start() d.Date {
+	return pledge.start
+}
+
+// Campaign returns the campaign of the pledge, or an empty string if the
+// pledge is not for a campaign.
+func (pledge *Pledge) Campaign() string {
+	return pledge.campaign
+}
+
+// Row returns the row of the pledge in the pledge ledger, as shown by Excel.
+func (pledge *Pledge) Row() int {
+	return pledge.row
+}
+
+// Installments returns the installments of the pledge in order of their due
+// dates.
+func (pledge *Pledge) Installments() []Installment {
+	return slices.Clone(pledge.installments)
+}
+
+// Paid returns the amount paid toward the pledge.
+func (pledge *Pledge) Paid() dec.Decimal {
+	var paid = dec.Zero
+	for _, installment := range pledge.installments {
+		paid = paid.Add(installment.paid)
+	}
+	return paid
+}
+
+// Balance returns the amount of the pledge not yet paid.
+func (pledge *Pledge) Balance() dec.Decimal {
+	return pledge.amount.Sub(pledge.Paid())
+}
+
+// IsFulfilled returns true if the pledge is paid in full.
+func (pledge *Pledge) IsFulfilled() bool {
+	return !pledge.Balance().GreaterThan(dec.Zero)
+}
+
+// Overdue returns the unpaid amount of the installments overdue on the date.
+func (pledge *Pledge) Overdue(asOf d.Date) dec.Decimal {
+	var overdue = dec.Zero
+	for _, installment := range pledge.installments {
+		if installment.IsOverdue(asOf) {
+			overdue = overdue.Add(installment.Unpaid())
+		}
+	}
+	return overdue
+}
+
+// Status returns Fulfilled, Overdue, or Current for the pledge on the date.
+func (pledge *Pledge) Status(asOf d.Date) string {
+	switch {
+	case pledge.IsFulfilled():
+		return "Fulfilled"
+	case pledge.Overdue(asOf).GreaterThan(dec.Zero):
+		return "Overdue"
+	default:
+		return "Current"
+	}
+}
+
+// accepts returns true if a gift to the campaign on the date may be applied
+// to the pledge.  Gifts before the start date or to another campaign are
+// not payments on the pledge.
+func (pledge *Pledge) accepts(date d.Date, campaign string) bool {
+	if date.Before(pledge.start) {
+		return false
+	}
+	return pledge.campaign == "" || campaign == pledge.campaign || campaign == campaigns.NoCampaign
+}
+
+// apply pays the installments of the pledge in order of their due dates with
+// the amount and returns the amount left over.
+func (pledge *Pledge) apply(amount dec.Decimal) dec.Decimal {
+	for index := range pledge.installments {
+		if !amount.GreaterThan(dec.Zero) {
+			break
+		}
+		var installment = &pledge.installments[index]
+		var payment = dec.Min(amount, installment.Unpaid())
+		if payment.GreaterThan(dec.Zero) {
+			installment.paid = installment.paid.Add(payment)
+			amount = amount.Sub(payment)
+		}
+	}
+	return amount
+}
@@ -0,0 +1,189 @@
+// ----------------------------------------------------------------------------
+//
+// Pledge tests
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package pledges
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"acorn_go/pkg/campaigns"
+	dna "acorn_go/pkg/donations"
+	dn "acorn_go/pkg/donors"
+	"testing"
+
+	dec "github.com/shopspring/decimal"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Test Support
+// ----------------------------------------------------------------------------
+
+// date returns the date for the tests.
+func date(t *testing.T, value string) d.Date {
+	t.Helper()
+	var result, err = d.NewFromString(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+// newPledge returns a pledge for the tests.
+func newPledge(t *testing.T, id string, donor string, amount int64, schedule Schedule, count int, start string,
+	campaign string) Pledge {
+	var pledge, err = NewPledge(id, donor, dec.NewFromInt(amount), schedule, count, date(t, start), campaign)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pledge
+}
+
+// gift returns a gift of the amount on the date to the campaign.
+func gift(t *testing.T, value string, amount int64, campaign string) dn.Gift {
+	t.Helper()
+	var result = dn.NewGift(date(t, value), dec.NewFromInt(amount), "Payment", "", 0)
+	result.SetCampaign(campaign)
+	return result
+}
+
+// ----------------------------------------------------------------------------
+// Tests
+// ----------------------------------------------------------------------------
+
+// TestNewPledge checks the due dates and amounts of the installments.
+func TestNewPledge(t *testing.T) {
+	var pledge = newPledge(t, "P1", "Ann", 1000, Quarterly, 3, "11/30/2025", "")
+	var tests = []struct {
+		due    string
+		amount string
+	}{
+		{"11/30/2025", "333.33"},
+		{"02/28/2026", "333.33"},
+		{"05/30/2026", "333.34"},
+	}
+	var installments = pledge.Installments()
+	if len(installments) != len(tests) {
+		t.Fatalf("got %d installments, want %d", len(installments), len(tests))
+	}
+	for index, tt := range tests {
+		var installment = installments[index]
+		if installment.Due().String() != date(t, tt.due).String() || installment.Amount().StringFixed(2) != tt.amount {
+			t.Errorf("installment %d: got %s %s, want %s %s", index, installment.Due().String(),
+				installment.Amount().StringFixed(2), tt.due, tt.amount)
+		}
+	}
+	if schedule, err := ParseSchedule(" monthly "); err != nil || schedule != Monthly {
+		t.Errorf("ParseSchedule: got %v, %v", schedule, err)
+	}
+	var invalid = []struct {
+		id     string
+		amount int64
+		count  int
+	}{
+		{"", 100, 1},
+		{"P2", 0, 1},
+		{"P3", 100, 0},
+	}
+	for _, tt := range invalid {
+		if _, err := NewPledge(tt.id, "Ann", dec.NewFromInt(tt.amount), Annual, tt.count, date(t, "07/01/2025"), ""); err == nil {
+			t.Errorf("invalid pledge %q was accepted", tt.id)
+		}
+	}
+}
+
+// TestApply checks that gifts pay off the oldest pledge first, skip gifts
+// before the start or to another campaign, and the balances, overdue
+// installments, and expected receipts.
+func TestApply(t *testing.T) {
+	var ledger = Ledger{pledges: []Pledge{
+		newPledge(t, "P2", "Ann", 600, Annual, 3, "07/01/2025", campaigns.SpringEvent),
+		newPledge(t, "P1", "Ann", 300, Annual, 3, "07/01/2024", ""),
+		newPledge(t, "P3", "Bob", 1200, Monthly, 12, "01/01/2026", ""),
+	}}
+	var donationList = make(dna.DonationList)
+	for _, key := range []string{"Ann", "Bob"} {
+		var donor = dn.NewDonorWithDonation(key)
+		donationList[key] = &donor
+	}
+	donationList["Ann"].AddGift(gift(t, "06/01/2024", 500, campaigns.NoCampaign))
+	donationList["Ann"].AddGift(gift(t, "07/15/2024", 100, campaigns.NoCampaign))
+	donationList["Ann"].AddGift(gift(t, "08/01/2025", 250, campaigns.NoCampaign))
+	donationList["Ann"].AddGift(gift(t, "12/01/2025", 400, campaigns.YearEndAppeal))
+	donationList["Bob"].AddGift(gift(t, "01/05/2026", 300, campaigns.NoCampaign))
+	donationList["Bob"].AddGift(gift(t, "07/01/2026", 500, campaigns.NoCampaign))
+	var asOf = date(t, "06/30/2026")
+	ledger.Apply(donationList, asOf)
+
+	var tests = []struct {
+		id      string
+		paid    int64
+		overdue int64
+		status  string
+	}{
+		{"P2", 50, 150, "Overdue"},
+		{"P1", 300, 0, "Fulfilled"},
+		{"P3", 300, 300, "Overdue"},
+	}
+	for index, tt := range tests {
+		var pledge = ledger.Pledges()[index]
+		if pledge.ID() != tt.id || pledge.Paid().IntPart() != tt.paid ||
+			pledge.Overdue(asOf).IntPart() != tt.overdue || pledge.Status(asOf) != tt.status {
+			t.Errorf("%s: got paid %s, overdue %s, %s", pledge.ID(), pledge.Paid().String(),
+				pledge.Overdue(asOf).String(), pledge.Status(asOf))
+		}
+	}
+	var expected = []struct {
+		fiscalYear   int
+		installments int
+		amount       int64
+		overdue      int64
+	}{
+		{2026, 7, 850, 450},
+		{2027, 5, 600, 0},
+	}
+	var receipts = ledger.Expected(asOf)
+	if len(receipts) != len(expected) {
+		t.Fatalf("got %d fiscal years, want %d", len(receipts), len(expected))
+	}
+	for index, tt := range expected {
+		var got = receipts[index]
+		if got.FiscalYear() != tt.fiscalYear || got.Installments() != tt.installments ||
+			got.Amount().IntPart() != tt.amount || got.Overdue().IntPart() != tt.overdue {
+			t.Errorf("%s: got %d installments, %s, overdue %s", got.Label(), got.Installments(),
+				got.Amount().String(), got.Overdue().String())
+		}
+	}
+}
+
+// TestApplyLaterRefund checks that a refund made after the report date does
+// not reduce the payments applied through that date.
+func TestApplyLaterRefund(t *testing.T) {
+	var ledger = Ledger{pledges: []Pledge{
+		newPledge(t, "P1", "Ann", 600, Annual, 2, "07/01/2025", ""),
+	}}
+	var donor = dn.NewDonorWithDonation("Ann")
+	donor.AddGift(gift(t, "08/01/2025", 300, campaigns.NoCampaign))
+	donor.AddGift(dn.NewGift(date(t, "02/01/2026"), dec.NewFromInt(-300), "Refund", "", 0))
+	var donationList = dna.DonationList{"Ann": &donor}
+
+	var asOf = date(t, "12/31/2025")
+	ledger.Apply(donationList, asOf)
+	if pledge := ledger.Pledges()[0]; pledge.Paid().IntPart() != 300 || pledge.Overdue(asOf).IntPart() != 0 {
+		t.Errorf("as of %s: got paid %s, overdue %s", asOf.String(), pledge.Paid().String(), pledge.Overdue(asOf).String())
+	}
+	asOf = date(t, "03/31/2026")
+	ledger.Apply(donationList, asOf)
+	if pledge := ledger.Pledges()[0]; pledge.Paid().IntPart() != 0 {
+		t.Errorf("as of %s: got paid %s, want 0", asOf.String(), pledge.Paid().String())
+	}
+}
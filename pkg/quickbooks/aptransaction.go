@@ -47,8 +47,8 @@ type APTransaction struct {
 const (
 	accountScholarship     = "7040"
 	accountDependednt      = "7045"
-	accountChecking        = "1010"
-	accountIndividualGrant = "7050"
+	AccountChecking        = "1010" // number of the operating account
+	AccountIndividualGrant = "7050" // number of the individual grants account
 )
 
 // Returned check from NC State
@@ -186,12 +186,12 @@ func (trans *APTransaction) IsScholarshipAccount() bool {
 
 // IsIndividualGrantAccount returns true if the account is the 7050
 func (trans *APTransaction) IsIndividualGrantAccount() bool {
-	return trans.Account() == accountIndividualGrant
+	return trans.Account() == AccountIndividualGrant
 }
 
 // IsBankAccount returns true if the account is 1010 - Cash
 func (trans *APTransaction) IsBankAccount() bool {
-	return trans.Account() == accountChecking
+	return trans.Account() == AccountChecking
 }
 
 // GTZero returns true if the amount is greater zero.
@@ -18,6 +18,7 @@ package quickbooks
 // ----------------------------------------------------------------------------
 
 import (
+	l "acorn_go/pkg/logging"
 	"acorn_go/pkg/spreadsheet"
 	"strconv"
 
@@ -77,10 +78,13 @@ func processBills(sprdsht *spreadsheet.Spreadsheet,
 	for row := 1; row < numRows; row++ {
 		var bill, err, okToUse = processBill(sprdsht, row, transList)
 		if err != nil {
-			return err
+			return sprdsht.WrapRow(err, "processing bill", row)
 		}
 		if okToUse {
 			billList.Add(&bill)
+		} else {
+			l.Warn("bill skipped because it has no matching accounts payable transaction",
+				l.File(sprdsht.FileName()), l.Row(row+1))
 		}
 	}
 	return nil
@@ -88,7 +88,7 @@ func NewQuickbooksTransactionType(value string) QuickbooksTransactionType {
 
 // String returns the string description of the transaction type.
 func (transType QuickbooksTransactionType) String() string {
-	assert.Assert(0 <= transType && transType <= 3,
+	assert.Assert(0 <= transType && transType <= Expenditure,
 		"Incorrect value for Quickbooks transaction type: "+strconv.Itoa(int(transType)))
 	return strValues[transType]
 }
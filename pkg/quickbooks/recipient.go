@@ -44,7 +44,9 @@ func NewRecipient(name string) Recipient {
 // Properties
 // ----------------------------------------------------------------------------
 
-// Name returns the name of the recipient.
+// Name returns the name of the recipient.  The name is the key of the
+// recipient, so it is not redacted here; programs redact it when they
+// write it to a spreadsheet.
 func (recipient *Recipient) Name() string {
 	return recipient.name
 }
@@ -92,7 +92,7 @@ func processTransactions(
 	for row := 1; row < numRows; row++ {
 		var transaction, err = processTransaction(sprdshtPtr, row)
 		if err != nil {
-			return err
+			return sprdshtPtr.WrapRow(err, "processing transaction", row)
 		}
 		if selectTransaction(&transaction) {
 			transList.Add(&transaction)
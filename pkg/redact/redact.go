@@ -0,0 +1,146 @@
+// ----------------------------------------------------------------------------
+//
+// Redaction
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+// The redact package replaces personal information with stable pseudonyms
+// so that analyses can be shared without exposing the identities of donors
+// and recipients.  Redaction is enabled when the environmental variable
+// ACORN_REDACT_KEY holds a key.  The same key always produces the same
+// pseudonym for the same person, so a donor has one pseudonym on every sheet
+// of every report.
+package redact
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Kind identifies the type of person being redacted.  It forms the
+// prefix of the pseudonym.
+type Kind string
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// KeyVariable is the environmental variable holding the redaction key.
+const KeyVariable = "ACORN_REDACT_KEY"
+
+const (
+	Donor     Kind = "Donor"
+	Recipient Kind = "Recipient"
+)
+
+// Number of hexadecimal digits in a pseudonym
+const pseudonymLength = 8
+
+// Number of leading zip code digits retained
+const zipDigits = 3
+
+const emailDomain = "example.org"
+
+// redactionKey is the key used to generate pseudonyms.  Redaction is
+// disabled when the key is empty.
+var redactionKey = ""
+
+// ----------------------------------------------------------------------------
+// Initialization
+// ----------------------------------------------------------------------------
+
+func init() {
+	redactionKey = os.Getenv(KeyVariable)
+}
+
+// ----------------------------------------------------------------------------
+// Functions
+// ----------------------------------------------------------------------------
+
+// Enable turns on redaction with the specified key.  An empty key turns
+// redaction off.
+func Enable(key string) {
+	redactionKey = key
+}
+
+// Disable turns off redaction.
+func Disable() {
+	redactionKey = ""
+}
+
+// Enabled returns true if personal information is being redacted.
+func Enabled() bool {
+	return redactionKey != ""
+}
+
+// Name returns a pseudonym for the name if redaction is enabled.  Otherwise,
+// the name is returned unchanged.  Empty names are not redacted.
+func Name(kind Kind, name string) string {
+	var result = name
+	if Enabled() && name != "" {
+		result = string(kind) + " " + strings.ToUpper(code(kind, name))
+	}
+	return result
+}
+
+// Email returns an email address formed from the pseudonym of the person
+// identified by the key if redaction is enabled.  Otherwise, the email is
+// returned unchanged.  Empty or placeholder emails are not redacted.
+func Email(kind Kind, key string, email string) string {
+	var result = email
+	var trimmed = strings.TrimSpace(email)
+	if Enabled() && trimmed != "" && trimmed != "--" {
+		result = strings.ToLower(string(kind)) + "-" + code(kind, key) + "@" + emailDomain
+	}
+	return result
+}
+
+// Street returns a street address formed from the pseudonym of the person
+// identified by the key if redaction is enabled.  Otherwise, the street is
+// returned unchanged.  Empty streets are not redacted.
+func Street(kind Kind, key string, street string) string {
+	var result = street
+	if Enabled() && strings.TrimSpace(street) != "" {
+		result = strings.ToUpper(code(kind, key)) + " Redacted Street"
+	}
+	return result
+}
+
+// Zip returns the zip code coarsened to its first three digits if
+// redaction is enabled.  Otherwise, the zip code is returned unchanged.
+func Zip(zip string) string {
+	var result = zip
+	var trimmed = strings.TrimSpace(zip)
+	if Enabled() && trimmed != "" {
+		if len(trimmed) > zipDigits {
+			trimmed = trimmed[:zipDigits]
+		}
+		result = trimmed + "XX"
+	}
+	return result
+}
+
+// code returns the hexadecimal code identifying the value.  It is computed
+// with a keyed hash so that the value cannot be recovered from the code
+// without the redaction key.
+func code(kind Kind, value string) string {
+	var mac = hmac.New(sha256.New, []byte(redactionKey))
+	mac.Write([]byte(string(kind) + ":" + strings.TrimSpace(value)))
+	var sum = hex.EncodeToString(mac.Sum(nil))
+	return sum[:pseudonymLength]
+}
@@ -0,0 +1,99 @@
+// ----------------------------------------------------------------------------
+//
+// Redaction Tests
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package redact
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"strings"
+	"testing"
+)
+
+// ----------------------------------------------------------------------------
+// Test functions
+// ----------------------------------------------------------------------------
+
+// Test_Disabled checks that values pass through unchanged when redaction
+// is disabled.
+func Test_Disabled(t *testing.T) {
+	Disable()
+	if Name(Donor, "Apple, Julietta") != "Apple, Julietta" {
+		t.Error("name was changed when redaction is disabled")
+	}
+	if Email(Donor, "Apple, Julietta", "ja@mail.com") != "ja@mail.com" {
+		t.Error("email was changed when redaction is disabled")
+	}
+	if Street(Donor, "Apple, Julietta", "1 Oak St") != "1 Oak St" {
+		t.Error("street was changed when redaction is disabled")
+	}
+	if Zip("27513-1234") != "27513-1234" {
+		t.Error("zip was changed when redaction is disabled")
+	}
+}
+
+// Test_Pseudonym checks that pseudonyms are stable and distinct.
+func Test_Pseudonym(t *testing.T) {
+	Enable("secret")
+	defer Disable()
+
+	var first = Name(Donor, "Apple, Julietta")
+	var second = Name(Donor, "Apple, Julietta")
+	var other = Name(Donor, "Baker, Tom")
+
+	if first != second {
+		t.Error("pseudonym is not stable: " + first + " " + second)
+	}
+	if first == other {
+		t.Error("different donors have the same pseudonym: " + first)
+	}
+	if !strings.HasPrefix(first, "Donor ") || strings.Contains(first, "Apple") {
+		t.Error("pseudonym is not redacted: " + first)
+	}
+	if Name(Recipient, "Apple, Julietta") == first {
+		t.Error("donor and recipient pseudonyms should differ")
+	}
+	if Name(Donor, "") != "" {
+		t.Error("empty name should not be redacted")
+	}
+	//
+	// A different key produces a different pseudonym
+	//
+	Enable("another secret")
+	if Name(Donor, "Apple, Julietta") == first {
+		t.Error("pseudonym does not depend on the key")
+	}
+}
+
+// Test_Contact checks the redaction of emails, streets, and zip codes.
+func Test_Contact(t *testing.T) {
+	Enable("secret")
+	defer Disable()
+
+	var email = Email(Donor, "Apple, Julietta", "ja@mail.com")
+	if strings.Contains(email, "ja@") || !strings.HasSuffix(email, "@"+emailDomain) {
+		t.Error("email is not redacted: " + email)
+	}
+	if Email(Donor, "Apple, Julietta", "--") != "--" {
+		t.Error("placeholder email should not be redacted")
+	}
+	var street = Street(Donor, "Apple, Julietta", "1 Oak St")
+	if strings.Contains(street, "Oak") {
+		t.Error("street is not redacted: " + street)
+	}
+	if Zip("27513-1234") != "275XX" {
+		t.Error("zip is not coarsened: " + Zip("27513-1234"))
+	}
+	if Zip("") != "" {
+		t.Error("empty zip should not be redacted")
+	}
+}
@@ -0,0 +1,125 @@
+// ----------------------------------------------------------------------------
+//
+// Exclusion audit
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package rules
+
+// This file records the rows of the donation spreadsheet that the rules did
+// not select so they can be reviewed.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"strconv"
+
+	l "acorn_go/pkg/logging"
+	r "acorn_go/pkg/redact"
+	"acorn_go/pkg/spreadsheet"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Exclusion is a row of the donation spreadsheet that was not selected.
+// The values are those in the spreadsheet.
+type Exclusion struct {
+	File   string
+	Row    int
+	Date   string
+	Payee  string
+	Type   string
+	Memo   string
+	Amount string
+	Rule   string
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// AuditTab is the name of the tab listing the excluded rows.
+const AuditTab = "Excluded Donations"
+
+var audit []Exclusion
+var audited = make(map[string]bool)
+
+// ----------------------------------------------------------------------------
+// Functions
+// ----------------------------------------------------------------------------
+
+// Audit returns the rows excluded since the audit list was reset.
+func Audit() []Exclusion {
+	return append([]Exclusion{}, audit...)
+}
+
+// ResetAudit discards the audit list.
+func ResetAudit() {
+	audit = nil
+	audited = make(map[string]bool)
+}
+
+// record adds an excluded row to the audit list.  A row excluded by more
+// than one loader is recorded once.
+func record(sprdsht *spreadsheet.Spreadsheet, row int, trans Transaction, ruleName string) {
+	var key = sprdsht.FileName() + ":" + strconv.Itoa(row)
+	if audited[key] {
+		return
+	}
+	audited[key] = true
+	var exclusion = Exclusion{
+		File:  sprdsht.FileName(),
+		Row:   row + 1,
+		Payee: trans.Payee,
+		Type:  trans.Type,
+		Memo:  trans.Memo,
+		Rule:  ruleName,
+	}
+	exclusion.Date, _ = sprdsht.Cell(row, ColumnDate)
+	exclusion.Amount, _ = sprdsht.Cell(row, ColumnAmount)
+	audit = append(audit, exclusion)
+	l.Debug("donation excluded", "rule", ruleName, l.File(exclusion.File), l.Row(exclusion.Row))
+}
+
+// OutputAudit writes the audit list to the current sheet of the output
+// spreadsheet.  When redaction is enabled, payees are redacted and memos,
+// which may name a person, are omitted.
+func OutputAudit(output *spreadsheet.SpreadsheetFile) {
+	var row = 1
+	var setName = ""
+	if active != nil {
+		setName = active.Name
+	}
+	spreadsheet.WriteCell(output, "A", row, "Donations excluded by rule set: "+setName)
+	row += 2
+	spreadsheet.WriteCell(output, "A", row, "Row")
+	spreadsheet.WriteCell(output, "B", row, "Date")
+	spreadsheet.WriteCell(output, "C", row, "Payee")
+	spreadsheet.WriteCell(output, "D", row, "Type")
+	spreadsheet.WriteCell(output, "E", row, "Memo")
+	spreadsheet.WriteCell(output, "F", row, "Amount")
+	spreadsheet.WriteCell(output, "G", row, "Rule")
+	for _, exclusion := range audit {
+		row++
+		spreadsheet.WriteCellInt(output, "A", row, exclusion.Row)
+		spreadsheet.WriteCell(output, "B", row, exclusion.Date)
+		spreadsheet.WriteCell(output, "C", row, r.Name(r.Donor, exclusion.Payee))
+		spreadsheet.WriteCell(output, "D", row, exclusion.Type)
+		if !r.Enabled() {
+			spreadsheet.WriteCell(output, "E", row, exclusion.Memo)
+		}
+		spreadsheet.WriteCell(output, "F", row, exclusion.Amount)
+		spreadsheet.WriteCell(output, "G", row, exclusion.Rule)
+	}
+	row += 2
+	spreadsheet.WriteCell(output, "A", row, "Rows excluded")
+	spreadsheet.WriteCellInt(output, "B", row, len(audit))
+}
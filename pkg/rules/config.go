@@ -0,0 +1,168 @@
+// ----------------------------------------------------------------------------
+//
+// Rule configuration
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package rules
+
+// This file loads rule sets.  Two rule sets are built in.  A JSON file named
+// by ACORN_RULES_FILE may add rule sets or replace the built-in ones, and
+// ACORN_RULE_SET selects the rule set the programs use.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+
+	sp "acorn_go/pkg/support"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Config is the content of a rules file.  Default names the rule set used
+// when ACORN_RULE_SET is not defined.
+type Config struct {
+	Default string            `json:"default"`
+	Sets    map[string][]Rule `json:"sets"`
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Environmental variables that configure the rules
+const (
+	FileVariable = "ACORN_RULES_FILE"
+	SetVariable  = "ACORN_RULE_SET"
+)
+
+// Names of the built-in rule sets
+const (
+	OperatingDonations = "operating donations only"
+	AllReceipts        = "all receipts"
+)
+
+// Values in the donation spreadsheet used by the built-in rule sets
+const (
+	typePayment    = "Payment"
+	typeRefund     = "Refund"
+	typeChargeback = "Chargeback"
+	typeReversal   = "Reversal"
+	typeDeposit    = "Deposit"
+	excludedDonor  = "Nadine L. Tolman Trust"
+)
+
+// active is the rule set used by the donation loaders.  It is loaded from
+// the environment the first time it is needed.
+var active *RuleSet
+var activeErr error
+
+// ----------------------------------------------------------------------------
+// Factory Functions
+// ----------------------------------------------------------------------------
+
+// Load returns the named rule set from the built-in rule sets and the rules
+// file.  If the file name is empty, only the built-in rule sets are
+// available.  If the set name is empty, the file's default is used, and
+// otherwise the operating donations rule set.
+func Load(fileName string, setName string) (RuleSet, error) {
+	var set RuleSet
+	var config = Config{Sets: builtInSets()}
+	var err error
+
+	if fileName != "" {
+		err = readConfig(fileName, &config)
+		if err != nil {
+			return set, sp.WrapFile(err, sp.Usage, "reading rules", fileName)
+		}
+	}
+	if setName == "" {
+		setName = config.Default
+	}
+	if setName == "" {
+		setName = OperatingDonations
+	}
+	var rules, found = config.Sets[setName]
+	if !found {
+		err = errors.New("rule set is not defined: " + setName)
+		return set, sp.WrapFile(err, sp.Usage, "selecting rules", fileName)
+	}
+	set = RuleSet{Name: setName, Rules: append([]Rule{}, rules...)}
+	err = set.validate()
+	return set, sp.WrapFile(err, sp.Usage, "validating rules", fileName)
+}
+
+// ----------------------------------------------------------------------------
+// Functions
+// ----------------------------------------------------------------------------
+
+// Active returns the rule set named by ACORN_RULE_SET from the rules file
+// named by ACORN_RULES_FILE.
+func Active() (RuleSet, error) {
+	if active == nil && activeErr == nil {
+		var set RuleSet
+		set, activeErr = Load(os.Getenv(FileVariable), os.Getenv(SetVariable))
+		active = &set
+	}
+	return *active, activeErr
+}
+
+// SetActive replaces the rule set used by the donation loaders.
+func SetActive(set RuleSet) error {
+	var err = set.validate()
+	if err == nil {
+		active = &set
+		activeErr = nil
+	}
+	return err
+}
+
+// builtInSets returns the built-in rule sets.  Operating donations are
+// payments other than those of the trust, which are reported separately.
+// All receipts include deposits such as bank interest.  Both include the
+// refunds, chargebacks, and reversals that net against the payments.
+func builtInSets() map[string][]Rule {
+	return map[string][]Rule{
+		OperatingDonations: {
+			{Name: "payments and reversals", Action: Include, Types: []string{typePayment, typeRefund, typeChargeback, typeReversal}},
+			{Name: "trust", Action: Exclude, Payees: []string{excludedDonor}},
+		},
+		AllReceipts: {
+			{Name: "receipts and reversals", Action: Include, Types: []string{typePayment, typeRefund, typeChargeback, typeReversal, typeDeposit}},
+		},
+	}
+}
+
+// readConfig adds the rule sets in a rules file to the configuration.
+// Unknown fields are reported so that misspelled conditions are not
+// silently ignored.
+func readConfig(fileName string, config *Config) error {
+	var content, err = os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+	var fileConfig Config
+	var decoder = json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(&fileConfig)
+	if err != nil {
+		return err
+	}
+	for name, rules := range fileConfig.Sets {
+		config.Sets[name] = rules
+	}
+	config.Default = fileConfig.Default
+	return err
+}
@@ -0,0 +1,270 @@
+// ----------------------------------------------------------------------------
+//
+// Donation rules
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+// The rules package decides which rows of the donation spreadsheet are
+// treated as donations.  A rule set is an ordered list of include and
+// exclude rules.  A row is selected when it matches every include rule and
+// no exclude rule.  Rule sets are named, so the same spreadsheet can be
+// analyzed as operating donations only or as all receipts.  Rows that are
+// not selected are recorded in an audit list.
+package rules
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"acorn_go/pkg/spreadsheet"
+
+	dec "github.com/shopspring/decimal"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Action specifies whether a rule includes or excludes the rows it matches.
+type Action string
+
+// Rule matches rows of the donation spreadsheet.  Every condition that is
+// specified must match for the rule to match.  A list matches if any of its
+// values match.  Payees, types, and classes must equal the cell, while memos
+// need only be contained in the cell.  Case is ignored.  Dates and amounts
+// are inclusive limits.
+type Rule struct {
+	Name      string       `json:"name"`
+	Action    Action       `json:"action"`
+	Payees    []string     `json:"payees,omitempty"`
+	Types     []string     `json:"types,omitempty"`
+	Memos     []string     `json:"memos,omitempty"`
+	Classes   []string     `json:"classes,omitempty"`
+	From      string       `json:"from,omitempty"`
+	Thru      string       `json:"thru,omitempty"`
+	MinAmount *dec.Decimal `json:"minAmount,omitempty"`
+	MaxAmount *dec.Decimal `json:"maxAmount,omitempty"`
+	fromDate  *d.Date
+	thruDate  *d.Date
+}
+
+// RuleSet is a named, ordered list of rules.
+type RuleSet struct {
+	Name  string
+	Rules []Rule
+}
+
+// Transaction holds the values of a row that rules examine.
+type Transaction struct {
+	Date   d.Date
+	Payee  string
+	Type   string
+	Memo   string
+	Class  string
+	Amount dec.Decimal
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const (
+	Include Action = "include"
+	Exclude Action = "exclude"
+)
+
+// Columns of the donation spreadsheet.  The memo and class columns are
+// optional.
+const (
+	ColumnDate   = "Date"
+	ColumnPayee  = "Payee"
+	ColumnType   = "Type"
+	ColumnMemo   = "Memo"
+	ColumnClass  = "Class"
+	ColumnAmount = "Payment"
+)
+
+// ----------------------------------------------------------------------------
+// Rule Set Methods
+// ----------------------------------------------------------------------------
+
+// Evaluate returns true if the transaction is selected.  If it is not
+// selected, the name of the rule that rejected it is also returned.
+func (set *RuleSet) Evaluate(trans Transaction) (bool, string) {
+	for index := range set.Rules {
+		var rule = &set.Rules[index]
+		var matches = rule.Matches(trans)
+		if (rule.Action == Include && !matches) || (rule.Action == Exclude && matches) {
+			return false, rule.Name
+		}
+	}
+	return true, ""
+}
+
+// Select returns true if a row of the donation spreadsheet is selected.
+// Rows that are not selected are added to the audit list.  The date and
+// amount are only read if a rule examines them.
+func (set *RuleSet) Select(sprdsht *spreadsheet.Spreadsheet, row int) (bool, error) {
+	var trans Transaction
+	var err error
+
+	trans.Payee, err = sprdsht.Cell(row, ColumnPayee)
+	if err == nil {
+		trans.Type, err = sprdsht.Cell(row, ColumnType)
+	}
+	if err == nil && sprdsht.HasColumn(ColumnMemo) {
+		trans.Memo, err = sprdsht.Cell(row, ColumnMemo)
+	}
+	if err == nil && sprdsht.HasColumn(ColumnClass) {
+		trans.Class, err = sprdsht.Cell(row, ColumnClass)
+	}
+	if err == nil && set.usesDate() {
+		trans.Date, err = sprdsht.CellDate(row, ColumnDate)
+	}
+	if err == nil && set.usesAmount() {
+		trans.Amount, err = sprdsht.CellDecimal(row, ColumnAmount)
+	}
+	if err != nil {
+		return false, err
+	}
+	var selected, ruleName = set.Evaluate(trans)
+	if !selected {
+		record(sprdsht, row, trans, ruleName)
+	}
+	return selected, err
+}
+
+// usesDate returns true if a rule in the set has a date limit.
+func (set *RuleSet) usesDate() bool {
+	for _, rule := range set.Rules {
+		if rule.From != "" || rule.Thru != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// usesAmount returns true if a rule in the set has an amount limit.
+func (set *RuleSet) usesAmount() bool {
+	for _, rule := range set.Rules {
+		if rule.MinAmount != nil || rule.MaxAmount != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// validate checks the rules and converts their date limits.  Rules without
+// a name are named by their position.
+func (set *RuleSet) validate() error {
+	var err error
+	for index := range set.Rules {
+		var rule = &set.Rules[index]
+		if rule.Name == "" {
+			rule.Name = "rule " + strconv.Itoa(index+1)
+		}
+		err = rule.validate()
+		if err != nil {
+			return errors.New("rule set " + set.Name + ", " + rule.Name + ": " + err.Error())
+		}
+	}
+	return err
+}
+
+// ----------------------------------------------------------------------------
+// Rule Methods
+// ----------------------------------------------------------------------------
+
+// Matches returns true if the transaction meets every condition of the rule.
+func (rule *Rule) Matches(trans Transaction) bool {
+	var result = true
+	result = result && matchesAny(rule.Payees, trans.Payee, equalValue)
+	result = result && matchesAny(rule.Types, trans.Type, equalValue)
+	result = result && matchesAny(rule.Memos, trans.Memo, containsValue)
+	result = result && matchesAny(rule.Classes, trans.Class, equalValue)
+	result = result && (rule.fromDate == nil || !trans.Date.Before(*rule.fromDate))
+	result = result && (rule.thruDate == nil || !trans.Date.After(*rule.thruDate))
+	result = result && (rule.MinAmount == nil || trans.Amount.GreaterThanOrEqual(*rule.MinAmount))
+	result = result && (rule.MaxAmount == nil || trans.Amount.LessThanOrEqual(*rule.MaxAmount))
+	return result
+}
+
+// validate checks the action and conditions of a rule and converts its
+// date limits.
+func (rule *Rule) validate() error {
+	var err error
+	if rule.Action != Include && rule.Action != Exclude {
+		return errors.New("action must be include or exclude: " + string(rule.Action))
+	}
+	if !rule.hasCondition() {
+		return errors.New("rule has no conditions")
+	}
+	rule.fromDate, err = parseLimit(rule.From)
+	if err == nil {
+		rule.thruDate, err = parseLimit(rule.Thru)
+	}
+	if err == nil && rule.fromDate != nil && rule.thruDate != nil && rule.thruDate.Before(*rule.fromDate) {
+		err = errors.New("from date is after thru date")
+	}
+	if err == nil && rule.MinAmount != nil && rule.MaxAmount != nil && rule.MaxAmount.LessThan(*rule.MinAmount) {
+		err = errors.New("minimum amount is greater than maximum amount")
+	}
+	return err
+}
+
+// hasCondition returns true if the rule specifies at least one condition.
+func (rule *Rule) hasCondition() bool {
+	return len(rule.Payees) > 0 || len(rule.Types) > 0 || len(rule.Memos) > 0 ||
+		len(rule.Classes) > 0 || rule.From != "" || rule.Thru != "" ||
+		rule.MinAmount != nil || rule.MaxAmount != nil
+}
+
+// ----------------------------------------------------------------------------
+// Support Functions
+// ----------------------------------------------------------------------------
+
+// matchesAny returns true if the list is empty or the value matches one of
+// the entries in the list.
+func matchesAny(list []string, value string, match func(string, string) bool) bool {
+	if len(list) == 0 {
+		return true
+	}
+	for _, entry := range list {
+		if match(strings.TrimSpace(entry), strings.TrimSpace(value)) {
+			return true
+		}
+	}
+	return false
+}
+
+// equalValue returns true if the value equals the entry, ignoring case.
+func equalValue(entry string, value string) bool {
+	return strings.EqualFold(entry, value)
+}
+
+// containsValue returns true if the value contains the entry, ignoring case.
+func containsValue(entry string, value string) bool {
+	return strings.Contains(strings.ToLower(value), strings.ToLower(entry))
+}
+
+// parseLimit converts a date limit.  An empty limit returns nil.
+func parseLimit(value string) (*d.Date, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var date, err = d.NewFromString(value)
+	if err != nil {
+		return nil, errors.New("invalid date: " + value)
+	}
+	return &date, nil
+}
@@ -0,0 +1,208 @@
+// ----------------------------------------------------------------------------
+//
+// Donation rules tests
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package rules
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"acorn_go/pkg/spreadsheet"
+	syn "acorn_go/pkg/synthetic"
+
+	dec "github.com/shopspring/decimal"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Test Support
+// ----------------------------------------------------------------------------
+
+// transaction returns a transaction for the tests.
+func transaction(t *testing.T, date string, payee string, transType string, memo string, amount int64) Transaction {
+	t.Helper()
+	var transDate, err = d.NewFromString(date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return Transaction{
+		Date:   transDate,
+		Payee:  payee,
+		Type:   transType,
+		Memo:   memo,
+		Amount: dec.NewFromInt(amount),
+	}
+}
+
+// writeRules writes a rules file and returns its name.
+func writeRules(t *testing.T, content string) string {
+	var fileName = filepath.Join(t.TempDir(), "rules.json")
+	var err = os.WriteFile(fileName, []byte(content), 0o600)
+	if err != nil {
+		t.Fatal("unable to write rules file: " + err.Error())
+	}
+	return fileName
+}
+
+// ----------------------------------------------------------------------------
+// Tests
+// ----------------------------------------------------------------------------
+
+// TestBuiltInSets checks the built-in rule sets.
+func TestBuiltInSets(t *testing.T) {
+	var tests = []struct {
+		name     string
+		set      string
+		trans    Transaction
+		selected bool
+		rule     string
+	}{
+		{"payment", OperatingDonations, transaction(t, "10/01/2025", "Able, Ann", "Payment", "", 100), true, ""},
+		{"trust", OperatingDonations, transaction(t, "10/01/2025", excludedDonor, "Payment", "", 5000), false, "trust"},
+		{"refund", OperatingDonations, transaction(t, "10/01/2025", "Able, Ann", "Refund", "", -100), true, ""},
+		{"chargeback", OperatingDonations, transaction(t, "10/01/2025", "Able, Ann", "Chargeback", "", -100), true, ""},
+		{"deposit", OperatingDonations, transaction(t, "10/01/2025", "Bank", "Deposit", "", 3), false, "payments and reversals"},
+		{"all receipts deposit", AllReceipts, transaction(t, "10/01/2025", "Bank", "Deposit", "", 3), true, ""},
+		{"all receipts refund", AllReceipts, transaction(t, "10/01/2025", "Able, Ann", "Refund", "", -100), true, ""},
+		{"all receipts trust", AllReceipts, transaction(t, "10/01/2025", excludedDonor, "Payment", "", 5000), true, ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var set, err = Load("", test.set)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var selected, rule = set.Evaluate(test.trans)
+			if selected != test.selected || rule != test.rule {
+				t.Errorf("expected %v %q, got %v %q", test.selected, test.rule, selected, rule)
+			}
+		})
+	}
+}
+
+// TestConditions checks each kind of condition.
+func TestConditions(t *testing.T) {
+	var fileName = writeRules(t, `{
+		"default": "test",
+		"sets": {
+			"test": [
+				{"name": "fiscal years", "action": "include", "from": "09/01/2022", "thru": "08/31/2026"},
+				{"name": "large", "action": "exclude", "minAmount": "10000"},
+				{"name": "in kind", "action": "exclude", "memos": ["in-kind"]},
+				{"name": "grants", "action": "exclude", "payees": ["community foundation"], "types": ["payment"]}
+			]
+		}
+	}`)
+	var set, err = Load(fileName, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tests = []struct {
+		name  string
+		trans Transaction
+		rule  string
+	}{
+		{"selected", transaction(t, "09/01/2022", "Able, Ann", "Payment", "", 9999), ""},
+		{"before", transaction(t, "08/31/2022", "Able, Ann", "Payment", "", 100), "fiscal years"},
+		{"after", transaction(t, "09/01/2026", "Able, Ann", "Payment", "", 100), "fiscal years"},
+		{"amount", transaction(t, "10/01/2025", "Able, Ann", "Payment", "", 10000), "large"},
+		{"memo", transaction(t, "10/01/2025", "Able, Ann", "Payment", "Books (In-Kind)", 100), "in kind"},
+		{"payee and type", transaction(t, "10/01/2025", "Community Foundation", "Payment", "", 100), "grants"},
+		{"payee only", transaction(t, "10/01/2025", "Community Foundation", "Deposit", "", 100), ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var selected, rule = set.Evaluate(test.trans)
+			if selected != (test.rule == "") || rule != test.rule {
+				t.Errorf("expected rule %q, got %v %q", test.rule, selected, rule)
+			}
+		})
+	}
+}
+
+// TestInvalidRules checks that invalid rules files are rejected.
+func TestInvalidRules(t *testing.T) {
+	var tests = []struct {
+		name    string
+		content string
+		set     string
+	}{
+		{"unknown set", `{"sets": {}}`, "missing"},
+		{"unknown field", `{"sets": {"x": [{"action": "include", "payee": ["a"]}]}}`, "x"},
+		{"bad action", `{"sets": {"x": [{"action": "keep", "payees": ["a"]}]}}`, "x"},
+		{"no conditions", `{"sets": {"x": [{"action": "exclude"}]}}`, "x"},
+		{"bad date", `{"sets": {"x": [{"action": "include", "from": "soon"}]}}`, "x"},
+		{"reversed dates", `{"sets": {"x": [{"action": "include", "from": "01/01/2025", "thru": "01/01/2024"}]}}`, "x"},
+		{"reversed amounts", `{"sets": {"x": [{"action": "include", "minAmount": 10, "maxAmount": 5}]}}`, "x"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var _, err = Load(writeRules(t, test.content), test.set)
+			if err == nil {
+				t.Error("invalid rules were accepted")
+			}
+		})
+	}
+}
+
+// TestSelect checks that rows of a donation spreadsheet are selected and
+// the excluded rows are audited.
+func TestSelect(t *testing.T) {
+	var dir = t.TempDir()
+	var generator, err = syn.New(dir, 1, 20)
+	if err == nil {
+		err = generator.Generate()
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sprdsht spreadsheet.Spreadsheet
+	sprdsht, err = spreadsheet.ProcessData(filepath.Join(dir, syn.DonationFile), syn.DonationTab)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var set RuleSet
+	set, err = Load("", OperatingDonations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ResetAudit()
+	defer ResetAudit()
+
+	var selected = 0
+	for row := 1; row < sprdsht.Size(); row++ {
+		var ok bool
+		ok, err = set.Select(&sprdsht, row)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok {
+			selected++
+		}
+	}
+	var audit = Audit()
+	if selected == 0 || len(audit) == 0 || selected+len(audit) != sprdsht.Size()-1 {
+		t.Fatalf("unexpected selection: %d selected, %d excluded, %d rows", selected, len(audit), sprdsht.Size()-1)
+	}
+	var trust = false
+	for _, exclusion := range audit {
+		if exclusion.Rule == "trust" && exclusion.Payee == excludedDonor && exclusion.Amount != "" {
+			trust = true
+		}
+	}
+	if !trust {
+		t.Error("the trust's donations were not audited")
+	}
+}
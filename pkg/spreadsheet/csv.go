@@ -0,0 +1,75 @@
+// ----------------------------------------------------------------------------
+//
+// CSV reader
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package spreadsheet
+
+// Some data, such as the exports of the online giving platform, arrive as
+// CSV files rather than Excel spreadsheets.  A CSV file is read into the
+// same Spreadsheet structure, so its cells are read by heading as in a
+// spreadsheet.  The first row must contain the headings.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"encoding/csv"
+	"errors"
+	"os"
+	"strings"
+
+	sp "acorn_go/pkg/support"
+)
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Byte order mark that some programs place at the start of a CSV file
+const byteOrderMark = "\ufeff"
+
+// ----------------------------------------------------------------------------
+// Functions
+// ----------------------------------------------------------------------------
+
+// ProcessCSV reads a CSV file and returns the column headings and a slice
+// of the data in a Spreadsheet structure.  Rows may have fewer fields than
+// the heading.
+func ProcessCSV(fileName string) (Spreadsheet, error) {
+	var spreadsheet Spreadsheet
+	var rows [][]string
+	spreadsheet.fileName = fileName
+	//
+	// Read the rows
+	//
+	var file, err = os.Open(fileName)
+	if err == nil {
+		var reader = csv.NewReader(file)
+		reader.FieldsPerRecord = -1
+		rows, err = reader.ReadAll()
+		err = errors.Join(err, file.Close())
+	}
+	if err == nil && len(rows) == 0 {
+		err = ErrEmpty
+	}
+	if err != nil {
+		err = sp.WrapFile(err, sp.Input, "reading CSV file", fileName)
+		return spreadsheet, err
+	}
+	//
+	// Form heading.  The first row must contain the headings.
+	//
+	if len(rows[0]) > 0 {
+		rows[0][0] = strings.TrimPrefix(rows[0][0], byteOrderMark)
+	}
+	spreadsheet.headings = rows[0]
+	spreadsheet.rows = rows
+	return spreadsheet, err
+}
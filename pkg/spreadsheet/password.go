@@ -0,0 +1,82 @@
+// ----------------------------------------------------------------------------
+//
+// Spreadsheet password
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package spreadsheet
+
+// This file obtains the password used to encrypt output spreadsheets that
+// contain personal information about donors.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// PasswordVariable is the environmental variable holding the password for
+// output spreadsheets.
+const PasswordVariable = "ACORN_SPREADSHEET_PASSWORD"
+
+// ----------------------------------------------------------------------------
+// Functions
+// ----------------------------------------------------------------------------
+
+// OutputPassword returns the password used to encrypt output spreadsheets.
+// The password is taken from the ACORN_SPREADSHEET_PASSWORD environmental
+// variable.  If the variable is not defined, the user is prompted for the
+// password at the terminal.  An error is returned if no password is supplied.
+func OutputPassword() (string, error) {
+	var err error = nil
+	var password = os.Getenv(PasswordVariable)
+
+	if password == "" {
+		password, err = promptPassword()
+	}
+	if err == nil && password == "" {
+		err = errors.New("a password is required for spreadsheets with donor information")
+	}
+	return password, err
+}
+
+// promptPassword asks the user for the password at the terminal.  The
+// password is not echoed as it is typed.
+func promptPassword() (string, error) {
+	var err error = nil
+	var input []byte
+	var fd = int(os.Stdin.Fd())
+	//
+	// Precondition
+	//
+	if !term.IsTerminal(fd) {
+		err = errors.New(PasswordVariable + " is not defined and the terminal is not available")
+		return "", err
+	}
+	//
+	// Read password
+	//
+	fmt.Print("Spreadsheet password: ")
+	input, err = term.ReadPassword(fd)
+	fmt.Println()
+	if err != nil {
+		return "", err
+	}
+	var password = strings.TrimSpace(string(input))
+	return password, err
+}
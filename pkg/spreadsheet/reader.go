@@ -22,6 +22,8 @@ import (
 	"strconv"
 	"strings"
 
+	sp "acorn_go/pkg/support"
+
 	d "github.com/waysys/waydate/pkg/date"
 
 	dec "github.com/shopspring/decimal"
@@ -33,6 +35,7 @@ import (
 // ----------------------------------------------------------------------------
 
 type Spreadsheet struct {
+	fileName string
 	headings []string
 	rows     [][]string
 }
@@ -41,20 +44,28 @@ type Spreadsheet struct {
 // Constants
 // ----------------------------------------------------------------------------
 
+// ErrEmpty is returned when a sheet has no rows.
+var ErrEmpty = errors.New("spreadsheet is empty")
+
 // ----------------------------------------------------------------------------
 // Functions
 // ----------------------------------------------------------------------------
 
 // readData extracts the donation data from Excel file and
-// returns an array of string arrays.
-func readData(excelFillName string, tab string) ([][]string, error) {
+// returns an array of string arrays.  If the password is not empty,
+// it is used to decrypt the file.
+func readData(excelFillName string, tab string, password string) ([][]string, error) {
 	var err error
 	var file *excel.File
 	var rows [][]string
 	//
 	// Open file
 	//
-	file, err = excel.OpenFile(excelFillName)
+	if password == "" {
+		file, err = excel.OpenFile(excelFillName)
+	} else {
+		file, err = excel.OpenFile(excelFillName, excel.Options{Password: password})
+	}
 	if err != nil {
 		return rows, err
 	}
@@ -78,22 +89,53 @@ func readData(excelFillName string, tab string) ([][]string, error) {
 	return rows, nil
 }
 
+// SheetNames returns the names of the sheets in an Excel file in the order
+// they appear.  If the password is not empty, it is used to decrypt the file.
+func SheetNames(fileName string, password string) ([]string, error) {
+	var err error
+	var file *excel.File
+	var names []string
+	//
+	// Open file
+	//
+	if password == "" {
+		file, err = excel.OpenFile(fileName)
+	} else {
+		file, err = excel.OpenFile(fileName, excel.Options{Password: password})
+	}
+	if err != nil {
+		return names, sp.WrapFile(err, sp.Input, "opening spreadsheet", fileName)
+	}
+	names = file.GetSheetList()
+	err = file.Close()
+	return names, sp.WrapFile(err, sp.Input, "closing spreadsheet", fileName)
+}
+
 // ProcessData reads the donation Excel file and returns the column headings
 // and a slice of the data in a Spreadsheet structure.
 func ProcessData(fileName string, tab string) (Spreadsheet, error) {
+	return ProcessProtectedData(fileName, tab, "")
+}
+
+// ProcessProtectedData reads an Excel file encrypted with the specified
+// password and returns the column headings and a slice of the data in a
+// Spreadsheet structure.
+func ProcessProtectedData(fileName string, tab string, password string) (Spreadsheet, error) {
 	var rows [][]string
 	var err error
 	var spreadsheet Spreadsheet
 	//
 	// Retieve data form .xlsx file
 	//
-	rows, err = readData(fileName, tab)
+	spreadsheet.fileName = fileName
+	rows, err = readData(fileName, tab, password)
+	if err == nil && len(rows) == 0 {
+		err = ErrEmpty
+	}
 	if err != nil {
+		err = sp.WrapFile(err, sp.Input, "reading spreadsheet", fileName)
 		return spreadsheet, err
 	}
-	if len(rows) == 0 {
-		err = errors.New("spreadsheet is empty")
-	}
 	//
 	// Form heading.  The first row in the spreadsheet must contain the headings.
 	//
@@ -112,6 +154,33 @@ func (spreadsheet *Spreadsheet) Size() int {
 	return len(spreadsheet.rows)
 }
 
+// FileName returns the name of the file the spreadsheet was read from.
+func (spreadsheet *Spreadsheet) FileName() string {
+	return spreadsheet.fileName
+}
+
+// WrapRow returns an error describing the failed operation on a row of the
+// spreadsheet.  The error includes the file name and the row number shown by
+// Excel.  A nil error returns nil.
+func (spreadsheet *Spreadsheet) WrapRow(err error, op string, row int) error {
+	return sp.WrapRow(err, sp.Input, op, spreadsheet.fileName, row+1)
+}
+
+// Row returns a copy of the values in a row of the spreadsheet.  Row 0 is
+// the heading row.  Trailing empty cells may be omitted.
+func (spreadsheet *Spreadsheet) Row(row int) ([]string, error) {
+	var values []string
+	var err error = nil
+
+	if row < 0 || row >= spreadsheet.Size() {
+		err = errors.New("invalid row for spreadsheet: " + strconv.Itoa(row))
+		return values, err
+	}
+	values = make([]string, len(spreadsheet.rows[row]))
+	copy(values, spreadsheet.rows[row])
+	return values, err
+}
+
 // Column returns an integer indicating the column position of a string
 // in the header.  If the string is not found in the header, an error
 // is returned.
@@ -133,6 +202,12 @@ func (spreadsheet *Spreadsheet) column(heading string) (int, error) {
 	return column, err
 }
 
+// HasColumn returns true if the heading is in the header.
+func (spreadsheet *Spreadsheet) HasColumn(heading string) bool {
+	var _, err = spreadsheet.column(heading)
+	return err == nil
+}
+
 // Cell returns the value in a cell of the spreadsheet.
 func (spreadsheet *Spreadsheet) Cell(row int, heading string) (string, error) {
 	var column int
@@ -19,6 +19,7 @@ package spreadsheet
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"testing"
 )
@@ -149,3 +150,69 @@ func Test_CreateSpreadsheet(t *testing.T) {
 		t.Error(err.Error())
 	}
 }
+
+// Test_SavePassword checks that a workbook saved with a password can only
+// be read with that password.
+func Test_SavePassword(t *testing.T) {
+	var err error
+	var spFile SpreadsheetFile
+	var sprdsht Spreadsheet
+	var filename = filepath.Join(t.TempDir(), "protected.xlsx")
+	var password = "acorn"
+	//
+	// Create and save an encrypted spreadsheet
+	//
+	spFile, err = New(filename, "Donors")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	spFile.SetPassword(password)
+	WriteCell(&spFile, "A", 1, "Donor Name")
+	WriteCell(&spFile, "A", 2, "Apple, Julietta")
+	err = spFile.ProtectSheet(password)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	err = spFile.Save()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	err = spFile.Close()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	//
+	// Read without a password
+	//
+	_, err = ProcessData(filename, "Donors")
+	if err == nil {
+		t.Error("encrypted spreadsheet was read without a password")
+	}
+	//
+	// Read with the password
+	//
+	sprdsht, err = ProcessProtectedData(filename, "Donors", password)
+	if err != nil {
+		t.Fatal("error reading encrypted spreadsheet: " + err.Error())
+	}
+	var cell string
+	cell, err = sprdsht.Cell(1, "Donor Name")
+	if err != nil {
+		t.Error(err.Error())
+	} else if cell != "Apple, Julietta" {
+		t.Error("incorrect value of cell: " + cell)
+	}
+}
+
+// Test_ProtectSheetEmptyPassword checks that a sheet cannot be protected
+// with an empty password.
+func Test_ProtectSheetEmptyPassword(t *testing.T) {
+	var spFile, err = New(filepath.Join(t.TempDir(), "sheet.xlsx"), "Sheet")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	err = spFile.ProtectSheet("")
+	if err == nil {
+		t.Error("sheet was protected with an empty password")
+	}
+}
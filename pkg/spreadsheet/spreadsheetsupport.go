@@ -15,11 +15,14 @@ package spreadsheet
 // ----------------------------------------------------------------------------
 
 import (
-	s "acorn_go/pkg/support"
+	"fmt"
 	"strconv"
 
+	"github.com/waysys/assert/assert"
+
 	dec "github.com/shopspring/decimal"
 	d "github.com/waysys/waydate/pkg/date"
+	excel "github.com/xuri/excelize/v2"
 )
 
 // ----------------------------------------------------------------------------
@@ -30,12 +33,24 @@ import (
 // Cell Functions
 // ----------------------------------------------------------------------------
 
+// The WriteCell functions do not stop the program when a cell cannot be
+// written.  The first error is kept and returned by Save, so the program can
+// finish and save what it has written.
+
 // cellName generates a string representing a cell in the spreadsheet.
 func CellName(column string, row int) string {
 	var cellName = column + strconv.Itoa(row)
 	return cellName
 }
 
+// ColumnLetter returns the letters of a column given its position.  The
+// first column is 0.
+func ColumnLetter(column int) string {
+	var letter, err = excel.ColumnNumberToName(column + 1)
+	assert.Assert(err == nil, "invalid column: "+strconv.Itoa(column))
+	return letter
+}
+
 // writeCell outputs a string value to the specified cell
 func WriteCell(
 	outputPtr *SpreadsheetFile,
@@ -45,7 +60,7 @@ func WriteCell(
 
 	var cell = CellName(column, row)
 	var err = outputPtr.SetCell(cell, value)
-	s.Check(err, "Error writing cell "+cell+": ")
+	outputPtr.recordError(cellError(cell, err))
 }
 
 // writeCellInt outputs an integer value to the specified cell
@@ -57,7 +72,7 @@ func WriteCellInt(
 
 	var cell = CellName(column, row)
 	var err = outputPtr.SetCellInt(cell, value)
-	s.Check(err, "Error writing cell "+cell+": ")
+	outputPtr.recordError(cellError(cell, err))
 }
 
 // writeCellFloat outputs a float64 value to the specified cell
@@ -69,7 +84,7 @@ func WriteCellFloat(
 
 	var cell = CellName(column, row)
 	var err = outputPtr.SetCellFloat(cell, value)
-	s.Check(err, "Error writing cell "+cell+": ")
+	outputPtr.recordError(cellError(cell, err))
 }
 
 // writeDecimal outputs a decimal value to the specified cell
@@ -81,7 +96,7 @@ func WriteCellDecimal(
 
 	var cell = CellName(column, row)
 	var err = outputPtr.SetCellDecimal(cell, value, FormatMoney)
-	s.Check(err, "Error writing cell "+cell+": ")
+	outputPtr.recordError(cellError(cell, err))
 }
 
 // WriteCellDate outputs a WayDate to the specified cell.
@@ -92,5 +107,13 @@ func WriteCellDate(
 	date d.Date) {
 	var cell = CellName(column, row)
 	var err = outputPtr.SetCellDate(cell, date)
-	s.Check(err, "Error writing cell "+cell+": ")
+	outputPtr.recordError(cellError(cell, err))
+}
+
+// cellError adds the cell name to an error from writing a cell.
+func cellError(cell string, err error) error {
+	if err != nil {
+		err = fmt.Errorf("cell %s: %w", cell, err)
+	}
+	return err
 }
@@ -20,6 +20,8 @@ import (
 	"errors"
 	"strconv"
 
+	sp "acorn_go/pkg/support"
+
 	dec "github.com/shopspring/decimal"
 	d "github.com/waysys/waydate/pkg/date"
 	"github.com/xuri/excelize/v2"
@@ -33,7 +35,9 @@ import (
 type SpreadsheetFile struct {
 	filename  string
 	sheetname string
+	password  string
 	filePtr   *excel.File
+	writeErr  *error
 }
 
 type FormatIndex int
@@ -71,6 +75,7 @@ func New(filename string, sheetname string) (SpreadsheetFile, error) {
 		return spFile, err
 	}
 	spFile.sheetname = sheetname
+	spFile.writeErr = new(error)
 	//
 	// create the file
 	//
@@ -92,6 +97,9 @@ func New(filename string, sheetname string) (SpreadsheetFile, error) {
 // ----------------------------------------------------------------------------
 
 // Save saves the Excel file with the name specified in the NewFile function.
+// If a password has been set with SetPassword, the workbook is encrypted
+// with that password.  The file is saved even if writing a cell failed, so
+// partial results are kept, and the first write error is returned.
 func (spFilePtr *SpreadsheetFile) Save() error {
 	var err error = nil
 	//
@@ -105,7 +113,61 @@ func (spFilePtr *SpreadsheetFile) Save() error {
 	// Save file
 	//
 	var filename = (*spFilePtr).filename
-	err = (*spFilePtr).filePtr.SaveAs(filename)
+	var password = (*spFilePtr).password
+	if password == "" {
+		err = (*spFilePtr).filePtr.SaveAs(filename)
+	} else {
+		err = (*spFilePtr).filePtr.SaveAs(filename, excel.Options{Password: password})
+	}
+	err = sp.WrapFile(err, sp.Output, "saving spreadsheet", filename)
+	return errors.Join(spFilePtr.Err(), err)
+}
+
+// Err returns the first error from writing a cell with the WriteCell
+// functions, or nil if every cell was written.
+func (spFilePtr *SpreadsheetFile) Err() error {
+	var err error = nil
+	if spFilePtr.writeErr != nil {
+		err = *spFilePtr.writeErr
+	}
+	return err
+}
+
+// recordError keeps the first error from writing a cell.  Later cells are
+// still written.
+func (spFilePtr *SpreadsheetFile) recordError(err error) {
+	if err != nil && spFilePtr.writeErr != nil && *spFilePtr.writeErr == nil {
+		*spFilePtr.writeErr = sp.WrapFile(err, sp.Output, "writing spreadsheet", spFilePtr.filename)
+	}
+}
+
+// SetPassword sets the password used to encrypt the workbook when it is
+// saved.  An empty password saves the workbook unencrypted.
+func (spFilePtr *SpreadsheetFile) SetPassword(password string) {
+	spFilePtr.password = password
+}
+
+// ProtectSheet protects the current sheet against editing with the
+// specified password.  Cells may still be selected and copied.
+func (spFilePtr *SpreadsheetFile) ProtectSheet(password string) error {
+	var err error = nil
+	//
+	// Precondition
+	//
+	if password == "" {
+		err = errors.New("sheet protection password must not be an empty string")
+		return err
+	}
+	//
+	// Protect sheet
+	//
+	var options = excel.SheetProtectionOptions{
+		AlgorithmName:       "SHA-512",
+		Password:            password,
+		SelectLockedCells:   true,
+		SelectUnlockedCells: true,
+	}
+	err = spFilePtr.filePtr.ProtectSheet(spFilePtr.sheetname, &options)
 	return err
 }
 
@@ -144,6 +206,8 @@ func (spFilePtr *SpreadsheetFile) AddSheet(sheetname string) (SpreadsheetFile, e
 	//
 	spFile.filename = spFilePtr.filename
 	spFile.filePtr = spFilePtr.filePtr
+	spFile.password = spFilePtr.password
+	spFile.writeErr = spFilePtr.writeErr
 	spFile.sheetname = sheetname
 	//
 	// Create new sheet
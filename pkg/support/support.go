@@ -10,6 +10,9 @@
 // ----------------------------------------------------------------------------
 
 // This package provides functions that help handle errors in main programs.
+// Functions return errors up to the program's run function.  Run logs the
+// error with its context, summarizes the warnings, and returns the exit code,
+// so deferred functions that save output always run.
 package support
 
 // ----------------------------------------------------------------------------
@@ -17,31 +20,156 @@ package support
 // ----------------------------------------------------------------------------
 
 import (
+	"errors"
+	"fmt"
 	"log/slog"
-	"os"
+	"strconv"
+
+	l "acorn_go/pkg/logging"
 )
 
 // ----------------------------------------------------------------------------
 // Types
 // ----------------------------------------------------------------------------
 
+// Kind classifies an error.
+type Kind int
+
+// Error is an error with the operation that failed and, where known, the
+// file and row being processed.
+type Error struct {
+	Kind Kind
+	Op   string
+	File string
+	Row  int
+	Err  error
+}
+
 // ----------------------------------------------------------------------------
 // Constants
 // ----------------------------------------------------------------------------
 
+const (
+	Other  Kind = iota // unclassified error
+	Input              // error reading or interpreting input data
+	Output             // error writing output
+	Usage              // error in the program's arguments or configuration
+)
+
+var kindNames = []string{"other", "input", "output", "usage"}
+
+// Exit codes returned by Run
+const (
+	ExitSuccess = 0
+	ExitFailure = 1
+)
+
+// ----------------------------------------------------------------------------
+// Factory Functions
+// ----------------------------------------------------------------------------
+
+// Wrap returns an error describing the failed operation.  A nil error
+// returns nil.
+func Wrap(err error, kind Kind, op string) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Kind: kind, Op: op, Err: err}
+}
+
+// WrapFile returns an error describing the failed operation on a file.  A
+// nil error returns nil.
+func WrapFile(err error, kind Kind, op string, file string) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Kind: kind, Op: op, File: file, Err: err}
+}
+
+// WrapRow returns an error describing the failed operation on a row of a
+// spreadsheet.  The row is the row number shown by Excel.  A nil error
+// returns nil.
+func WrapRow(err error, kind Kind, op string, file string, row int) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Kind: kind, Op: op, File: file, Row: row, Err: err}
+}
+
+// ----------------------------------------------------------------------------
+// Methods
+// ----------------------------------------------------------------------------
+
+// Error returns the description of the error.
+func (e *Error) Error() string {
+	var message = e.Op
+	if e.File != "" {
+		message += " " + e.File
+	}
+	if e.Row > 0 {
+		message += " row " + strconv.Itoa(e.Row)
+	}
+	return message + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// String returns the name of the kind of error.
+func (kind Kind) String() string {
+	var name = kindNames[Other]
+	if kind >= 0 && int(kind) < len(kindNames) {
+		name = kindNames[kind]
+	}
+	return name
+}
+
 // ----------------------------------------------------------------------------
-// Error Handling Functions
+// Program Functions
 // ----------------------------------------------------------------------------
 
-// check tests an error to see if it is null.  If not, it prints an
-// error message and exits the program.
-func Check(err error, message string) {
+// Run initializes logging for the command, calls the run function, and
+// returns the exit code.  An error returned by the run function is logged
+// with the file and row of the first support.Error in its chain.  Warnings
+// logged while the program ran are summarized.
+func Run(command string, run func() error) int {
+	var code = ExitSuccess
+
+	l.Init(command)
+	var err = run()
+	summarizeWarnings()
 	if err != nil {
-		logger := slog.New(slog.NewJSONHandler(os.Stderr, nil))
-		logger.Error(
-			message+err.Error(),
-			"error", err,
-		)
-		os.Exit(1)
+		l.Error(err.Error(), attrs(err)...)
+		code = ExitFailure
+	}
+	return code
+}
+
+// attrs returns the logging fields of an error.
+func attrs(err error) []any {
+	var result = []any{}
+	var supportErr *Error
+	if errors.As(err, &supportErr) {
+		result = append(result, slog.String("kind", supportErr.Kind.String()))
+		if supportErr.File != "" {
+			result = append(result, l.File(supportErr.File))
+		}
+		if supportErr.Row > 0 {
+			result = append(result, l.Row(supportErr.Row))
+		}
+	}
+	return result
+}
+
+// summarizeWarnings prints the number of each warning logged.
+func summarizeWarnings() {
+	var lines = l.Summary()
+	if len(lines) > 0 {
+		fmt.Println("Warnings:")
+		for _, line := range lines {
+			fmt.Println("  " + line)
+		}
 	}
 }
@@ -0,0 +1,68 @@
+// ----------------------------------------------------------------------------
+//
+// Support tests
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package support
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+// ----------------------------------------------------------------------------
+// Tests
+// ----------------------------------------------------------------------------
+
+// Test_Wrap checks the message and chain of a wrapped error.
+func Test_Wrap(t *testing.T) {
+	var cause = errors.New("invalid date")
+	var err = WrapRow(cause, Input, "processing donation", "donations.xlsx", 7)
+	var expected = "processing donation donations.xlsx row 7: invalid date"
+	if err.Error() != expected {
+		t.Error("incorrect message: " + err.Error())
+	}
+	if !errors.Is(err, cause) {
+		t.Error("wrapped error does not unwrap to the cause")
+	}
+	var supportErr *Error
+	if !errors.As(errors.Join(err, errors.New("closing")), &supportErr) || supportErr.Kind != Input {
+		t.Error("joined error does not contain the support error")
+	}
+	if Wrap(nil, Output, "saving") != nil || WrapFile(nil, Output, "saving", "a.xlsx") != nil {
+		t.Error("wrapping a nil error did not return nil")
+	}
+}
+
+// Test_Kind checks the names of the kinds of errors.
+func Test_Kind(t *testing.T) {
+	if Usage.String() != "usage" || Kind(42).String() != "other" {
+		t.Error("incorrect kind name: " + Usage.String() + ", " + Kind(42).String())
+	}
+}
+
+// Test_Run checks the exit codes returned by Run.
+func Test_Run(t *testing.T) {
+	var stderr = os.Stderr
+	os.Stderr, _ = os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	defer func() { os.Stderr = stderr }()
+
+	var code = Run("test", func() error { return nil })
+	if code != ExitSuccess {
+		t.Error("successful run returned an exit code of failure")
+	}
+	code = Run("test", func() error { return Wrap(errors.New("missing"), Usage, "parsing arguments") })
+	if code != ExitFailure {
+		t.Error("failed run returned an exit code of success")
+	}
+}
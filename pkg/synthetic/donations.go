@@ -0,0 +1,260 @@
+// ----------------------------------------------------------------------------
+//
+// Synthetic donations
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package synthetic
+
+// This file creates the donations of the fictitious donors and writes the
+// donations.xlsx spreadsheet.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"sort"
+	"strconv"
+
+	a "acorn_go/pkg/accounting"
+	s "acorn_go/pkg/spreadsheet"
+
+	dec "github.com/shopspring/decimal"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// receipt is a row in the donations spreadsheet.
+type receipt struct {
+	date      d.Date
+	transType string
+	num       string
+	payee     string
+	memo      string
+	amount    dec.Decimal
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Transaction types in the donations spreadsheet
+const (
+	typePayment = "Payment"
+	typeRefund  = "Refund"
+	typeDeposit = "Deposit"
+)
+
+// The trust's payments are excluded from the donation analyses.
+const excludedDonor = "Nadine L. Tolman Trust"
+
+const bank = "Truist Bank"
+
+const accountOperating = "1010 Cash:Cash in bank - operating"
+
+// Probability that a gift is refunded
+const refundRate = 0.02
+
+var donationHeadings = []string{
+	"Date",
+	"Payee",
+	"Type",
+	"Num",
+	"Memo",
+	"Account",
+	"Payment",
+}
+
+// ----------------------------------------------------------------------------
+// Methods
+// ----------------------------------------------------------------------------
+
+// writeDonations creates the receipts and writes the donations.xlsx
+// spreadsheet.
+func (gen *Generator) writeDonations() error {
+	var receipts = gen.createReceipts()
+	var output, err = s.New(gen.path(DonationFile), DonationTab)
+	if err != nil {
+		return err
+	}
+	writeHeadings(&output, donationHeadings)
+	for index, rcpt := range receipts {
+		var row = index + 2
+		s.WriteCellDate(&output, "A", row, rcpt.date)
+		s.WriteCell(&output, "B", row, rcpt.payee)
+		s.WriteCell(&output, "C", row, rcpt.transType)
+		s.WriteCell(&output, "D", row, rcpt.num)
+		s.WriteCell(&output, "E", row, rcpt.memo)
+		s.WriteCell(&output, "F", row, accountOperating)
+		s.WriteCellDecimal(&output, "G", row, rcpt.amount)
+	}
+	return save(&output)
+}
+
+// createReceipts returns the receipts of all donors sorted by date.
+func (gen *Generator) createReceipts() []receipt {
+	var receipts = []receipt{}
+
+	for _, donor := range gen.donors {
+		receipts = append(receipts, gen.donorReceipts(donor)...)
+	}
+	receipts = append(receipts, gen.otherReceipts()...)
+
+	sort.SliceStable(receipts, func(i, j int) bool {
+		return receipts[i].date.Before(receipts[j].date)
+	})
+	return receipts
+}
+
+// donorReceipts returns the gifts and refunds of a donor.
+func (gen *Generator) donorReceipts(donor *person) []receipt {
+	var receipts = []receipt{}
+	var years = gen.givingYears(donor)
+
+	var give = func(fy a.FYIndicator, offset int, amount dec.Decimal) {
+		var gift = receipt{
+			date:      gen.dateInMonth(fy, offset),
+			transType: typePayment,
+			payee:     donor.key(),
+			memo:      memo(offset),
+			amount:    amount,
+		}
+		if gen.chance(0.5) {
+			gift.num = strconv.Itoa(gen.between(1001, 9999))
+		}
+		receipts = append(receipts, gift)
+		//
+		// A few gifts are refunded the following month
+		//
+		if gen.chance(refundRate) && offset+1 < monthsInFiscalYear(fy) {
+			var refund = receipt{
+				date:      gen.dateInMonth(fy, offset+1),
+				transType: typeRefund,
+				payee:     donor.key(),
+				memo:      "Refund of gift",
+				amount:    amount.Neg(),
+			}
+			receipts = append(receipts, refund)
+		}
+	}
+
+	for _, fy := range years {
+		var months = monthsInFiscalYear(fy)
+		switch donor.profile {
+		case monthly:
+			var amount = gen.amount(25, 100, 25)
+			var stop = months
+			if fy == a.CurrentFiscalYear && gen.chance(0.4) {
+				stop = gen.between(2, months-2)
+			}
+			for offset := 0; offset < stop; offset++ {
+				give(fy, offset, amount)
+			}
+		case major:
+			for count := gen.between(1, 2); count > 0; count-- {
+				give(fy, gen.random.IntN(months), gen.amount(1000, 10000, 250))
+			}
+		case oneTime:
+			give(fy, gen.random.IntN(months), gen.amount(25, 500, 25))
+		default:
+			for count := gen.between(1, 3); count > 0; count-- {
+				give(fy, gen.random.IntN(months), gen.amount(25, 750, 25))
+			}
+		}
+	}
+	return receipts
+}
+
+// givingYears returns the fiscal years in which a donor gives.
+func (gen *Generator) givingYears(donor *person) []a.FYIndicator {
+	var years = []a.FYIndicator{}
+	var first = a.FY2023
+	var last = a.CurrentFiscalYear
+
+	switch donor.profile {
+	case newDonor:
+		first = a.CurrentFiscalYear - a.FYIndicator(gen.random.IntN(2))
+	case lapsed:
+		last = a.FY2023 + a.FYIndicator(gen.random.IntN(2))
+	case monthly:
+		first = a.FY2023 + a.FYIndicator(gen.random.IntN(2))
+	case oneTime:
+		first = a.FYIndicators[gen.random.IntN(a.NumFiscalYears)]
+		last = first
+	}
+	if donor.deceased {
+		last = min(last, a.FY2024)
+	}
+
+	for fy := first; fy <= last; fy++ {
+		var skip = false
+		switch donor.profile {
+		case reactivated:
+			skip = fy == a.FY2024 || fy == a.FY2025
+		case major:
+			skip = fy != first && gen.chance(0.2)
+		case loyal:
+			skip = fy != first && gen.chance(0.1)
+		}
+		if !skip {
+			years = append(years, fy)
+		}
+	}
+	return years
+}
+
+// otherReceipts returns receipts that are not donations from donors: the
+// trust's payments, which are excluded from the analyses, and bank interest.
+func (gen *Generator) otherReceipts() []receipt {
+	var receipts = []receipt{}
+
+	for _, fy := range a.FYIndicators {
+		var trust = receipt{
+			date:      gen.dateInMonth(fy, 3),
+			transType: typePayment,
+			payee:     excludedDonor,
+			memo:      "Annual distribution",
+			amount:    gen.amount(5000, 20000, 500),
+		}
+		receipts = append(receipts, trust)
+
+		for offset := 2; offset < monthsInFiscalYear(fy); offset += 3 {
+			var interest = receipt{
+				date:      fiscalDate(fy, offset, 28),
+				transType: typeDeposit,
+				payee:     bank,
+				memo:      "Interest",
+				amount:    dec.New(int64(gen.between(500, 4000)), -2),
+			}
+			receipts = append(receipts, interest)
+		}
+	}
+	return receipts
+}
+
+// ----------------------------------------------------------------------------
+// Support Functions
+// ----------------------------------------------------------------------------
+
+// memo returns the memo of a gift made in the specified month of the
+// fiscal year.  Month 0 is September.
+func memo(offset int) string {
+	var result = ""
+	switch offset {
+	case 2:
+		result = "Giving Tuesday"
+	case 3:
+		result = "Year-end appeal"
+	case 7:
+		result = "Spring celebration"
+	}
+	return result
+}
@@ -0,0 +1,274 @@
+// ----------------------------------------------------------------------------
+//
+// Synthetic donors
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package synthetic
+
+// This file creates fictitious donors and writes the donors.xlsx and
+// households.xlsx spreadsheets.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"strconv"
+	"strings"
+
+	s "acorn_go/pkg/spreadsheet"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// profile describes the giving pattern of a donor.
+type profile int
+
+// person is a fictitious donor.
+type person struct {
+	first     string
+	last      string
+	spouse    string
+	street    string
+	city      string
+	zip       string
+	email     string
+	household int
+	deceased  bool
+	profile   profile
+	partner   *person
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+const (
+	loyal       profile = iota // gives every year
+	newDonor                   // first gift in one of the last two years
+	lapsed                     // gave in early years only
+	reactivated                // gave, stopped, and returned
+	monthly                    // gives a fixed amount every month
+	major                      // gives $1,000 or more most years
+	oneTime                    // gave a single gift
+)
+
+// Probability of each profile in percent, in the order of the constants
+var profileWeights = []int{30, 15, 15, 8, 7, 10, 15}
+
+var firstNames = []string{
+	"James", "Mary", "Robert", "Patricia", "John", "Jennifer", "Michael",
+	"Linda", "David", "Elizabeth", "William", "Barbara", "Richard", "Susan",
+	"Joseph", "Jessica", "Thomas", "Karen", "Charles", "Sarah", "Daniel",
+	"Nancy", "Matthew", "Lisa", "Anthony", "Betty", "Mark", "Margaret",
+	"Steven", "Sandra", "Andrew", "Ashley", "Kenneth", "Dorothy", "Kevin",
+	"Emily", "Brian", "Donna", "George", "Michelle", "Edward", "Carol",
+	"Ronald", "Amanda", "Timothy", "Melissa", "Jason", "Deborah",
+}
+
+var lastNames = []string{
+	"Abernathy", "Blackwell", "Caldwell", "Dunbar", "Ellington", "Fairchild",
+	"Galloway", "Hargrove", "Ingram", "Jernigan", "Kensington", "Lockhart",
+	"Merriweather", "Northcutt", "Oakley", "Pendleton", "Quarles", "Ridgeway",
+	"Stanfield", "Thackeray", "Underwood", "Vanderford", "Whitfield", "Yarborough",
+	"Ashworth", "Beasley", "Crenshaw", "Dorsett", "Eubanks", "Faircloth",
+	"Greenleaf", "Holloway", "Ivey", "Kimbrough", "Langston", "McAllister",
+}
+
+var streetNames = []string{
+	"Oak Hollow Dr", "Chestnut St", "Maple Ridge Rd", "Magnolia Ln",
+	"Dogwood Ct", "Longleaf Pine Way", "Cardinal Dr", "Holly Springs Rd",
+	"Willow Bend Ct", "Azalea Ave", "Sycamore St", "Heritage Oaks Dr",
+}
+
+// Cities in North Carolina with their zip codes
+var cities = []string{"Apex", "Cary", "Raleigh", "Durham", "Chapel Hill", "Wake Forest"}
+
+var zips = map[string][]string{
+	"Apex":        {"27502", "27523", "27539"},
+	"Cary":        {"27511", "27513", "27518", "27519"},
+	"Raleigh":     {"27603", "27606", "27609", "27612", "27615"},
+	"Durham":      {"27705", "27707", "27713"},
+	"Chapel Hill": {"27514", "27516", "27517"},
+	"Wake Forest": {"27587"},
+}
+
+const state = "NC"
+
+// Email address used when the donor has no email
+const noEmail = "--"
+
+var householdHeadings = []string{
+	"Household",
+	"Donor full name",
+	"Mailing Name",
+	"Bill street",
+	"Bill city",
+	"Bill state",
+	"Bill zip",
+}
+
+var donorHeadings = []string{
+	"Donor full name",
+	"Invite Name",
+	"Email",
+	"Bill street",
+	"Bill city",
+	"Bill state",
+	"Bill zip",
+	"NumberHousehold",
+	"Deceased",
+}
+
+// ----------------------------------------------------------------------------
+// Methods
+// ----------------------------------------------------------------------------
+
+// key returns the name of the donor as it appears in Quickbooks.
+func (p *person) key() string {
+	return p.last + ", " + p.first
+}
+
+// inviteName returns the name used on invitations.
+func (p *person) inviteName() string {
+	var name = p.first + " " + p.last
+	if p.spouse != "" {
+		name = p.first + " and " + p.spouse + " " + p.last
+	}
+	return name
+}
+
+// createDonors creates the fictitious donors.  Some households have two
+// donors who give separately.
+func (gen *Generator) createDonors() {
+	for len(gen.donors) < gen.donorCount {
+		var donor = gen.createPerson()
+		gen.donors = append(gen.donors, donor)
+		//
+		// Spouse who gives separately from the same address
+		//
+		if donor.spouse != "" && gen.chance(0.15) && len(gen.donors) < gen.donorCount {
+			var partner = *donor
+			partner.first, partner.spouse = donor.spouse, donor.first
+			partner.email = gen.email(partner.first, partner.last)
+			partner.profile = gen.profile()
+			if !gen.names[partner.key()] {
+				gen.names[partner.key()] = true
+				partner.partner = donor
+				gen.donors = append(gen.donors, &partner)
+			}
+		}
+	}
+}
+
+// createPerson creates a donor with a unique name.
+func (gen *Generator) createPerson() *person {
+	var donor = person{}
+	for {
+		donor.first = gen.pick(firstNames)
+		donor.last = gen.pick(lastNames)
+		if !gen.names[donor.key()] {
+			break
+		}
+	}
+	gen.names[donor.key()] = true
+
+	donor.household = 1
+	if gen.chance(0.5) {
+		donor.spouse = gen.pick(firstNames)
+		donor.household = 2
+	}
+	donor.street = strconv.Itoa(gen.between(100, 9999)) + " " + gen.pick(streetNames)
+	donor.city = gen.pick(cities)
+	donor.zip = gen.pick(zips[donor.city])
+	donor.email = noEmail
+	if gen.chance(0.8) {
+		donor.email = gen.email(donor.first, donor.last)
+	}
+	donor.deceased = gen.chance(0.02)
+	donor.profile = gen.profile()
+	return &donor
+}
+
+// email returns an email address for the donor.
+func (gen *Generator) email(first string, last string) string {
+	var domain = gen.pick([]string{"example.com", "example.net", "example.org"})
+	return strings.ToLower(first + "." + last + "@" + domain)
+}
+
+// profile returns a random giving profile.
+func (gen *Generator) profile() profile {
+	var value = gen.random.IntN(100)
+	var result = oneTime
+	for index, weight := range profileWeights {
+		if value < weight {
+			result = profile(index)
+			break
+		}
+		value -= weight
+	}
+	return result
+}
+
+// writeDonors writes the donors.xlsx spreadsheet.
+func (gen *Generator) writeDonors() error {
+	var output, err = s.New(gen.path(DonorFile), DonorTab)
+	if err != nil {
+		return err
+	}
+	writeHeadings(&output, donorHeadings)
+	for index, donor := range gen.donors {
+		var row = index + 2
+		var deceased = ""
+		if donor.deceased {
+			deceased = "Yes"
+		}
+		s.WriteCell(&output, "A", row, donor.key())
+		s.WriteCell(&output, "B", row, donor.inviteName())
+		s.WriteCell(&output, "C", row, donor.email)
+		s.WriteCell(&output, "D", row, donor.street)
+		s.WriteCell(&output, "E", row, donor.city)
+		s.WriteCell(&output, "F", row, state)
+		s.WriteCell(&output, "G", row, donor.zip)
+		s.WriteCellInt(&output, "H", row, donor.household)
+		s.WriteCell(&output, "I", row, deceased)
+	}
+	return save(&output)
+}
+
+// writeHouseholds writes the households.xlsx spreadsheet.  Each spouse who
+// gives separately is placed in a household with the donor.  The mailing
+// name and address are given on the donor's row only.
+func (gen *Generator) writeHouseholds() error {
+	var output, err = s.New(gen.path(HouseholdFile), HouseholdTab)
+	if err != nil {
+		return err
+	}
+	writeHeadings(&output, householdHeadings)
+	var row = 2
+	for _, partner := range gen.donors {
+		if partner.partner == nil {
+			continue
+		}
+		var donor = partner.partner
+		var household = donor.key() + " and " + partner.first
+		s.WriteCell(&output, "A", row, household)
+		s.WriteCell(&output, "B", row, donor.key())
+		s.WriteCell(&output, "C", row, donor.inviteName())
+		s.WriteCell(&output, "D", row, donor.street)
+		s.WriteCell(&output, "E", row, donor.city)
+		s.WriteCell(&output, "F", row, state)
+		s.WriteCell(&output, "G", row, donor.zip)
+		s.WriteCell(&output, "A", row+1, household)
+		s.WriteCell(&output, "B", row+1, partner.key())
+		row += 2
+	}
+	return save(&output)
+}
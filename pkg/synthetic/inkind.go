@@ -0,0 +1,119 @@
+// ----------------------------------------------------------------------------
+//
+// Synthetic in-kind gifts
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package synthetic
+
+// This file creates in-kind gifts of stock, goods, and event services by
+// some of the donors and writes the inkind.xlsx spreadsheet.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"sort"
+
+	a "acorn_go/pkg/accounting"
+	s "acorn_go/pkg/spreadsheet"
+
+	dec "github.com/shopspring/decimal"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// inKindGift is a row in the in-kind spreadsheet.
+type inKindGift struct {
+	date        d.Date
+	donor       string
+	description string
+	value       dec.Decimal
+	method      string
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Probability that a loyal or major donor gives an in-kind gift in a year
+const inKindRate = 0.1
+
+// Descriptions of in-kind gifts with the method used to value them
+var inKindDescriptions = []string{
+	"shares of common stock",
+	"laptop computers for scholarship recipients",
+	"catering for the spring celebration",
+	"printing of the year-end appeal",
+	"gift basket for the silent auction",
+}
+
+var inKindMethods = []string{
+	"Closing price on date of transfer",
+	"Retail price of comparable items",
+	"Vendor invoice",
+	"Vendor invoice",
+	"Donor estimate",
+}
+
+var inKindHeadings = []string{
+	"Date",
+	"Donor full name",
+	"Description",
+	"Fair Market Value",
+	"Valuation Method",
+}
+
+// ----------------------------------------------------------------------------
+// Methods
+// ----------------------------------------------------------------------------
+
+// writeInKind writes the inkind.xlsx spreadsheet with the in-kind gifts in
+// date order.
+func (gen *Generator) writeInKind() error {
+	var gifts = []inKindGift{}
+	for _, donor := range gen.donors {
+		if (donor.profile != loyal && donor.profile != major) || donor.deceased {
+			continue
+		}
+		for _, fy := range a.FYIndicators {
+			if !gen.chance(inKindRate) {
+				continue
+			}
+			var index = gen.random.IntN(len(inKindDescriptions))
+			gifts = append(gifts, inKindGift{
+				date:        gen.date(fy),
+				donor:       donor.key(),
+				description: inKindDescriptions[index],
+				value:       gen.amount(100, 5000, 50),
+				method:      inKindMethods[index],
+			})
+		}
+	}
+	sort.SliceStable(gifts, func(i, j int) bool {
+		return gifts[i].date.Before(gifts[j].date)
+	})
+
+	var output, err = s.New(gen.path(InKindFile), InKindTab)
+	if err != nil {
+		return err
+	}
+	writeHeadings(&output, inKindHeadings)
+	for index, gift := range gifts {
+		var row = index + 2
+		s.WriteCellDate(&output, "A", row, gift.date)
+		s.WriteCell(&output, "B", row, gift.donor)
+		s.WriteCell(&output, "C", row, gift.description)
+		s.WriteCellDecimal(&output, "D", row, gift.value)
+		s.WriteCell(&output, "E", row, gift.method)
+	}
+	return save(&output)
+}
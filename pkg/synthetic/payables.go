@@ -0,0 +1,303 @@
+// ----------------------------------------------------------------------------
+//
+// Synthetic accounts payable
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package synthetic
+
+// This file creates scholarship bills, payments, and refunds for fictitious
+// recipients and writes the accounts_payable.xlsx and bills.xlsx spreadsheets.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"sort"
+
+	a "acorn_go/pkg/accounting"
+	s "acorn_go/pkg/spreadsheet"
+
+	dec "github.com/shopspring/decimal"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// student is a fictitious scholarship recipient.
+type student struct {
+	name      string
+	school    string
+	dependent bool
+	first     a.FYIndicator
+	last      a.FYIndicator
+}
+
+// apRow is a row in the accounts payable spreadsheet.
+type apRow struct {
+	date      d.Date
+	transType string
+	vendor    string
+	memo      string
+	account   string
+	billed    dec.Decimal
+	paid      dec.Decimal
+}
+
+// billRow is a row in the bills spreadsheet.
+type billRow struct {
+	date      d.Date
+	vendor    string
+	memo      string
+	billType  string
+	itemClass string
+	amount    dec.Decimal
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Transaction types in the accounts payable spreadsheet
+const (
+	typeBill         = "Bill"
+	typeBillPayment  = "Bill Payment"
+	typeVendorCredit = "Vendor Credit"
+)
+
+// Bill types in the bills spreadsheet
+const (
+	billGrant      = "Grant"
+	billTransfer   = "Transfer"
+	billIndividual = "Individual"
+	billDependent  = "Dependent"
+)
+
+const (
+	accountScholarship = "7040 Grants, contracts, & direct assistance:Awards & grants - individuals"
+	accountDependent   = "7045 Grants, contracts, & direct assistance:Awards & grants - dependents"
+	accountIndividual  = "7050 Grants, contracts, & direct assistance:Grants to individuals"
+)
+
+// Number of new scholarship recipients each fiscal year
+const (
+	minNewStudents = 6
+	maxNewStudents = 9
+)
+
+// Probability that a school returns a scholarship payment
+const creditRate = 0.05
+
+// Months of the fall and spring semester bills.  Month 0 is September.
+var semesters = []int{0, 4}
+
+var schools = []string{
+	"North Carolina State University",
+	"University of North Carolina Wilmington",
+	"University of North Carolina at Chapel Hill",
+	"Appalachian State University",
+	"East Carolina University",
+	"Wake Technical Community College",
+	"Durham Technical Community College",
+	"North Carolina Central University",
+}
+
+var studentLastNames = []string{
+	"Alvarez", "Bennett", "Chavez", "Dawson", "Estrada", "Fleming", "Gaines",
+	"Huang", "Izzard", "Jenkins", "Kowalski", "Lindqvist", "Moreno", "Nguyen",
+	"Okafor", "Patel", "Quintero", "Rasmussen", "Sandoval", "Torres",
+}
+
+var apHeadings = []string{
+	"Date",
+	"Type",
+	"Num",
+	"Payee",
+	"Memo",
+	"Account",
+	"Billed",
+	"Paid",
+}
+
+var billHeadings = []string{
+	"Date",
+	"Vendor",
+	"Memo",
+	"Bills",
+	"Item class",
+	"Amount",
+}
+
+// ----------------------------------------------------------------------------
+// Methods
+// ----------------------------------------------------------------------------
+
+// writePayables creates the scholarship transactions and writes the
+// accounts_payable.xlsx and bills.xlsx spreadsheets.
+func (gen *Generator) writePayables() error {
+	var apRows = []apRow{}
+	var billRows = []billRow{}
+
+	var bill = func(date d.Date, vendor string, memo string, account string, billType string, amount dec.Decimal) {
+		apRows = append(apRows, apRow{date, typeBill, vendor, memo, account, amount, dec.Zero})
+		billRows = append(billRows, billRow{date, vendor, memo, billType, itemClass(billType), amount})
+	}
+	var pay = func(date d.Date, vendor string, memo string, amount dec.Decimal) {
+		apRows = append(apRows, apRow{date, typeBillPayment, vendor, memo, accountOperating, dec.Zero, amount})
+	}
+	//
+	// Scholarships paid to schools
+	//
+	for _, stdnt := range gen.createStudents() {
+		var account = accountScholarship
+		var billType = billGrant
+		if stdnt.dependent {
+			account = accountDependent
+			billType = billDependent
+		}
+		var amount = gen.amount(1500, 4000, 500)
+		for fy := stdnt.first; fy <= stdnt.last; fy++ {
+			for _, offset := range semesters {
+				var billDate = fiscalDate(fy, offset, gen.between(1, 10))
+				var payDate = fiscalDate(fy, offset, gen.between(15, 28))
+				bill(billDate, stdnt.school, stdnt.name, account, billType, amount)
+				pay(payDate, stdnt.school, stdnt.name, amount)
+				//
+				// The school returns the payment with a vendor credit and
+				// a deposit.  Some scholarships are transferred to another
+				// school.
+				//
+				if gen.chance(creditRate) && offset+2 < monthsInFiscalYear(fy) {
+					var creditDate = fiscalDate(fy, offset+2, gen.between(1, 20))
+					apRows = append(apRows,
+						apRow{creditDate, typeVendorCredit, stdnt.school, stdnt.name, account, dec.Zero, amount},
+						apRow{creditDate, typeDeposit, stdnt.school, stdnt.name, accountOperating, amount, dec.Zero})
+					if gen.chance(0.5) {
+						var school = gen.pick(schools)
+						var transferDate = fiscalDate(fy, offset+2, 25)
+						bill(transferDate, school, stdnt.name, account, billTransfer, amount)
+						pay(transferDate, school, stdnt.name, amount)
+					}
+				}
+			}
+		}
+	}
+	//
+	// Grants paid directly to individuals
+	//
+	for _, fy := range a.FYIndicators {
+		for count := gen.between(1, 3); count > 0; count-- {
+			var name = gen.pick(firstNames) + " " + gen.pick(studentLastNames)
+			var date = gen.date(fy)
+			var amount = gen.amount(250, 1500, 250)
+			bill(date, name, name, accountIndividual, billIndividual, amount)
+			pay(date, name, name, amount)
+		}
+	}
+
+	sort.SliceStable(apRows, func(i, j int) bool {
+		return apRows[i].date.Before(apRows[j].date)
+	})
+	sort.SliceStable(billRows, func(i, j int) bool {
+		return billRows[i].date.Before(billRows[j].date)
+	})
+	var err = writeAPRows(gen.path(APFile), apRows)
+	if err == nil {
+		err = writeBillRows(gen.path(BillFile), billRows)
+	}
+	return err
+}
+
+// createStudents creates the scholarship recipients.  Each fiscal year,
+// new recipients receive scholarships for one to four years.
+func (gen *Generator) createStudents() []student {
+	var students = []student{}
+	var names = make(map[string]bool)
+
+	for _, fy := range a.FYIndicators {
+		for count := gen.between(minNewStudents, maxNewStudents); count > 0; count-- {
+			var stdnt = student{
+				school:    gen.pick(schools),
+				dependent: gen.chance(0.1),
+				first:     fy,
+				last:      min(fy+a.FYIndicator(gen.random.IntN(4)), a.CurrentFiscalYear),
+			}
+			for {
+				stdnt.name = gen.pick(firstNames) + " " + gen.pick(studentLastNames)
+				if !names[stdnt.name] {
+					break
+				}
+			}
+			names[stdnt.name] = true
+			students = append(students, stdnt)
+		}
+	}
+	return students
+}
+
+// ----------------------------------------------------------------------------
+// Support Functions
+// ----------------------------------------------------------------------------
+
+// itemClass returns the Quickbooks item class of a bill.
+func itemClass(billType string) string {
+	var result = "Scholarship"
+	switch billType {
+	case billDependent:
+		result = "Dependent scholarship"
+	case billIndividual:
+		result = "Individual grant"
+	}
+	return result
+}
+
+// writeAPRows writes the accounts payable spreadsheet.
+func writeAPRows(fileName string, rows []apRow) error {
+	var output, err = s.New(fileName, APTab)
+	if err != nil {
+		return err
+	}
+	writeHeadings(&output, apHeadings)
+	for index, item := range rows {
+		var row = index + 2
+		s.WriteCellDate(&output, "A", row, item.date)
+		s.WriteCell(&output, "B", row, item.transType)
+		s.WriteCell(&output, "C", row, "")
+		s.WriteCell(&output, "D", row, item.vendor)
+		s.WriteCell(&output, "E", row, item.memo)
+		s.WriteCell(&output, "F", row, item.account)
+		if !item.billed.IsZero() {
+			s.WriteCellDecimal(&output, "G", row, item.billed)
+		}
+		if !item.paid.IsZero() {
+			s.WriteCellDecimal(&output, "H", row, item.paid)
+		}
+	}
+	return save(&output)
+}
+
+// writeBillRows writes the bills spreadsheet.
+func writeBillRows(fileName string, rows []billRow) error {
+	var output, err = s.New(fileName, BillTab)
+	if err != nil {
+		return err
+	}
+	writeHeadings(&output, billHeadings)
+	for index, item := range rows {
+		var row = index + 2
+		s.WriteCellDate(&output, "A", row, item.date)
+		s.WriteCell(&output, "B", row, item.vendor)
+		s.WriteCell(&output, "C", row, item.memo)
+		s.WriteCell(&output, "D", row, item.billType)
+		s.WriteCell(&output, "E", row, item.itemClass)
+		s.WriteCellDecimal(&output, "F", row, item.amount)
+	}
+	return save(&output)
+}
@@ -0,0 +1,81 @@
+// ----------------------------------------------------------------------------
+//
+// Synthetic pledges
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package synthetic
+
+// This file creates pledges by some of the major donors and writes the
+// pledges.xlsx spreadsheet.  The pledges are not tied to the gifts, so the
+// pledge report finds pledges that are paid, behind, and overpaid.
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"strconv"
+
+	a "acorn_go/pkg/accounting"
+	"acorn_go/pkg/campaigns"
+	s "acorn_go/pkg/spreadsheet"
+)
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Probability that a living major donor makes a pledge
+const pledgeRate = 0.5
+
+var pledgeHeadings = []string{
+	"Pledge",
+	"Donor full name",
+	"Pledge Amount",
+	"Schedule",
+	"Installments",
+	"Start Date",
+	"Campaign",
+}
+
+// ----------------------------------------------------------------------------
+// Methods
+// ----------------------------------------------------------------------------
+
+// writePledges writes the pledges.xlsx spreadsheet.  A pledge is paid in
+// three annual or eight quarterly installments starting in FY2025.
+func (gen *Generator) writePledges() error {
+	var output, err = s.New(gen.path(PledgeFile), PledgeTab)
+	if err != nil {
+		return err
+	}
+	writeHeadings(&output, pledgeHeadings)
+	var row = 2
+	for _, donor := range gen.donors {
+		if donor.profile != major || donor.deceased || !gen.chance(pledgeRate) {
+			continue
+		}
+		var schedule, installments = "Annual", 3
+		if gen.chance(0.3) {
+			schedule, installments = "Quarterly", 8
+		}
+		var campaign = ""
+		if gen.chance(0.3) {
+			campaign = campaigns.YearEndAppeal
+		}
+		s.WriteCell(&output, "A", row, "P"+strconv.Itoa(row-1))
+		s.WriteCell(&output, "B", row, donor.key())
+		s.WriteCellDecimal(&output, "C", row, gen.amount(3000, 15000, 1500))
+		s.WriteCell(&output, "D", row, schedule)
+		s.WriteCellInt(&output, "E", row, installments)
+		s.WriteCellDate(&output, "F", row, fiscalDate(a.FY2025, gen.random.IntN(12), 1))
+		s.WriteCell(&output, "G", row, campaign)
+		row++
+	}
+	return save(&output)
+}
@@ -0,0 +1,241 @@
+// ----------------------------------------------------------------------------
+//
+// Synthetic data generator
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+// The synthetic package generates realistic but fictitious versions of the
+// spreadsheets exported from Quickbooks and the donor address list.  The
+// spreadsheets have the same tabs and headings as the real spreadsheets, so
+// every program can be run without access to the organization's data.  The
+// generator is seeded, so the same seed always produces the same data.
+package synthetic
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"errors"
+	"math/rand/v2"
+	"path/filepath"
+	"strconv"
+
+	a "acorn_go/pkg/accounting"
+	s "acorn_go/pkg/spreadsheet"
+
+	dec "github.com/shopspring/decimal"
+	d "github.com/waysys/waydate/pkg/date"
+)
+
+// ----------------------------------------------------------------------------
+// Types
+// ----------------------------------------------------------------------------
+
+// Generator holds the state of the random number generator and the
+// people created so far.
+type Generator struct {
+	random     *rand.Rand
+	dir        string
+	donorCount int
+	donors     []*person
+	names      map[string]bool
+}
+
+// ----------------------------------------------------------------------------
+// Constants
+// ----------------------------------------------------------------------------
+
+// Names and tabs of the generated spreadsheets.  These match the
+// spreadsheets read by the programs.
+const (
+	DonationFile  = "donations.xlsx"
+	DonationTab   = "Worksheet"
+	DonorFile     = "donors.xlsx"
+	DonorTab      = "Sheet1"
+	HouseholdFile = "households.xlsx"
+	HouseholdTab  = "Sheet1"
+	APFile        = "accounts_payable.xlsx"
+	APTab         = "Worksheet"
+	BillFile      = "bills.xlsx"
+	BillTab       = "Sheet1"
+	PledgeFile    = "pledges.xlsx"
+	PledgeTab     = "Sheet1"
+	InKindFile    = "inkind.xlsx"
+	InKindTab     = "Sheet1"
+)
+
+// Files lists the names of the generated spreadsheets.
+var Files = []string{
+	DonationFile,
+	DonorFile,
+	HouseholdFile,
+	APFile,
+	BillFile,
+	PledgeFile,
+	InKindFile,
+}
+
+// DefaultDonorCount is the number of donors generated when no count is
+// specified.
+const DefaultDonorCount = 150
+
+// MaxDonorCount is the largest number of donors that can be generated with
+// unique names.
+const MaxDonorCount = 1000
+
+// Calendar year in which the first fiscal year, FY2023, begins
+const firstFiscalYearBegins = 2022
+
+// Number of months in the current fiscal year with data.  The current
+// fiscal year ends with July, the current month used by the programs.
+const currentFiscalYearMonths = 11
+
+// ----------------------------------------------------------------------------
+// Factory Functions
+// ----------------------------------------------------------------------------
+
+// New returns a generator that writes spreadsheets to the specified
+// directory.  The seed determines the data generated.
+func New(dir string, seed uint64, donorCount int) (Generator, error) {
+	var generator Generator
+	var err error = nil
+	//
+	// Preconditions
+	//
+	if dir == "" {
+		err = errors.New("directory must not be an empty string")
+		return generator, err
+	}
+	if donorCount < 1 || donorCount > MaxDonorCount {
+		err = errors.New("number of donors must be from 1 to " + strconv.Itoa(MaxDonorCount))
+		return generator, err
+	}
+	generator = Generator{
+		random:     rand.New(rand.NewPCG(seed, seed)),
+		dir:        dir,
+		donorCount: donorCount,
+		donors:     []*person{},
+		names:      make(map[string]bool),
+	}
+	return generator, err
+}
+
+// ----------------------------------------------------------------------------
+// Methods
+// ----------------------------------------------------------------------------
+
+// Generate writes the donation, donor, household, accounts payable, bill,
+// pledge, and in-kind spreadsheets.
+func (gen *Generator) Generate() error {
+	var err error = nil
+
+	gen.createDonors()
+	err = gen.writeDonors()
+	if err == nil {
+		err = gen.writeHouseholds()
+	}
+	if err == nil {
+		err = gen.writeDonations()
+	}
+	if err == nil {
+		err = gen.writePayables()
+	}
+	if err == nil {
+		err = gen.writePledges()
+	}
+	if err == nil {
+		err = gen.writeInKind()
+	}
+	return err
+}
+
+// path returns the full path name of a generated spreadsheet.
+func (gen *Generator) path(fileName string) string {
+	return filepath.Join(gen.dir, fileName)
+}
+
+// chance returns true with the specified probability.
+func (gen *Generator) chance(probability float64) bool {
+	return gen.random.Float64() < probability
+}
+
+// between returns a random integer from low to high inclusive.
+func (gen *Generator) between(low int, high int) int {
+	return low + gen.random.IntN(high-low+1)
+}
+
+// pick returns a random element of a slice of strings.
+func (gen *Generator) pick(values []string) string {
+	return values[gen.random.IntN(len(values))]
+}
+
+// amount returns a random amount from low to high rounded to the
+// specified increment.
+func (gen *Generator) amount(low int, high int, increment int) dec.Decimal {
+	var value = gen.between(low/increment, high/increment) * increment
+	return dec.NewFromInt(int64(value))
+}
+
+// date returns a random date in the specified fiscal year.
+func (gen *Generator) date(fy a.FYIndicator) d.Date {
+	return gen.dateInMonth(fy, gen.random.IntN(monthsInFiscalYear(fy)))
+}
+
+// dateInMonth returns a random date in the specified month of the fiscal
+// year.  Month 0 is September.
+func (gen *Generator) dateInMonth(fy a.FYIndicator, offset int) d.Date {
+	return fiscalDate(fy, offset, gen.between(1, 28))
+}
+
+// ----------------------------------------------------------------------------
+// Support Functions
+// ----------------------------------------------------------------------------
+
+// monthsInFiscalYear returns the number of months with data in the
+// fiscal year.
+func monthsInFiscalYear(fy a.FYIndicator) int {
+	var months = 12
+	if fy == a.CurrentFiscalYear {
+		months = currentFiscalYearMonths
+	}
+	return months
+}
+
+// fiscalDate returns the date on the specified day of the month of a fiscal
+// year.  Month 0 is September.
+func fiscalDate(fy a.FYIndicator, offset int, day int) d.Date {
+	var year = firstFiscalYearBegins + int(fy)
+	var month = 9 + offset
+	if month > 12 {
+		month -= 12
+		year++
+	}
+	var date, err = d.New(d.Month(month), d.Day(day), d.Year(year))
+	if err != nil {
+		panic(err)
+	}
+	return date
+}
+
+// writeHeadings writes the headings in the first row of a sheet.
+func writeHeadings(output *s.SpreadsheetFile, headings []string) {
+	var column = "A"
+	for _, heading := range headings {
+		s.WriteCell(output, column, 1, heading)
+		column = s.NextLetter(column)
+	}
+}
+
+// save saves and closes a generated spreadsheet.
+func save(output *s.SpreadsheetFile) error {
+	var err = output.Save()
+	if err == nil {
+		err = output.Close()
+	}
+	return err
+}
@@ -0,0 +1,296 @@
+// ----------------------------------------------------------------------------
+//
+// Synthetic data generator tests
+//
+// Author: William Shaffer
+//
+// Copyright (c) 2026 William Shaffer All Rights Reserved
+//
+// ----------------------------------------------------------------------------
+
+package synthetic
+
+// ----------------------------------------------------------------------------
+// Imports
+// ----------------------------------------------------------------------------
+
+import (
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	dns "acorn_go/pkg/donations"
+	dn "acorn_go/pkg/donors"
+	"acorn_go/pkg/pledges"
+	s "acorn_go/pkg/spreadsheet"
+)
+
+// ----------------------------------------------------------------------------
+// Support Functions
+// ----------------------------------------------------------------------------
+
+// generate writes the spreadsheets to a temporary directory and returns
+// the directory.
+func generate(t *testing.T, seed uint64) string {
+	var dir = t.TempDir()
+	var generator, err = New(dir, seed, DefaultDonorCount)
+	if err != nil {
+		t.Fatal("error creating generator: " + err.Error())
+	}
+	err = generator.Generate()
+	if err != nil {
+		t.Fatal("error generating spreadsheets: " + err.Error())
+	}
+	return dir
+}
+
+// read reads a generated spreadsheet.
+func read(t *testing.T, dir string, fileName string, tab string) s.Spreadsheet {
+	var sprdsht, err = s.ProcessData(filepath.Join(dir, fileName), tab)
+	if err != nil {
+		t.Fatal("error reading " + fileName + ": " + err.Error())
+	}
+	return sprdsht
+}
+
+// ----------------------------------------------------------------------------
+// Test functions
+// ----------------------------------------------------------------------------
+
+// Test_New checks the preconditions of the generator.
+func Test_New(t *testing.T) {
+	var _, err = New("", 1, DefaultDonorCount)
+	if err == nil {
+		t.Error("empty directory was accepted")
+	}
+	_, err = New(t.TempDir(), 1, 0)
+	if err == nil {
+		t.Error("zero donors was accepted")
+	}
+	_, err = New(t.TempDir(), 1, MaxDonorCount+1)
+	if err == nil {
+		t.Error("too many donors was accepted")
+	}
+}
+
+// Test_Donations checks that the generated donations and donors can be
+// read by the donation and donor packages.
+func Test_Donations(t *testing.T) {
+	var dir = generate(t, 1)
+	var donationSheet = read(t, dir, DonationFile, DonationTab)
+	var donorSheet = read(t, dir, DonorFile, DonorTab)
+
+	var donationList, err = dns.NewDonationList(&donationSheet)
+	if err != nil {
+		t.Fatal("error creating donation list: " + err.Error())
+	}
+	var donorList dn.DonorList
+	donorList, err = dn.NewDonorAddressList(&donorSheet)
+	if err != nil {
+		t.Fatal("error creating donor list: " + err.Error())
+	}
+	if donorList.Count() != DefaultDonorCount {
+		t.Error("wrong number of donors: " + strconv.Itoa(donorList.Count()))
+	}
+	//
+	// Every donor in the donation list must have an address
+	//
+	for _, key := range donationList.DonorKeys() {
+		if !donorList.Contains(key) {
+			t.Error("donor is not in donor list: " + key)
+		}
+	}
+	if donationList.Contains(excludedDonor) {
+		t.Error("excluded donor is in donation list")
+	}
+	//
+	// The donations include refunds, which the loaders skip
+	//
+	var refunds = 0
+	for row := 1; row < donationSheet.Size(); row++ {
+		var value, _ = donationSheet.Cell(row, "Type")
+		if value == typeRefund {
+			refunds++
+		}
+	}
+	if refunds == 0 {
+		t.Error("no refunds were generated")
+	}
+}
+
+// Test_Households checks that the generated households can be read and that
+// every payee in a household is a donor.
+func Test_Households(t *testing.T) {
+	var dir = generate(t, 1)
+	var householdSheet = read(t, dir, HouseholdFile, HouseholdTab)
+	var donorSheet = read(t, dir, DonorFile, DonorTab)
+
+	var households, err = dn.NewHouseholdListFromSheet(&householdSheet)
+	if err != nil {
+		t.Fatal("error creating household list: " + err.Error())
+	}
+	var donorList dn.DonorList
+	donorList, err = dn.NewDonorAddressList(&donorSheet)
+	if err != nil {
+		t.Fatal("error creating donor list: " + err.Error())
+	}
+	if households.Count() == 0 {
+		t.Error("no households were generated")
+	}
+	for _, key := range households.Keys() {
+		var household = households.Get(key)
+		if len(household.Members()) != 2 || household.Address().Street == "" {
+			t.Error("incorrect household: " + key)
+		}
+		for _, payee := range household.Members() {
+			if !donorList.Contains(payee) {
+				t.Error("payee is not in donor list: " + payee)
+			}
+		}
+	}
+	var rollup = households.Rollup(donorList)
+	if rollup.Count() != donorList.Count()-households.Count() {
+		t.Error("wrong number of household donors: " + strconv.Itoa(rollup.Count()))
+	}
+}
+
+// Test_Payables checks the headings of the accounts payable and bills
+// spreadsheets and that every bill has an accounts payable transaction.
+func Test_Payables(t *testing.T) {
+	var dir = generate(t, 1)
+	var apSheet = read(t, dir, APFile, APTab)
+	var billSheet = read(t, dir, BillFile, BillTab)
+
+	var bills = make(map[string]bool)
+	var credits = 0
+	for row := 1; row < apSheet.Size(); row++ {
+		var transType, _ = apSheet.Cell(row, "Type")
+		var date, _ = apSheet.Cell(row, "Date")
+		var vendor, _ = apSheet.Cell(row, "Payee")
+		var memo, _ = apSheet.Cell(row, "Memo")
+		var _, err = apSheet.CellDecimal(row, "Billed")
+		if err == nil {
+			_, err = apSheet.CellDecimal(row, "Paid")
+		}
+		if err == nil {
+			_, err = apSheet.Cell(row, "Account")
+		}
+		if err != nil {
+			t.Fatal("error reading accounts payable: " + err.Error())
+		}
+		switch transType {
+		case typeBill:
+			bills[date+vendor+memo] = true
+		case typeVendorCredit:
+			credits++
+		}
+	}
+	if credits == 0 {
+		t.Error("no vendor credits were generated")
+	}
+
+	var types = make(map[string]bool)
+	for row := 1; row < billSheet.Size(); row++ {
+		var date, _ = billSheet.Cell(row, "Date")
+		var vendor, _ = billSheet.Cell(row, "Vendor")
+		var memo, _ = billSheet.Cell(row, "Memo")
+		var billType, err = billSheet.Cell(row, "Bills")
+		if err == nil {
+			_, err = billSheet.Cell(row, "Item class")
+		}
+		if err != nil {
+			t.Fatal("error reading bills: " + err.Error())
+		}
+		if !bills[date+vendor+memo] {
+			t.Error("bill has no accounts payable transaction: " + vendor + " " + memo)
+		}
+		types[billType] = true
+	}
+	for _, billType := range []string{billGrant, billDependent, billIndividual} {
+		if !types[billType] {
+			t.Error("no bills of type: " + billType)
+		}
+	}
+}
+
+// Test_Pledges checks that the generated pledges can be read and that every
+// pledge is made by a donor.
+func Test_Pledges(t *testing.T) {
+	var dir = generate(t, 1)
+	var pledgeSheet = read(t, dir, PledgeFile, PledgeTab)
+	var donorSheet = read(t, dir, DonorFile, DonorTab)
+
+	var ledger, err = pledges.NewLedger(&pledgeSheet)
+	if err != nil {
+		t.Fatal("error creating pledge ledger: " + err.Error())
+	}
+	var donorList dn.DonorList
+	donorList, err = dn.NewDonorAddressList(&donorSheet)
+	if err != nil {
+		t.Fatal("error creating donor list: " + err.Error())
+	}
+	if ledger.Count() == 0 {
+		t.Error("no pledges were generated")
+	}
+	for _, pledge := range ledger.Pledges() {
+		if !donorList.Contains(pledge.Donor()) {
+			t.Error("pledge donor is not in donor list: " + pledge.Donor())
+		}
+	}
+}
+
+// Test_InKind checks that the generated in-kind gifts can be added to the
+// donation list and that every gift is made by a donor.
+func Test_InKind(t *testing.T) {
+	var dir = generate(t, 1)
+	var inKindSheet = read(t, dir, InKindFile, InKindTab)
+	var donorSheet = read(t, dir, DonorFile, DonorTab)
+
+	var donationList = make(dns.DonationList)
+	var count, err = donationList.AddInKind(&inKindSheet)
+	if err != nil {
+		t.Fatal("error adding in-kind gifts: " + err.Error())
+	}
+	var donorList dn.DonorList
+	donorList, err = dn.NewDonorAddressList(&donorSheet)
+	if err != nil {
+		t.Fatal("error creating donor list: " + err.Error())
+	}
+	if count == 0 {
+		t.Error("no in-kind gifts were generated")
+	}
+	for _, key := range donationList.DonorKeys() {
+		if !donorList.Contains(key) {
+			t.Error("in-kind donor is not in donor list: " + key)
+		}
+	}
+}
+
+// Test_Seed checks that the same seed produces the same data.
+func Test_Seed(t *testing.T) {
+	var first = read(t, generate(t, 7), DonationFile, DonationTab)
+	var second = read(t, generate(t, 7), DonationFile, DonationTab)
+	var other = read(t, generate(t, 8), DonationFile, DonationTab)
+
+	var same = func(a *s.Spreadsheet, b *s.Spreadsheet) bool {
+		if a.Size() != b.Size() {
+			return false
+		}
+		for row := 1; row < a.Size(); row++ {
+			for _, heading := range donationHeadings {
+				var x, _ = a.Cell(row, heading)
+				var y, _ = b.Cell(row, heading)
+				if x != y {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	if !same(&first, &second) {
+		t.Error("the same seed produced different donations")
+	}
+	if same(&first, &other) {
+		t.Error("different seeds produced the same donations")
+	}
+}